@@ -16,6 +16,7 @@ package cmd
 import (
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/spf13/cobra"
 )
@@ -23,11 +24,50 @@ import (
 //Analytics turns analytics on/off
 func Analytics() *cobra.Command {
 	var disable bool
+	var url string
 	cmd := &cobra.Command{
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#analytics"),
 		Use:   "analytics",
 		Short: "Enable / Disable analytics",
+		Long: `Enable / Disable analytics
+
+The okteto CLI sends anonymous usage events to help us improve it.
+They are sent to Mixpanel (https://api.mixpanel.com), or to the endpoint set with --url, as 'GET <url>/track?data=<payload>' requests,
+where the payload is the base64-encoded JSON event. Events are skipped for the rest of the command if the endpoint can't be reached.
+
+Events: Up, Up Error, Up Duration Time, Initial Sync Duration Time, Reconnect, Sync Error, Sync Reset Database, Manifest Has Changed,
+Down, DownVolumes, Push, Build, BuildTransientError, Status, Doctor, Exec, Logs, Deploy Stack, Destroy Stack, Stack Field Not Supported,
+Create Manifest, Login, Signup, Context, Kubeconfig, Namespace, CreateNamespace, DeleteNamespace, DeployPreview, DestroyPreview and Disable Analytics.
+
+Every event includes:
+    the event name and whether the command succeeded
+    the operating system, the okteto CLI version and the origin of the command ('cli', or the value of OKTETO_ORIGIN)
+    the name and the type of the okteto context (Cloud, Staging, Enterprise or Kubernetes)
+    an anonymous id of your machine, or your Okteto user id if you are logged in
+
+Some events add:
+    Up: the name of the development container, and whether it runs interactively, it's a single service and it uses divert
+    Up Duration Time and Initial Sync Duration Time: the elapsed time
+    Push: the URL of the Okteto Registry
+    Build and BuildTransientError: the URL of the Okteto build service
+    Status: whether --info was used
+    Deploy Stack: whether the stack is a compose file
+    Create Manifest: the language of the manifest
+    Stack Field Not Supported: the unsupported stack field
+
+No source code, file names, environment variables, secrets or command arguments are collected.
+
+'okteto analytics --disable' persists the choice in $HOME/.okteto/analytics.json, and 'okteto analytics' enables them again.
+Set OKTETO_DISABLE_ANALYTICS=1 to disable them without persisting the choice. It takes precedence over the analytics file and this command:
+no event is sent, and the analytics file and the machine id aren't created. Analytics are also disabled when telemetry is disabled in your Okteto context.
+`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("url") {
+				if err := setAnalyticsURL(url); err != nil {
+					return err
+				}
+			}
+
 			if disable {
 				return disableAnalytics()
 			}
@@ -35,10 +75,27 @@ func Analytics() *cobra.Command {
 			return enableAnalytics()
 		},
 	}
-	cmd.Flags().BoolVarP(&disable, "disable", "d", false, "disable analytics")
+	cmd.Flags().BoolVarP(&disable, "disable", "d", false, "disable analytics. Set OKTETO_DISABLE_ANALYTICS=1 to disable them without persisting the choice")
+	cmd.Flags().StringVarP(&url, "url", "", "", "send analytics to a self-hosted endpoint instead of the default one. Use an empty value to restore the default endpoint")
 	return cmd
 }
 
+func setAnalyticsURL(url string) error {
+	if err := analytics.SetURL(url); err != nil {
+		return errors.UserError{
+			E:    err,
+			Hint: "Use a URL like 'https://analytics.mycompany.com'",
+		}
+	}
+
+	if url == "" {
+		log.Success("Analytics will be sent to the default endpoint")
+		return nil
+	}
+	log.Success("Analytics will be sent to %s", url)
+	return nil
+}
+
 func disableAnalytics() error {
 	if err := analytics.Disable(); err != nil {
 		return err
@@ -54,5 +111,8 @@ func enableAnalytics() error {
 	}
 
 	log.Success("Analytics have been enabled")
+	if analytics.DisabledByEnv() {
+		log.Warning("Analytics remain disabled while %s is set", analytics.DisableEnvVar)
+	}
 	return nil
 }
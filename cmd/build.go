@@ -22,7 +22,9 @@ import (
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/cmd/build"
+	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/spf13/cobra"
 )
@@ -30,11 +32,24 @@ import (
 //Build build and optionally push a Docker image
 func Build(ctx context.Context) *cobra.Command {
 
-	options := build.BuildOptions{}
+	options := build.BuildOptions{EnableContextCheck: true}
+	manifest := ""
 	cmd := &cobra.Command{
-		Use:   "build [PATH]",
+		Use:   "build [PATH|NAME]",
 		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#build"),
 		Short: "Build (and optionally push) a Docker image",
+		Long: `Build (and optionally push) a Docker image
+
+PATH builds the directory PATH. NAME builds the image NAME of the 'build' section of your okteto manifest.
+
+The 'args' of the okteto manifest are passed to the build as build arguments, after expanding them:
+    $VAR and ${VAR} are replaced by the value of the local environment variable VAR. Use $$ to write a literal $
+    $(command) is replaced by the output of command, run in your local shell from the current folder.
+        Trailing newlines are removed, and the build fails if the command fails
+    \$( keeps a literal $(
+
+The same rules apply to the 'args' of 'image' and 'push' in okteto up --build and okteto push.
+`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			if err := contextCMD.Init(ctx); err != nil {
@@ -46,13 +61,23 @@ func Build(ctx context.Context) *cobra.Command {
 				path = args[0]
 			}
 
-			if err := utils.CheckIfDirectory(path); err != nil {
-				return fmt.Errorf("invalid build context: %s", err.Error())
-			}
-			options.Path = path
+			if len(args) == 1 && utils.CheckIfDirectory(path) != nil {
+				b, err := getManifestBuild(manifest, path)
+				if err != nil {
+					return err
+				}
+				if err := setManifestBuildOptions(ctx, &options, b); err != nil {
+					return err
+				}
+			} else {
+				if err := utils.CheckIfDirectory(path); err != nil {
+					return fmt.Errorf("invalid build context: %s", err.Error())
+				}
+				options.Path = path
 
-			if options.File == "" {
-				options.File = filepath.Join(path, "Dockerfile")
+				if options.File == "" {
+					options.File = filepath.Join(path, "Dockerfile")
+				}
 			}
 
 			if err := utils.CheckIfRegularFile(options.File); err != nil {
@@ -66,7 +91,8 @@ func Build(ctx context.Context) *cobra.Command {
 			}
 
 			ctx := context.Background()
-			if err := build.Run(ctx, "", options); err != nil {
+			digest, err := build.Run(ctx, "", options)
+			if err != nil {
 				analytics.TrackBuild(okteto.Context().Buildkit, false)
 				return err
 			}
@@ -76,6 +102,11 @@ func Build(ctx context.Context) *cobra.Command {
 				log.Information("Your image won't be pushed. To push your image specify the flag '-t'.")
 			} else {
 				log.Success(fmt.Sprintf("Image '%s' successfully pushed", options.Tag))
+				if digest != "" {
+					if err := outputImageDigest(options.Tag, digest, ""); err != nil {
+						return err
+					}
+				}
 			}
 
 			analytics.TrackBuild(okteto.Context().Buildkit, true)
@@ -84,12 +115,57 @@ func Build(ctx context.Context) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&options.File, "file", "f", "", "name of the Dockerfile (Default is 'PATH/Dockerfile')")
+	cmd.Flags().StringVarP(&manifest, "manifest", "", utils.DefaultDevManifest, "path to the okteto manifest defining the image NAME in its 'build' section")
 	cmd.Flags().StringVarP(&options.Tag, "tag", "t", "", "name and optionally a tag in the 'name:tag' format (it is automatically pushed)")
 	cmd.Flags().StringVarP(&options.Target, "target", "", "", "set the target build stage to build")
 	cmd.Flags().BoolVarP(&options.NoCache, "no-cache", "", false, "do not use cache when building the image")
+	cmd.Flags().StringArrayVar(&options.NoCacheFilter, "no-cache-filter", nil, "do not use cache when building the given stage of the Dockerfile (can be set more than once)")
 	cmd.Flags().StringArrayVar(&options.CacheFrom, "cache-from", nil, "cache source images")
-	cmd.Flags().StringVarP(&options.OutputMode, "progress", "", "tty", "show plain/tty build output")
+	cmd.Flags().StringVarP(&options.OutputMode, "progress", "", "tty", "show plain/tty/rawjson build output")
 	cmd.Flags().StringArrayVar(&options.BuildArgs, "build-arg", nil, "set build-time variables")
+	cmd.Flags().StringVarP(&options.Platform, "platform", "", "", "target platforms of the build, e.g. 'linux/amd64,linux/arm64' (multiple platforms require a BuildKit instance)")
+	cmd.Flags().BoolVarP(&options.OCIMediaTypes, "oci-mediatypes", "", false, "push the image using OCI media types instead of Docker media types")
+	cmd.Flags().BoolVarP(&options.Force, "force", "", false, "build even if the build context is your home directory or it is too large")
 	cmd.Flags().StringArrayVar(&options.Secrets, "secret", nil, "secret files exposed to the build. Format: id=mysecret,src=/local/secret")
+	cmd.Flags().StringArrayVar(&options.SecretCommands, "secret-command", nil, "command whose output is exposed to the build as a secret. Format: id=mysecret,cmd=<command>")
 	return cmd
 }
+
+// getManifestBuild returns the image called name of the 'build' section of the okteto manifest
+func getManifestBuild(manifest, name string) (*model.BuildInfo, error) {
+	dev, err := utils.LoadDev(manifest, "", "")
+	if err != nil {
+		if errors.IsNotExist(err) {
+			return nil, fmt.Errorf("invalid build context: '%s' is not a directory and there is no okteto manifest defining it in its 'build' section", name)
+		}
+		return nil, err
+	}
+	b, ok := dev.Build[name]
+	if !ok {
+		return nil, fmt.Errorf("invalid build context: '%s' is neither a directory nor an image of the 'build' section of '%s'", name, manifest)
+	}
+	return b, nil
+}
+
+// setManifestBuildOptions sets the build options of an image of the 'build' section of the okteto manifest.
+// The command flags take precedence over the values of the manifest
+func setManifestBuildOptions(ctx context.Context, options *build.BuildOptions, b *model.BuildInfo) error {
+	options.Path = b.Context
+	if options.File == "" {
+		options.File = b.Dockerfile
+	}
+	if options.Tag == "" {
+		options.Tag = b.Name
+	}
+	if options.Target == "" {
+		options.Target = b.Target
+	}
+	options.CacheFrom = append(append([]string{}, b.CacheFrom...), options.CacheFrom...)
+
+	args, err := build.ExpandBuildArgs(ctx, b.Args)
+	if err != nil {
+		return err
+	}
+	options.BuildArgs = append(model.SerializeBuildArgs(args), options.BuildArgs...)
+	return nil
+}
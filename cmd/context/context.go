@@ -36,6 +36,7 @@ type ContextOptions struct {
 	Namespace  string
 	Builder    string
 	OnlyOkteto bool
+	Insecure   bool
 }
 
 // Context points okteto to a cluster.
@@ -120,10 +121,12 @@ Or show a list of available options with:
 	}
 
 	cmd.AddCommand(List())
+	cmd.AddCommand(Show())
 	cmd.Flags().StringVarP(&ctxOptions.Token, "token", "t", "", "API token for authentication")
 	cmd.Flags().StringVarP(&ctxOptions.Namespace, "namespace", "n", "", "namespace of your okteto context")
 	cmd.Flags().StringVarP(&ctxOptions.Builder, "builder", "b", "", "url of the builder service")
 	cmd.Flags().BoolVarP(&ctxOptions.OnlyOkteto, "okteto", "", false, "only shows okteto cluster options")
+	cmd.Flags().BoolVarP(&ctxOptions.Insecure, "insecure", "", false, "skip the verification of the TLS certificate of the okteto instance. The setting is saved in the okteto context")
 	return cmd
 }
 
@@ -132,6 +135,11 @@ func runContext(ctx context.Context, oktetoContext string, ctxOptions *ContextOp
 	kubeconfigFile := config.GetKubeconfigPath()
 
 	if okteto.IsOktetoURL(oktetoContext) {
+		if ctxOptions.Insecure {
+			log.Warning("The TLS certificate of '%s' won't be verified. Don't use '--insecure' with okteto instances you don't trust", oktetoContext)
+		}
+		okteto.SetInsecureSkipTLSVerify(oktetoContext, ctxOptions.Insecure)
+
 		user, err := login.AuthenticateToOktetoCluster(ctx, oktetoContext, ctxOptions.Token)
 		if err != nil {
 			return err
@@ -140,8 +148,9 @@ func runContext(ctx context.Context, oktetoContext string, ctxOptions *ContextOp
 		octxStore := okteto.ContextStore()
 		octxStore.CurrentContext = oktetoContext
 		octxStore.Contexts[oktetoContext] = &okteto.OktetoContext{
-			Name:  oktetoContext,
-			Token: user.Token,
+			Name:     oktetoContext,
+			Token:    user.Token,
+			Insecure: okteto.IsInsecureSkipTLSVerify(oktetoContext),
 		}
 
 		oktetoClient, err := okteto.NewOktetoClient()
@@ -15,7 +15,10 @@ package context
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"sort"
+	"text/tabwriter"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -30,25 +33,38 @@ func List() *cobra.Command {
 		Args:    utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#context"),
 		Short:   "Lists okteto contexts",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			oCtxs := okteto.ContextStore()
-			contexts := make([]string, 0)
-			for name := range oCtxs.Contexts {
-				if name == oCtxs.CurrentContext {
-					contexts = append(contexts, fmt.Sprintf("* %s", name))
-				} else {
-					contexts = append(contexts, fmt.Sprintf("  %s", name))
-				}
-			}
-			sort.Slice(contexts, func(i, j int) bool {
-				return len(contexts[i]) < len(contexts[j])
-			})
-			for _, ctx := range contexts {
-				fmt.Println(ctx)
-			}
-
+			printContexts(os.Stdout, okteto.ContextStore())
 			return nil
 		},
 	}
 
 	return cmd
 }
+
+// printContexts prints a table with the okteto contexts, marking the current context with '*'
+func printContexts(out io.Writer, oCtxs *okteto.OktetoContextStore) {
+	names := make([]string, 0, len(oCtxs.Contexts))
+	for name := range oCtxs.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(w, "  Name\tNamespace\tBuilder\tRegistry\tOkteto\n")
+	for _, name := range names {
+		oCtx := oCtxs.Contexts[name]
+		current := " "
+		if name == oCtxs.CurrentContext {
+			current = "*"
+		}
+		fmt.Fprintf(w, "%s %s\t%s\t%s\t%s\t%v\n", current, name, valueOrDash(oCtx.Namespace), valueOrDash(oCtx.Buildkit), valueOrDash(oCtx.Registry), okteto.IsOktetoURL(name))
+	}
+	w.Flush()
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
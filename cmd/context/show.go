@@ -0,0 +1,97 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+type contextOutput struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Registry  string `json:"registry,omitempty"`
+	Buildkit  string `json:"buildkit,omitempty"`
+	IsOkteto  bool   `json:"isOkteto"`
+	Insecure  bool   `json:"insecure,omitempty"`
+}
+
+// Show shows the current okteto context
+func Show() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "show",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#context"),
+		Short: "Shows the current okteto context",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(output); err != nil {
+				return err
+			}
+
+			if err := okteto.InitContext(context.Background(), ""); err != nil {
+				return err
+			}
+
+			return showContext(getContextOutput(), output)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. One of: ['json']")
+	return cmd
+}
+
+func validateOutput(output string) error {
+	if output != "" && output != "json" {
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['json']")
+	}
+	return nil
+}
+
+func getContextOutput() *contextOutput {
+	octx := okteto.Context()
+	return &contextOutput{
+		Name:      octx.Name,
+		Namespace: octx.Namespace,
+		Registry:  octx.Registry,
+		Buildkit:  octx.Buildkit,
+		IsOkteto:  okteto.IsOktetoContext(),
+		Insecure:  octx.Insecure,
+	}
+}
+
+func showContext(ctxOutput *contextOutput, output string) error {
+	switch output {
+	case "json":
+		bytes, err := json.MarshalIndent(ctxOutput, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bytes))
+	default:
+		fmt.Printf("Name:      %s\n", ctxOutput.Name)
+		fmt.Printf("Namespace: %s\n", ctxOutput.Namespace)
+		fmt.Printf("Registry:  %s\n", ctxOutput.Registry)
+		fmt.Printf("Builder:   %s\n", ctxOutput.Buildkit)
+		fmt.Printf("Okteto:    %t\n", ctxOutput.IsOkteto)
+		if ctxOutput.Insecure {
+			log.Warning("The TLS certificate of this context is not verified. Run 'okteto context %s' without '--insecure' to verify it", ctxOutput.Name)
+		}
+	}
+	return nil
+}
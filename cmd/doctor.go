@@ -15,6 +15,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
@@ -26,14 +27,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// Doctor generates a zip file with all okteto-related log files
+// Doctor diagnoses the common failures of 'okteto up' and generates a zip file with all okteto-related log files
 func Doctor() *cobra.Command {
 	var devPath string
 	var namespace string
 	var k8sContext string
 	cmd := &cobra.Command{
 		Use:   "doctor",
-		Short: "Generates a zip file with the okteto logs",
+		Short: "Diagnoses common 'okteto up' failures and generates a zip file with the okteto logs",
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#doctor"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			log.Info("starting doctor command")
@@ -46,6 +47,10 @@ func Doctor() *cobra.Command {
 				return errors.ErrNotInDevContainer
 			}
 
+			devPath, err := utils.FindDevManifest(devPath)
+			if err != nil {
+				return err
+			}
 			dev, err := utils.LoadDev(devPath, namespace, k8sContext)
 			if err != nil {
 				return err
@@ -60,10 +65,16 @@ func Doctor() *cobra.Command {
 				return err
 			}
 
+			checks := doctor.RunChecks(ctx, dev, c)
+			failed := printChecks(checks)
+
 			filename, err := doctor.Run(ctx, dev, devPath, c)
 			if err == nil {
 				log.Information("Your doctor file is available at %s", filename)
 			}
+			if err == nil && failed > 0 {
+				err = fmt.Errorf("%d of %d checks failed", failed, len(checks))
+			}
 			analytics.TrackDoctor(err == nil)
 			return err
 		},
@@ -73,3 +84,20 @@ func Doctor() *cobra.Command {
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the up command was executing")
 	return cmd
 }
+
+// printChecks prints the report of the doctor checks and returns the number of failed checks
+func printChecks(checks []doctor.Check) int {
+	failed := 0
+	for _, check := range checks {
+		if check.Err == nil {
+			log.Success("%s", check.Name)
+			continue
+		}
+		failed++
+		log.Fail("%s: %s", check.Name, check.Err)
+		if check.Hint != "" {
+			log.Hint("    %s", check.Hint)
+		}
+	}
+	return failed
+}
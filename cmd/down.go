@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
@@ -34,6 +35,7 @@ import (
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
 // Down deactivates the development container
@@ -42,6 +44,9 @@ func Down() *cobra.Command {
 	var namespace string
 	var k8sContext string
 	var rm bool
+	var all bool
+	var flush bool
+	var yes bool
 
 	cmd := &cobra.Command{
 		Use:   "down",
@@ -49,10 +54,26 @@ func Down() *cobra.Command {
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#down"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
+			if all && cmd.Flags().Changed("file") {
+				return errors.UserError{
+					E:    fmt.Errorf("flags '--all' and '--file' can't be used together"),
+					Hint: "Use '--all' to deactivate every development container of the namespace",
+				}
+			}
+
 			if err := contextCMD.Init(ctx); err != nil {
 				return err
 			}
 
+			if all {
+				if err := okteto.SetCurrentContext(k8sContext, namespace); err != nil {
+					return err
+				}
+				err := runDownAll(ctx, okteto.Context().Namespace, rm, yes)
+				analytics.TrackDown(err == nil)
+				return err
+			}
+
 			dev, err := utils.LoadDev(devPath, namespace, k8sContext)
 			if err != nil {
 				return err
@@ -62,7 +83,7 @@ func Down() *cobra.Command {
 				return err
 			}
 
-			if err := runDown(ctx, dev, rm); err != nil {
+			if err := runDown(ctx, dev, rm, flush); err != nil {
 				analytics.TrackDown(false)
 				return err
 			}
@@ -76,10 +97,13 @@ func Down() *cobra.Command {
 	cmd.Flags().BoolVarP(&rm, "volumes", "v", false, "remove persistent volume")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the down command is executed")
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the down command is executed")
+	cmd.Flags().BoolVar(&flush, "flush", true, "wait until the file synchronization completes before deactivating your development container")
+	cmd.Flags().BoolVar(&all, "all", false, "deactivate all the development containers of the namespace")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "deactivate the development containers of '--all' without asking for confirmation")
 	return cmd
 }
 
-func runDown(ctx context.Context, dev *model.Dev, rm bool) error {
+func runDown(ctx context.Context, dev *model.Dev, rm, flush bool) error {
 	spinner := utils.NewSpinner("Deactivating your development container...")
 	spinner.Start()
 	defer spinner.Stop()
@@ -89,6 +113,15 @@ func runDown(ctx context.Context, dev *model.Dev, rm bool) error {
 	exit := make(chan error, 1)
 
 	go func() {
+		if flush {
+			spinner.Update("Synchronizing your files...")
+			if err := down.Flush(ctx, dev); err != nil {
+				exit <- err
+				return
+			}
+			spinner.Update("Deactivating your development container...")
+		}
+
 		c, _, err := okteto.GetK8sClient()
 		if err != nil {
 			exit <- err
@@ -96,7 +129,7 @@ func runDown(ctx context.Context, dev *model.Dev, rm bool) error {
 		}
 
 		if dev.Divert != nil {
-			if err := diverts.Delete(ctx, dev, c); err != nil {
+			if err := diverts.Delete(ctx, dev, false, c); err != nil {
 				exit <- err
 				return
 			}
@@ -168,6 +201,111 @@ func runDown(ctx context.Context, dev *model.Dev, rm bool) error {
 	return nil
 }
 
+func runDownAll(ctx context.Context, namespace string, rm, yes bool) error {
+	c, _, err := okteto.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	devApps, err := apps.ListDevModeOn(ctx, namespace, c)
+	if err != nil {
+		return fmt.Errorf("failed to list the development containers: %w", err)
+	}
+	if len(devApps) == 0 {
+		log.Information("There are no development containers active in namespace '%s'", namespace)
+		return nil
+	}
+
+	devs := make([]*model.Dev, len(devApps))
+	names := make([]string, len(devApps))
+	for i, app := range devApps {
+		devs[i] = &model.Dev{
+			Name:      apps.GetDevName(ctx, app, c),
+			Namespace: namespace,
+			Interface: model.Localhost,
+		}
+		names[i] = devs[i].Name
+	}
+
+	if !yes {
+		log.Information("Development containers active in namespace '%s': %s", namespace, strings.Join(names, ", "))
+		confirmed, err := utils.AskYesNo(fmt.Sprintf("Do you want to deactivate %d development containers? [y/n] ", len(devApps)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			log.Information("Development containers not deactivated")
+			return nil
+		}
+	}
+
+	failed := []string{}
+	for i, app := range devApps {
+		if err := runDownApp(ctx, devs[i], app, rm, c); err != nil {
+			if err == errors.ErrIntSig {
+				return err
+			}
+			log.Fail("Development container '%s' failed to be deactivated: %s", devs[i].Name, err.Error())
+			failed = append(failed, devs[i].Name)
+			continue
+		}
+		log.Success("Development container '%s' deactivated", devs[i].Name)
+	}
+
+	log.Information("%d of %d development containers deactivated", len(devApps)-len(failed), len(devApps))
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to deactivate development containers: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// runDownApp deactivates an app in dev mode without an okteto manifest
+func runDownApp(ctx context.Context, dev *model.Dev, app apps.App, rm bool, c *kubernetes.Clientset) error {
+	spinner := utils.NewSpinner(fmt.Sprintf("Deactivating '%s'...", dev.Name))
+	spinner.Start()
+	defer spinner.Stop()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	defer signal.Stop(stop)
+	exit := make(chan error, 1)
+
+	go func() {
+		trMap := map[string]*apps.Translation{
+			app.ObjectMeta().Name: {MainDev: dev, Dev: dev, App: app},
+		}
+		if err := down.Run(dev, app, trMap, true, c); err != nil {
+			exit <- err
+			return
+		}
+
+		if !rm {
+			exit <- nil
+			return
+		}
+
+		timeout, err := model.GetTimeout()
+		if err != nil {
+			exit <- err
+			return
+		}
+		if err := volumes.Destroy(ctx, dev.GetVolumeName(), dev.Namespace, c, timeout); err != nil {
+			exit <- err
+			return
+		}
+		exit <- nil
+	}()
+
+	select {
+	case <-stop:
+		log.Infof("CTRL+C received, starting shutdown sequence")
+		spinner.Stop()
+		return errors.ErrIntSig
+	case err := <-exit:
+		return err
+	}
+}
+
 func removeVolume(ctx context.Context, dev *model.Dev) error {
 	c, _, err := okteto.GetK8sClient()
 	if err != nil {
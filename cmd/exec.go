@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
@@ -31,15 +32,23 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/ssh"
+	"golang.org/x/term"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/spf13/cobra"
 )
 
+// defaultContainerAnnotation selects the default container of a pod for kubectl exec and kubectl logs
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
 // Exec executes a command on the CND container
 func Exec() *cobra.Command {
 	var devPath string
 	var namespace string
 	var k8sContext string
+	var podName string
+	var container string
 
 	cmd := &cobra.Command{
 		Use:   "exec <command>",
@@ -52,6 +61,15 @@ func Exec() *cobra.Command {
 				return err
 			}
 
+			if podName != "" {
+				if err := okteto.SetCurrentContext(k8sContext, namespace); err != nil {
+					return err
+				}
+				err := executePodExec(ctx, okteto.Context().Namespace, podName, container, args)
+				analytics.TrackExec(err == nil)
+				return err
+			}
+
 			dev, err := utils.LoadDev(devPath, namespace, k8sContext)
 			if err != nil {
 				return err
@@ -63,7 +81,7 @@ func Exec() *cobra.Command {
 
 			t := time.NewTicker(1 * time.Second)
 			iter := 0
-			err = executeExec(ctx, dev, args)
+			err = executeExec(ctx, dev, container, args)
 			for errors.IsTransient(err) {
 				if iter == 0 {
 					log.Yellow("Connection lost to your development container, reconnecting...")
@@ -71,7 +89,7 @@ func Exec() *cobra.Command {
 				iter++
 				iter = iter % 10
 				<-t.C
-				err = executeExec(ctx, dev, args)
+				err = executeExec(ctx, dev, container, args)
 			}
 
 			analytics.TrackExec(err == nil)
@@ -91,11 +109,13 @@ func Exec() *cobra.Command {
 	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the exec command is executed")
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the exec command is executed")
+	cmd.Flags().StringVarP(&podName, "pod", "", "", "execute the command in this pod of the namespace instead of your development container, without reading the okteto manifest")
+	cmd.Flags().StringVarP(&container, "container", "", "", "container where the command is executed (defaults to the development container, or to the default container of the pod given by --pod)")
 
 	return cmd
 }
 
-func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
+func executeExec(ctx context.Context, dev *model.Dev, container string, args []string) error {
 
 	wrapped := []string{"sh", "-c"}
 	wrapped = append(wrapped, args...)
@@ -140,11 +160,18 @@ func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
 		return err
 	}
 
+	if container != "" {
+		dev.Container, err = getExecContainer(pod, container)
+		if err != nil {
+			return err
+		}
+	}
 	if dev.Container == "" {
 		dev.Container = pod.Spec.Containers[0].Name
 	}
 
-	if dev.RemoteModeEnabled() {
+	// the SSH server only runs in the development container
+	if dev.RemoteModeEnabled() && container == "" {
 		p, err := ssh.GetPort(dev.Name)
 		if err != nil {
 			log.Infof("failed to get the SSH port for %s: %s", dev.Name, err)
@@ -164,3 +191,58 @@ func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
 
 	return exec.Exec(ctx, c, cfg, dev.Namespace, pod.Name, dev.Container, true, os.Stdin, os.Stdout, os.Stderr, wrapped)
 }
+
+// executePodExec executes a command in a container of any running pod of a namespace
+func executePodExec(ctx context.Context, namespace, podName, container string, args []string) error {
+	c, cfg, err := okteto.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	pod, err := c.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return errors.UserError{
+				E:    fmt.Errorf("pod '%s' not found in namespace '%s'", podName, namespace),
+				Hint: "Use the '--namespace' flag or 'okteto context' to change the namespace where the command is executed",
+			}
+		}
+		return err
+	}
+	if pod.Status.Phase != apiv1.PodRunning {
+		return fmt.Errorf("pod '%s' is not running: its phase is '%s'", podName, pod.Status.Phase)
+	}
+
+	container, err = getExecContainer(pod, container)
+	if err != nil {
+		return err
+	}
+
+	tty := term.IsTerminal(int(os.Stdin.Fd()))
+	return exec.Exec(ctx, c, cfg, namespace, pod.Name, container, tty, os.Stdin, os.Stdout, os.Stderr, args)
+}
+
+// getExecContainer returns the container of a pod where a command is executed.
+// It defaults to the container of the 'kubectl.kubernetes.io/default-container' annotation or to the first container of the pod
+func getExecContainer(pod *apiv1.Pod, container string) (string, error) {
+	if container == "" {
+		container = pod.Annotations[defaultContainerAnnotation]
+	}
+	if container == "" {
+		return pod.Spec.Containers[0].Name, nil
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name == container {
+			return container, nil
+		}
+	}
+
+	names := []string{}
+	for _, c := range pod.Spec.Containers {
+		names = append(names, c.Name)
+	}
+	return "", errors.UserError{
+		E:    fmt.Errorf("container '%s' not found in pod '%s'", container, pod.Name),
+		Hint: fmt.Sprintf("Use the '--container' flag to select one of these containers: %s", strings.Join(names, ", ")),
+	}
+}
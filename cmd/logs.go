@@ -0,0 +1,203 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/spf13/cobra"
+)
+
+// logsReconnectInterval is the time to wait before reconnecting to the logs of a restarted development container
+var logsReconnectInterval = 1 * time.Second
+
+type logsOptions struct {
+	devPath    string
+	namespace  string
+	k8sContext string
+	container  string
+	follow     bool
+	since      time.Duration
+	tail       int64
+}
+
+// Logs streams the logs of your development container
+func Logs() *cobra.Command {
+	options := &logsOptions{}
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Streams the logs of your development container",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#logs"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			stop := make(chan os.Signal, 1)
+			signal.Notify(stop, os.Interrupt)
+			defer signal.Stop(stop)
+			go func() {
+				<-stop
+				cancel()
+			}()
+
+			if err := contextCMD.Init(ctx); err != nil {
+				return err
+			}
+
+			dev, err := utils.LoadDev(options.devPath, options.namespace, options.k8sContext)
+			if err != nil {
+				return err
+			}
+
+			if err := okteto.SetCurrentContext(dev.Context, dev.Namespace); err != nil {
+				return err
+			}
+
+			c, _, err := okteto.GetK8sClient()
+			if err != nil {
+				return err
+			}
+
+			err = streamDevLogs(ctx, dev, options, c, os.Stdout)
+			analytics.TrackLogs(err == nil)
+
+			if errors.IsNotFound(err) {
+				return errors.UserError{
+					E:    fmt.Errorf("development container not found in namespace '%s'", dev.Namespace),
+					Hint: "Run 'okteto up' to create your development container or use 'okteto context' to change your current context",
+				}
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.devPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", "", "namespace where the logs command is executed")
+	cmd.Flags().StringVarP(&options.k8sContext, "context", "c", "", "context where the logs command is executed")
+	cmd.Flags().StringVarP(&options.container, "container", "", "", "container of the development pod to get the logs from (defaults to the development container)")
+	cmd.Flags().BoolVarP(&options.follow, "follow", "", false, "keep streaming the logs, reconnecting if the development container restarts")
+	cmd.Flags().DurationVarP(&options.since, "since", "", 0, "only return logs newer than a relative duration like 10s, 5m or 1h")
+	cmd.Flags().Int64VarP(&options.tail, "tail", "", -1, "number of lines to show from the end of the logs (defaults to all of them)")
+
+	return cmd
+}
+
+// streamDevLogs writes the logs of the development container to w.
+// With --follow, it reconnects to the development container when its stream ends, until ctx is cancelled
+func streamDevLogs(ctx context.Context, dev *model.Dev, options *logsOptions, c kubernetes.Interface, w io.Writer) error {
+	opts := options.podLogOptions()
+	reconnecting := false
+	for {
+		pod, err := utils.GetDevPod(ctx, dev, c)
+		if err != nil {
+			if !reconnecting || ctx.Err() != nil {
+				return err
+			}
+			log.Infof("failed to get the development container pod: %s", err)
+		} else {
+			container, err := getLogsContainer(pod, dev, options.container)
+			if err != nil {
+				return err
+			}
+			opts.Container = container
+
+			err = pods.StreamLogs(ctx, pod, opts, c, w)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if !options.follow || (err != nil && !reconnecting) {
+				return err
+			}
+			if err != nil {
+				log.Infof("logs stream of pod '%s' failed: %s", pod.Name, err)
+			}
+
+			// only the logs written after the stream ended are shown after reconnecting
+			opts.SinceSeconds = nil
+			opts.TailLines = nil
+			opts.SinceTime = &metav1.Time{Time: time.Now()}
+		}
+
+		if !reconnecting {
+			log.Yellow("Development container logs interrupted, reconnecting...")
+		}
+		reconnecting = true
+
+		select {
+		case <-time.After(logsReconnectInterval):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (o *logsOptions) podLogOptions() *apiv1.PodLogOptions {
+	opts := &apiv1.PodLogOptions{
+		Follow: o.follow,
+	}
+	if o.since > 0 {
+		seconds := int64(o.since.Round(time.Second).Seconds())
+		if seconds == 0 {
+			seconds = 1
+		}
+		opts.SinceSeconds = &seconds
+	}
+	if o.tail >= 0 {
+		tail := o.tail
+		opts.TailLines = &tail
+	}
+	return opts
+}
+
+// getLogsContainer returns the container to get the logs from, defaulting to the development container
+func getLogsContainer(pod *apiv1.Pod, dev *model.Dev, container string) (string, error) {
+	if container == "" {
+		devContainer := apps.GetDevContainer(&pod.Spec, dev.Container)
+		if devContainer == nil {
+			return "", fmt.Errorf("container '%s' not found in pod '%s'", dev.Container, pod.Name)
+		}
+		return devContainer.Name, nil
+	}
+
+	if apps.GetContainer(&pod.Spec, container) == nil {
+		names := []string{}
+		for _, c := range pod.Spec.Containers {
+			names = append(names, c.Name)
+		}
+		return "", errors.UserError{
+			E:    fmt.Errorf("container '%s' not found in your development container pod", container),
+			Hint: fmt.Sprintf("Use the '--container' flag to select one of these containers: %s", strings.Join(names, ", ")),
+		}
+	}
+	return container, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+//Manifest okteto manifest commands
+func Manifest() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Okteto manifest commands",
+		Long: fmt.Sprintf(`Okteto manifest commands
+
+An okteto manifest can extend another one with 'extends: <path>', relative to the folder of the manifest.
+The manifest is deep-merged over the manifest it extends:
+    maps, like 'resources' or 'labels', are merged key by key and the values of the manifest win
+    the lists %s are appended after the lists of the manifest it extends
+    the rest of values, including other lists like 'command' or 'sync', replace the values of the manifest it extends
+`, "'"+strings.Join(model.ExtendsAppendedFields(), "', '")+"'"),
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli#manifest"),
+	}
+	cmd.AddCommand(Validate())
+	return cmd
+}
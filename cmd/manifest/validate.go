@@ -0,0 +1,75 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+//Validate validates an okteto manifest without activating any development container
+func Validate() *cobra.Command {
+	var devPath string
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validates an okteto manifest",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli#manifest"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			devPath, err := utils.FindDevManifest(devPath)
+			if err != nil {
+				return err
+			}
+
+			errs := validateManifest(devPath)
+			if len(errs) == 0 {
+				log.Success("'%s' is valid", devPath)
+				return nil
+			}
+
+			log.Fail("'%s' is not valid:", devPath)
+			for _, err := range errs {
+				fmt.Printf("    - %s\n", err)
+			}
+			return fmt.Errorf("%d errors found in '%s'", len(errs), devPath)
+		},
+	}
+	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
+	return cmd
+}
+
+// validateManifest loads the okteto manifest in devPath, resolving 'extends' and the environment variables,
+// and returns the errors of its schema and of the checks run by okteto when activating it
+func validateManifest(devPath string) []error {
+	if !model.FileExists(devPath) {
+		return []error{fmt.Errorf("'%s' does not exist", devPath)}
+	}
+	dev, err := model.Get(devPath)
+	if err != nil {
+		var schemaErr *model.SchemaError
+		if errors.As(err, &schemaErr) {
+			errs := []error{}
+			for _, e := range schemaErr.Errors {
+				errs = append(errs, errors.New(e))
+			}
+			return errs
+		}
+		return []error{err}
+	}
+	return dev.ValidateStrict()
+}
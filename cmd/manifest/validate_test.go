@@ -0,0 +1,81 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_validateManifest(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "base.yml"), []byte("image: okteto/base\nforward:\n  - 8080:8080\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		manifest string
+		errors   int
+		expected string
+	}{
+		{
+			name:     "valid",
+			manifest: "extends: base.yml\nname: api\n",
+			errors:   0,
+		},
+		{
+			name:     "schema-errors",
+			manifest: "name: api\nfowrard:\n  - 8080:8080\nimge: okteto/api\n",
+			errors:   2,
+		},
+		{
+			name:     "extends-schema-errors",
+			manifest: "extends: base.yml\nname: api\nfowrard:\n  - 9229:9229\n",
+			errors:   1,
+			expected: "line 3: unknown field 'fowrard' (did you mean 'forward'?)",
+		},
+		{
+			name:     "extends-errors",
+			manifest: "extends: base.yml\nname: api\nforward:\n  - 8080:8081\n",
+			errors:   1,
+		},
+		{
+			name:     "validation-error",
+			manifest: "name: Api\n",
+			errors:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devPath := filepath.Join(dir, "okteto.yml")
+			if err := os.WriteFile(devPath, []byte(tt.manifest), 0600); err != nil {
+				t.Fatal(err)
+			}
+			errs := validateManifest(devPath)
+			if len(errs) != tt.errors {
+				t.Fatalf("expected %d errors, got %v", tt.errors, errs)
+			}
+			if tt.expected != "" && !strings.Contains(errs[0].Error(), tt.expected) {
+				t.Errorf("expected '%s' in error '%s'", tt.expected, errs[0].Error())
+			}
+		})
+	}
+
+	if errs := validateManifest(filepath.Join(dir, "missing.yml")); len(errs) != 1 {
+		t.Errorf("expected an error for a missing manifest, got %v", errs)
+	}
+}
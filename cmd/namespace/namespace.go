@@ -30,6 +30,7 @@ import (
 
 // Namespace fetch credentials for a cluster namespace
 func Namespace(ctx context.Context) *cobra.Command {
+	var createIfMissing bool
 	cmd := &cobra.Command{
 		Use:   "namespace [name]",
 		Short: "Downloads k8s credentials for a namespace",
@@ -49,14 +50,46 @@ func Namespace(ctx context.Context) *cobra.Command {
 				return errors.ErrContextIsNotOktetoCluster
 			}
 
-			err := RunNamespace(ctx, namespace)
+			if createIfMissing && namespace == "" {
+				return errors.UserError{
+					E:    fmt.Errorf("'--create-if-missing' requires the name of the namespace"),
+					Hint: "Run 'okteto namespace <name> --create-if-missing'",
+				}
+			}
+
+			err := useNamespace(ctx, namespace, createIfMissing)
 			analytics.TrackNamespace(err == nil)
 			return err
 		},
 	}
+	cmd.Flags().BoolVarP(&createIfMissing, "create-if-missing", "", false, "create the namespace if it doesn't exist")
 	return cmd
 }
 
+// useNamespace activates a namespace, creating it first if it doesn't exist and createIfMissing is set
+func useNamespace(ctx context.Context, namespace string, createIfMissing bool) error {
+	if !createIfMissing {
+		return RunNamespace(ctx, namespace)
+	}
+
+	hasAccess, err := hasAccessToNamespace(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	if hasAccess {
+		return RunNamespace(ctx, namespace)
+	}
+
+	log.Information("Namespace '%s' not found, creating it...", namespace)
+	if err := executeCreateNamespace(ctx, namespace, nil); err != nil {
+		return errors.UserError{
+			E:    err,
+			Hint: "If the namespace already exists, ask one of its members to give you access to it",
+		}
+	}
+	return nil
+}
+
 // RunNamespace starts the kubeconfig sequence
 func RunNamespace(ctx context.Context, namespace string) error {
 
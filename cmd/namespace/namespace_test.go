@@ -0,0 +1,137 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+func Test_useNamespace(t *testing.T) {
+	var tests = []struct {
+		name            string
+		createIfMissing bool
+		spaces          string
+		createErr       bool
+		wantOps         []string
+		wantErr         string
+		wantHint        bool
+	}{
+		{
+			name:     "no-create-if-missing",
+			spaces:   `{"data":{"spaces":[{"id":"cindy"}]}}`,
+			wantOps:  []string{"credentials"},
+			wantErr:  "credentials requested",
+			wantHint: false,
+		},
+		{
+			name:            "existing-namespace",
+			createIfMissing: true,
+			spaces:          `{"data":{"spaces":[{"id":"cindy"},{"id":"test"}]}}`,
+			wantOps:         []string{"spaces", "credentials"},
+			wantErr:         "credentials requested",
+			wantHint:        false,
+		},
+		{
+			name:            "missing-namespace",
+			createIfMissing: true,
+			spaces:          `{"data":{"spaces":[{"id":"cindy"}]}}`,
+			wantOps:         []string{"spaces", "createSpace", "credentials"},
+			wantErr:         "failed to activate your new namespace test",
+			wantHint:        true,
+		},
+		{
+			name:            "create-error",
+			createIfMissing: true,
+			spaces:          `{"data":{"spaces":[{"id":"cindy"}]}}`,
+			createErr:       true,
+			wantOps:         []string{"spaces", "createSpace"},
+			wantErr:         "name already taken",
+			wantHint:        true,
+		},
+		{
+			name:            "list-error",
+			createIfMissing: true,
+			spaces:          `{"errors":[{"message":"server unavailable"}]}`,
+			wantOps:         []string{"spaces"},
+			wantErr:         "server unavailable",
+			wantHint:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := []string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Query     string                 `json:"query"`
+					Variables map[string]interface{} `json:"variables"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.Contains(body.Query, "createSpace"):
+					ops = append(ops, "createSpace")
+					if body.Variables["name"] != "test" {
+						t.Errorf("unexpected namespace created: %v", body.Variables["name"])
+					}
+					if tt.createErr {
+						w.Write([]byte(`{"errors":[{"message":"name already taken"}]}`))
+						return
+					}
+					w.Write([]byte(`{"data":{"createSpace":{"id":"test"}}}`))
+				case strings.Contains(body.Query, "credentials"):
+					// stop before the kubeconfig is written
+					ops = append(ops, "credentials")
+					w.Write([]byte(`{"errors":[{"message":"credentials requested"}]}`))
+				case strings.Contains(body.Query, "spaces"):
+					ops = append(ops, "spaces")
+					w.Write([]byte(tt.spaces))
+				default:
+					t.Errorf("unexpected query: %s", body.Query)
+				}
+			}))
+			defer server.Close()
+
+			okteto.CurrentStore = &okteto.OktetoContextStore{
+				CurrentContext: server.URL,
+				Contexts: map[string]*okteto.OktetoContext{
+					server.URL: {Name: server.URL, Token: "token", Namespace: "cindy"},
+				},
+			}
+
+			err := useNamespace(context.Background(), "test", tt.createIfMissing)
+
+			if !reflect.DeepEqual(ops, tt.wantOps) {
+				t.Errorf("expected calls %v, got %v", tt.wantOps, ops)
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if _, ok := err.(errors.UserError); ok != tt.wantHint {
+				t.Errorf("expected user error to be %t, got %T", tt.wantHint, err)
+			}
+		})
+	}
+}
@@ -32,6 +32,7 @@ import (
 
 func deploy(ctx context.Context) *cobra.Command {
 	var branch string
+	var revision string
 	var repository string
 	var name string
 	var namespace string
@@ -40,23 +41,31 @@ func deploy(ctx context.Context) *cobra.Command {
 	var timeout time.Duration
 	var variables []string
 	var filename string
+	var output string
+	var k8sContext string
 
 	cmd := &cobra.Command{
 		Use:   "deploy",
 		Short: "Deploys an okteto pipeline",
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#deploy"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(output); err != nil {
+				return err
+			}
+			if output != "" {
+				utils.DisableSpinner()
+			}
 
 			if err := contextCMD.Init(ctx); err != nil {
 				return err
 			}
 
-			if !okteto.IsOktetoContext() {
-				return errors.ErrContextIsNotOktetoCluster
+			if err := okteto.SetCurrentContext(k8sContext, namespace); err != nil {
+				return err
 			}
 
-			if err := okteto.SetCurrentContext("", namespace); err != nil {
-				return err
+			if !okteto.IsOktetoContext() {
+				return errors.ErrContextIsNotOktetoCluster
 			}
 
 			cwd, err := os.Getwd()
@@ -77,6 +86,7 @@ func deploy(ctx context.Context) *cobra.Command {
 				name = getPipelineName(repository)
 			}
 
+			branchFromFlag := branch
 			if branch == "" {
 				log.Info("inferring git repository branch")
 				b, err := utils.GetBranch(ctx, cwd)
@@ -93,8 +103,16 @@ func deploy(ctx context.Context) *cobra.Command {
 				if err != nil {
 					return err
 				}
-				pipeline, err := oktetoClient.GetPipelineByRepository(ctx, repository)
+				var pipeline *okteto.GitDeployResponse
+				if branchFromFlag != "" {
+					pipeline, err = oktetoClient.GetPipelineByRepositoryAndBranch(ctx, repository, branchFromFlag)
+				} else {
+					pipeline, err = oktetoClient.GetPipelineByRepository(ctx, repository)
+				}
 				if err == nil {
+					if output != "" {
+						return printOutput(getPipelineOutput(name, pipeline), output)
+					}
 					log.Information("Pipeline URL: %s", getPipelineURL(pipeline.GitDeploy))
 					log.Success("Pipeline '%s' was already deployed", name)
 					return nil
@@ -104,13 +122,18 @@ func deploy(ctx context.Context) *cobra.Command {
 				}
 			}
 
-			resp, err := deployPipeline(ctx, name, repository, branch, filename, variables)
+			resp, err := deployPipeline(ctx, name, repository, branch, revision, filename, variables)
 			if err != nil {
 				return err
 			}
-			log.Information("Pipeline URL: %s", getPipelineURL(resp.GitDeploy))
+			if output == "" {
+				log.Information("Pipeline URL: %s", getPipelineURL(resp.GitDeploy))
+			}
 
 			if !wait {
+				if output != "" {
+					return printOutput(getPipelineOutput(name, resp), output)
+				}
 				log.Success("Pipeline '%s' scheduled for deployment", name)
 				return nil
 			}
@@ -118,6 +141,12 @@ func deploy(ctx context.Context) *cobra.Command {
 			if err := waitUntilRunning(ctx, name, resp.Action, timeout); err != nil {
 				return err
 			}
+			if output != "" {
+				pipelineOutput := getPipelineOutput(name, resp)
+				pipelineOutput.Status = "deployed"
+				refreshActionOutput(ctx, pipelineOutput)
+				return printOutput(pipelineOutput, output)
+			}
 			log.Success("Pipeline '%s' successfully deployed", name)
 			return nil
 		},
@@ -125,17 +154,20 @@ func deploy(ctx context.Context) *cobra.Command {
 
 	cmd.Flags().StringVarP(&name, "name", "p", "", "name of the pipeline (defaults to the git config name)")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the up command is executed (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "okteto context where the pipeline is deployed, without changing your current context (defaults to the current context)")
 	cmd.Flags().StringVarP(&repository, "repository", "r", "", "the repository to deploy (defaults to the current repository)")
 	cmd.Flags().StringVarP(&branch, "branch", "b", "", "the branch to deploy (defaults to the current branch)")
+	cmd.Flags().StringVarP(&revision, "revision", "", "", "the git revision of the branch to deploy (defaults to the latest commit of the branch)")
 	cmd.Flags().BoolVarP(&wait, "wait", "w", false, "wait until the pipeline finishes (defaults to false)")
 	cmd.Flags().BoolVarP(&skipIfExists, "skip-if-exists", "", false, "skip the pipeline deployment if the pipeline already exists in the namespace (defaults to false)")
 	cmd.Flags().DurationVarP(&timeout, "timeout", "t", (5 * time.Minute), "the length of time to wait for completion, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
 	cmd.Flags().StringArrayVarP(&variables, "var", "v", []string{}, "set a pipeline variable (can be set more than once)")
 	cmd.Flags().StringVarP(&filename, "filename", "f", "", "relative path within the repository to the manifest file (default to okteto-pipeline.yaml or .okteto/okteto-pipeline.yaml)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. One of: ['json', 'yaml']")
 	return cmd
 }
 
-func deployPipeline(ctx context.Context, name, repository, branch, filename string, variables []string) (*okteto.GitDeployResponse, error) {
+func deployPipeline(ctx context.Context, name, repository, branch, revision, filename string, variables []string) (*okteto.GitDeployResponse, error) {
 	spinner := utils.NewSpinner("Deploying your pipeline...")
 	spinner.Start()
 	defer spinner.Stop()
@@ -164,9 +196,9 @@ func deployPipeline(ctx context.Context, name, repository, branch, filename stri
 			})
 		}
 		namespace := okteto.Context().Namespace
-		log.Infof("deploy pipeline %s defined on filename='%s' repository=%s branch=%s on namespace=%s", name, filename, repository, branch, namespace)
+		log.Infof("deploy pipeline %s defined on filename='%s' repository=%s branch=%s revision=%s on namespace=%s", name, filename, repository, branch, revision, namespace)
 
-		resp, err = oktetoClient.DeployPipeline(ctx, name, repository, branch, filename, varList)
+		resp, err = oktetoClient.DeployPipeline(ctx, name, repository, branch, revision, filename, varList)
 		exit <- err
 	}()
 
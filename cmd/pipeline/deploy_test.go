@@ -15,11 +15,13 @@ package pipeline
 
 import (
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
 )
 
 func Test_getRepositoryURL(t *testing.T) {
@@ -111,3 +113,47 @@ func Test_getRepositoryURL(t *testing.T) {
 		})
 	}
 }
+
+func Test_getPipelineOutput(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Name:      "https://cloud.okteto.com",
+				Namespace: "namespace",
+			},
+		},
+	}
+
+	resp := &okteto.GitDeployResponse{
+		Action:    &okteto.Action{ID: "1", Name: "action", Status: "progressing"},
+		GitDeploy: &okteto.GitDeploy{ID: "2", Name: "movies", Status: "progressing"},
+	}
+	result := getPipelineOutput("movies", resp)
+	expected := &pipelineOutput{
+		Name:      "movies",
+		Namespace: "namespace",
+		Status:    "progressing",
+		URL:       "https://cloud.okteto.com/#/spaces/namespace?resourceId=2",
+		Action:    &actionOutput{ID: "1", Name: "action", Status: "progressing"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %+v, got %+v", expected, result)
+	}
+
+	result = getPipelineOutput("movies", nil)
+	if result.Name != "movies" || result.Namespace != "namespace" || result.Action != nil {
+		t.Errorf("unexpected output for a missing pipeline: %+v", result)
+	}
+}
+
+func Test_validateOutput(t *testing.T) {
+	for _, output := range []string{"", "json", "yaml"} {
+		if err := validateOutput(output); err != nil {
+			t.Errorf("unexpected error for output '%s': %s", output, err)
+		}
+	}
+	if err := validateOutput("table"); err == nil {
+		t.Error("expected error for output 'table'")
+	}
+}
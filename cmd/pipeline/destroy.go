@@ -16,8 +16,10 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
@@ -29,29 +31,63 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	defaultPollInterval = 1 * time.Second
+	maxPollInterval     = 30 * time.Second
+)
+
 func destroy(ctx context.Context) *cobra.Command {
 	var name string
+	var branch string
+	var selector string
 	var namespace string
 	var wait bool
 	var destroyVolumes bool
 	var timeout time.Duration
+	var output string
+	var yes bool
+	var k8sContext string
 
 	cmd := &cobra.Command{
 		Use:   "destroy",
 		Short: "Destroys an okteto pipeline",
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#destroy"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(output); err != nil {
+				return err
+			}
+			if output != "" {
+				utils.DisableSpinner()
+			}
 
 			if err := contextCMD.Init(ctx); err != nil {
 				return err
 			}
 
+			if err := okteto.SetCurrentContext(k8sContext, namespace); err != nil {
+				return err
+			}
+
 			if !okteto.IsOktetoContext() {
 				return errors.ErrContextIsNotOktetoCluster
 			}
 
-			if err := okteto.SetCurrentContext("", namespace); err != nil {
-				return err
+			if name != "" && branch != "" {
+				return errors.UserError{
+					E:    fmt.Errorf("flags '--name' and '--branch' can't be used together"),
+					Hint: "Use '--branch' to destroy the pipeline deployed from a branch of the current repository",
+				}
+			}
+
+			if (name != "" || branch != "") && selector != "" {
+				return errors.UserError{
+					E:    fmt.Errorf("flag '--selector' can't be used together with '--name' or '--branch'"),
+					Hint: "Use '--name' to destroy a single pipeline or '--selector' to destroy all the pipelines matching a label selector",
+				}
+			}
+
+			if selector != "" {
+				return destroyPipelinesBySelector(ctx, selector, destroyVolumes, wait, yes, timeout, output)
 			}
 
 			if name == "" {
@@ -64,7 +100,14 @@ func destroy(ctx context.Context) *cobra.Command {
 					return err
 				}
 
-				name = getPipelineName(repo)
+				if branch != "" {
+					name, err = getPipelineNameByBranch(ctx, repo, branch)
+					if err != nil {
+						return err
+					}
+				} else {
+					name = getPipelineName(repo)
+				}
 			}
 
 			resp, err := destroyPipeline(ctx, name, destroyVolumes)
@@ -73,6 +116,9 @@ func destroy(ctx context.Context) *cobra.Command {
 			}
 
 			if !wait {
+				if output != "" {
+					return printOutput(getPipelineOutput(name, resp), output)
+				}
 				log.Success("Pipeline '%s' scheduled for destruction", name)
 				return nil
 			}
@@ -82,6 +128,12 @@ func destroy(ctx context.Context) *cobra.Command {
 				return err
 			}
 
+			if output != "" {
+				pipelineOutput := getPipelineOutput(name, resp)
+				pipelineOutput.Status = "destroyed"
+				refreshActionOutput(ctx, pipelineOutput)
+				return printOutput(pipelineOutput, output)
+			}
 			log.Success("Pipeline '%s' successfully destroyed", name)
 
 			return nil
@@ -89,13 +141,141 @@ func destroy(ctx context.Context) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&name, "name", "p", "", "name of the pipeline (defaults to the git config name)")
+	cmd.Flags().StringVarP(&branch, "branch", "b", "", "destroy the pipeline deployed from this branch of the current repository")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "destroy all the pipelines matching the label selector (e.g. -l key1=value1,key2)")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the up command is executed (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "okteto context where the pipeline is destroyed, without changing your current context (defaults to the current context)")
 	cmd.Flags().BoolVarP(&wait, "wait", "w", false, "wait until the pipeline finishes (defaults to false)")
 	cmd.Flags().BoolVarP(&destroyVolumes, "volumes", "v", false, "destroy persistent volumes created by the pipeline (defaults to false)")
 	cmd.Flags().DurationVarP(&timeout, "timeout", "t", (5 * time.Minute), "the length of time to wait for completion, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. One of: ['json', 'yaml']")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "destroy the pipelines matching '--selector' without asking for confirmation")
 	return cmd
 }
 
+func getPipelineNameByBranch(ctx context.Context, repository, branch string) (string, error) {
+	oktetoClient, err := okteto.NewOktetoClient()
+	if err != nil {
+		return "", err
+	}
+	pipeline, err := oktetoClient.GetPipelineByRepositoryAndBranch(ctx, repository, branch)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", fmt.Errorf("no pipeline found for branch '%s' of repository '%s'", branch, repository)
+		}
+		return "", fmt.Errorf("failed to get pipeline: %w", err)
+	}
+	return pipeline.GitDeploy.Name, nil
+}
+
+func destroyPipelinesBySelector(ctx context.Context, selector string, destroyVolumes, wait, yes bool, timeout time.Duration, output string) error {
+	oktetoClient, err := okteto.NewOktetoClient()
+	if err != nil {
+		return err
+	}
+
+	pipelines, err := oktetoClient.ListPipelines(ctx, selector)
+	if err != nil {
+		return fmt.Errorf("failed to list pipelines: %w", err)
+	}
+	if len(pipelines) == 0 {
+		if output != "" {
+			return printOutput([]*pipelineOutput{}, output)
+		}
+		log.Information("No pipelines found matching selector '%s'", selector)
+		return nil
+	}
+
+	if !yes {
+		if output != "" {
+			return errors.UserError{
+				E:    fmt.Errorf("destroying the pipelines matching '%s' requires confirmation", selector),
+				Hint: "Use '--yes' to destroy them without asking for confirmation",
+			}
+		}
+		names := []string{}
+		for _, p := range pipelines {
+			names = append(names, p.Name)
+		}
+		log.Information("Pipelines matching '%s': %s", selector, strings.Join(names, ", "))
+		confirmed, err := utils.AskYesNo(fmt.Sprintf("Do you want to destroy %d pipelines? [y/n] ", len(pipelines)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			log.Information("Pipelines not destroyed")
+			return nil
+		}
+	}
+
+	responses := map[string]*okteto.GitDeployResponse{}
+	failed := []string{}
+	for _, p := range pipelines {
+		resp, err := destroyPipeline(ctx, p.Name, destroyVolumes)
+		if err != nil {
+			if output == "" {
+				log.Fail("%s", err.Error())
+			}
+			failed = append(failed, p.Name)
+			continue
+		}
+		responses[p.Name] = resp
+	}
+
+	destroyed := []string{}
+	outputs := []*pipelineOutput{}
+	for _, p := range pipelines {
+		resp, ok := responses[p.Name]
+		if !ok {
+			continue
+		}
+		pipelineOutput := getPipelineOutput(p.Name, resp)
+		if !wait {
+			if output == "" {
+				log.Success("Pipeline '%s' scheduled for destruction", p.Name)
+			}
+			destroyed = append(destroyed, p.Name)
+			outputs = append(outputs, pipelineOutput)
+			continue
+		}
+		var action *okteto.Action
+		if resp != nil {
+			action = resp.Action
+		}
+		if err := waitUntilDestroyed(ctx, p.Name, action, timeout); err != nil {
+			if errors.ErrIntSig == err {
+				return err
+			}
+			if output == "" {
+				log.Fail("Pipeline '%s' failed to be destroyed: %s", p.Name, err.Error())
+			}
+			failed = append(failed, p.Name)
+			continue
+		}
+		destroyed = append(destroyed, p.Name)
+		pipelineOutput.Status = "destroyed"
+		refreshActionOutput(ctx, pipelineOutput)
+		outputs = append(outputs, pipelineOutput)
+		if output == "" {
+			log.Success("Pipeline '%s' successfully destroyed", p.Name)
+		}
+	}
+
+	if output != "" {
+		if err := printOutput(outputs, output); err != nil {
+			return err
+		}
+	} else if wait {
+		log.Information("%d of %d pipelines matching '%s' destroyed", len(destroyed), len(pipelines), selector)
+	} else {
+		log.Information("%d of %d pipelines matching '%s' scheduled for destruction", len(destroyed), len(pipelines), selector)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to destroy pipelines: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
 func destroyPipeline(ctx context.Context, name string, destroyVolumes bool) (*okteto.GitDeployResponse, error) {
 	spinner := utils.NewSpinner("Destroying your pipeline...")
 	spinner.Start()
@@ -168,42 +348,135 @@ func waitUntilDestroyed(ctx context.Context, name string, action *okteto.Action,
 }
 
 func waitToBeDestroyed(ctx context.Context, name string, action *okteto.Action, timeout time.Duration) error {
-	if action == nil {
-		return deprecatedWaitToBeDestroyed(ctx, name, timeout)
-	}
 	oktetoClient, err := okteto.NewOktetoClient()
 	if err != nil {
 		return err
 	}
+
+	var capabilities *okteto.Capabilities
+	if action == nil {
+		// only needed to tell apart backends without actions from a missing action
+		capabilities = getCapabilities(ctx, oktetoClient)
+	}
+	poll, err := shouldPollPipelineStatus(capabilities, action)
+	if err != nil {
+		return fmt.Errorf("failed to wait for pipeline '%s': %w", name, err)
+	}
+	if poll {
+		return deprecatedWaitToBeDestroyed(ctx, name, timeout)
+	}
 	return oktetoClient.WaitForActionToFinish(ctx, action.Name, timeout)
 }
 
-//TODO: remove when all users are in Okteto Enterprise >= 0.10.0
+//getCapabilities returns the features supported by the okteto backend, or nil if they can't be retrieved
+func getCapabilities(ctx context.Context, oktetoClient *okteto.OktetoClient) *okteto.Capabilities {
+	capabilities, err := oktetoClient.GetCapabilities(ctx)
+	if err != nil {
+		log.Infof("failed to get okteto capabilities: %s", err)
+		return nil
+	}
+	return capabilities
+}
+
+//shouldPollPipelineStatus returns if the pipeline status must be polled because the okteto backend doesn't support actions
+func shouldPollPipelineStatus(capabilities *okteto.Capabilities, action *okteto.Action) (bool, error) {
+	if capabilities == nil {
+		if action != nil {
+			return false, nil
+		}
+		log.Warning("Couldn't check the features supported by your Okteto instance. Falling back to polling the pipeline status")
+		return true, nil
+	}
+
+	if !capabilities.Actions {
+		log.Warning("Your Okteto instance doesn't support actions. Falling back to polling the pipeline status")
+		return true, nil
+	}
+
+	if action == nil {
+		return false, fmt.Errorf("the okteto API didn't return the action of the pipeline")
+	}
+	return false, nil
+}
+
+//deprecatedWaitToBeDestroyed polls the pipeline status for Okteto Enterprise < 0.10.0
 func deprecatedWaitToBeDestroyed(ctx context.Context, name string, timeout time.Duration) error {
+	interval, err := getPollInterval()
+	if err != nil {
+		return err
+	}
 
-	t := time.NewTicker(1 * time.Second)
-	to := time.NewTicker(timeout)
 	oktetoClient, err := okteto.NewOktetoClient()
 	if err != nil {
 		return err
 	}
 
+	return pollUntilDestroyed(ctx, oktetoClient, name, timeout, interval)
+}
+
+type pipelineGetter interface {
+	GetPipelineByName(ctx context.Context, name string) (*okteto.GitDeploy, error)
+}
+
+func pollUntilDestroyed(ctx context.Context, c pipelineGetter, name string, timeout, interval time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	maxInterval := maxPollInterval
+	if interval > maxInterval {
+		maxInterval = interval
+	}
+
 	for {
 		select {
-		case <-to.C:
-			return fmt.Errorf("pipeline '%s' didn't finish after %s", name, timeout.String())
-		case <-t.C:
-			p, err := oktetoClient.GetPipelineByName(ctx, name)
-			if err != nil {
-				if errors.IsNotFound(err) || errors.IsNotExist(err) {
-					return nil
-				}
-				return fmt.Errorf("failed to get pipeline '%s': %s", name, err)
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("pipeline '%s' didn't finish after %s", name, timeout.String())
 			}
+			return ctx.Err()
+		case <-time.After(withJitter(interval)):
+		}
 
-			if p.Status == "error" {
-				return fmt.Errorf("pipeline '%s' failed", name)
+		p, err := c.GetPipelineByName(ctx, name)
+		if err != nil {
+			if errors.IsNotFound(err) || errors.IsNotExist(err) {
+				return nil
+			}
+			if ctx.Err() != nil {
+				continue
 			}
+			return fmt.Errorf("failed to get pipeline '%s': %s", name, err)
+		}
+
+		if p.Status == "error" {
+			return fmt.Errorf("pipeline '%s' failed", name)
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
 		}
 	}
 }
+
+//getPollInterval returns the initial interval to poll the pipeline status, configurable with OKTETO_PIPELINE_POLL_INTERVAL
+func getPollInterval() (time.Duration, error) {
+	v := os.Getenv("OKTETO_PIPELINE_POLL_INTERVAL")
+	if v == "" {
+		return defaultPollInterval, nil
+	}
+
+	parsed, err := time.ParseDuration(v)
+	if err != nil || parsed <= 0 {
+		return 0, fmt.Errorf("OKTETO_PIPELINE_POLL_INTERVAL is not a valid duration: %s", v)
+	}
+	return parsed, nil
+}
+
+//withJitter adds up to a 20% of random delay to avoid clients polling in lockstep
+func withJitter(interval time.Duration) time.Duration {
+	return interval + time.Duration(rand.Int63n(int64(interval)/5+1))
+}
@@ -0,0 +1,156 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+type fakePipelineGetter struct {
+	statuses []string
+	calls    int
+}
+
+func (f *fakePipelineGetter) GetPipelineByName(_ context.Context, name string) (*okteto.GitDeploy, error) {
+	f.calls++
+	if f.calls > len(f.statuses) {
+		return nil, errors.ErrNotFound
+	}
+	return &okteto.GitDeploy{Name: name, Status: f.statuses[f.calls-1]}, nil
+}
+
+func Test_shouldPollPipelineStatus(t *testing.T) {
+	action := &okteto.Action{ID: "1", Name: "action", Status: "progressing"}
+	tests := []struct {
+		name         string
+		capabilities *okteto.Capabilities
+		action       *okteto.Action
+		expected     bool
+		expectErr    bool
+	}{
+		{
+			name:         "actions-supported",
+			capabilities: &okteto.Capabilities{Actions: true},
+			action:       action,
+			expected:     false,
+		},
+		{
+			name:         "actions-supported-missing-action",
+			capabilities: &okteto.Capabilities{Actions: true},
+			action:       nil,
+			expectErr:    true,
+		},
+		{
+			name:         "actions-not-supported",
+			capabilities: &okteto.Capabilities{Actions: false},
+			action:       nil,
+			expected:     true,
+		},
+		{
+			name:         "actions-not-supported-with-action",
+			capabilities: &okteto.Capabilities{Actions: false},
+			action:       action,
+			expected:     true,
+		},
+		{
+			name:         "unknown-capabilities-with-action",
+			capabilities: nil,
+			action:       action,
+			expected:     false,
+		},
+		{
+			name:         "unknown-capabilities-missing-action",
+			capabilities: nil,
+			action:       nil,
+			expected:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := shouldPollPipelineStatus(tt.capabilities, tt.action)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_pollUntilDestroyed(t *testing.T) {
+	ctx := context.Background()
+
+	getter := &fakePipelineGetter{statuses: []string{"destroying", "destroying"}}
+	if err := pollUntilDestroyed(ctx, getter, "movies", time.Second, time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if getter.calls != 3 {
+		t.Errorf("expected 3 calls, got %d", getter.calls)
+	}
+
+	getter = &fakePipelineGetter{statuses: []string{"destroying", "error"}}
+	if err := pollUntilDestroyed(ctx, getter, "movies", time.Second, time.Millisecond); err == nil || !strings.Contains(err.Error(), "failed") {
+		t.Errorf("expected pipeline failure, got %v", err)
+	}
+}
+
+func Test_pollUntilDestroyedTimeout(t *testing.T) {
+	statuses := make([]string, 1000)
+	for i := range statuses {
+		statuses[i] = "destroying"
+	}
+	getter := &fakePipelineGetter{statuses: statuses}
+
+	start := time.Now()
+	err := pollUntilDestroyed(context.Background(), getter, "movies", 50*time.Millisecond, 5*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "didn't finish after 50ms") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("timeout took too long: %s", elapsed)
+	}
+}
+
+func Test_getPollInterval(t *testing.T) {
+	defer os.Unsetenv("OKTETO_PIPELINE_POLL_INTERVAL")
+
+	os.Unsetenv("OKTETO_PIPELINE_POLL_INTERVAL")
+	if got, err := getPollInterval(); err != nil || got != defaultPollInterval {
+		t.Errorf("expected default interval, got %s, %v", got, err)
+	}
+
+	os.Setenv("OKTETO_PIPELINE_POLL_INTERVAL", "10s")
+	if got, err := getPollInterval(); err != nil || got != 10*time.Second {
+		t.Errorf("expected 10s, got %s, %v", got, err)
+	}
+
+	os.Setenv("OKTETO_PIPELINE_POLL_INTERVAL", "wrong")
+	if _, err := getPollInterval(); err == nil {
+		t.Error("expected error for an invalid interval")
+	}
+}
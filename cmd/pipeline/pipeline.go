@@ -15,11 +15,30 @@ package pipeline
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
 )
 
+type pipelineOutput struct {
+	Name      string        `json:"name" yaml:"name"`
+	Namespace string        `json:"namespace" yaml:"namespace"`
+	Status    string        `json:"status,omitempty" yaml:"status,omitempty"`
+	URL       string        `json:"url,omitempty" yaml:"url,omitempty"`
+	Action    *actionOutput `json:"action,omitempty" yaml:"action,omitempty"`
+}
+
+type actionOutput struct {
+	ID     string `json:"id" yaml:"id"`
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"`
+}
+
 //Pipeline pipeline management commands
 func Pipeline(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
@@ -31,3 +50,70 @@ func Pipeline(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(destroy(ctx))
 	return cmd
 }
+
+func validateOutput(output string) error {
+	if output != "" && output != "json" && output != "yaml" {
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['json', 'yaml']")
+	}
+	return nil
+}
+
+func getPipelineOutput(name string, resp *okteto.GitDeployResponse) *pipelineOutput {
+	result := &pipelineOutput{
+		Name:      name,
+		Namespace: okteto.Context().Namespace,
+	}
+	if resp == nil {
+		return result
+	}
+	if resp.GitDeploy != nil {
+		result.Status = resp.GitDeploy.Status
+		if resp.GitDeploy.ID != "" {
+			result.URL = getPipelineURL(resp.GitDeploy)
+		}
+	}
+	if resp.Action != nil {
+		result.Action = &actionOutput{
+			ID:     resp.Action.ID,
+			Name:   resp.Action.Name,
+			Status: resp.Action.Status,
+		}
+	}
+	return result
+}
+
+//refreshActionOutput updates the action status once the command finished waiting for it
+func refreshActionOutput(ctx context.Context, pipelineOutput *pipelineOutput) {
+	if pipelineOutput.Action == nil {
+		return
+	}
+	oktetoClient, err := okteto.NewOktetoClient()
+	if err != nil {
+		log.Infof("failed to refresh action '%s': %s", pipelineOutput.Action.Name, err)
+		return
+	}
+	action, err := oktetoClient.GetAction(ctx, pipelineOutput.Action.Name)
+	if err != nil {
+		log.Infof("failed to refresh action '%s': %s", pipelineOutput.Action.Name, err)
+		return
+	}
+	pipelineOutput.Action.Status = action.Status
+}
+
+func printOutput(value interface{}, output string) error {
+	var bytes []byte
+	var err error
+	switch output {
+	case "json":
+		bytes, err = json.MarshalIndent(value, "", "  ")
+	case "yaml":
+		bytes, err = yaml.Marshal(value)
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to serialize the pipeline output: %w", err)
+	}
+	fmt.Println(string(bytes))
+	return nil
+}
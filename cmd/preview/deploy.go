@@ -78,9 +78,8 @@ func Deploy(ctx context.Context) *cobra.Command {
 				name = getExpandedName(args[0])
 			}
 
-			if err := okteto.SetCurrentContext("", name); err != nil {
-				return err
-			}
+			// the namespace of the preview environment doesn't exist until it is deployed
+			okteto.Context().Namespace = name
 
 			varList := []okteto.Variable{}
 			for _, v := range variables {
@@ -18,12 +18,15 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
+	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/cmd/build"
 	"github.com/okteto/okteto/pkg/cmd/down"
+	"github.com/okteto/okteto/pkg/cmd/scan"
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/k8s/apps"
 	"github.com/okteto/okteto/pkg/k8s/deployments"
@@ -33,19 +36,49 @@ import (
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/registry"
 	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
+const (
+	// deployStrategyRecreate stops the running pods before starting the new ones
+	deployStrategyRecreate = "recreate"
+
+	// deployStrategyRolling starts the new pods before stopping the running ones
+	deployStrategyRolling = "rolling"
+)
+
+type pushOptions struct {
+	DevPath        string
+	Namespace      string
+	K8sContext     string
+	ImageTag       string
+	AutoDeploy     bool
+	Progress       string
+	AppName        string
+	NoCache        bool
+	NoCacheFilter  []string
+	Force          bool
+	PullBase       bool
+	CacheFrom      []string
+	OCIMediaTypes  bool
+	SecretEnvs     []string
+	SecretCommands []string
+	Secrets        []string
+	OutputDigest   string
+	Wait           bool
+	Timeout        time.Duration
+	Scan           bool
+	ScanSeverity   string
+	DeployStrategy string
+	ShowDiff       bool
+	Container      string
+}
+
 // Push builds, pushes and redeploys the target app
 func Push(ctx context.Context) *cobra.Command {
-	var devPath string
-	var namespace string
-	var k8sContext string
-	var imageTag string
-	var autoDeploy bool
-	var progress string
-	var appName string
-	var noCache bool
+	pushOpts := &pushOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "push",
@@ -60,19 +93,33 @@ func Push(ctx context.Context) *cobra.Command {
 				return err
 			}
 
-			dev, err := utils.LoadDevOrDefault(devPath, appName, namespace, k8sContext)
+			secrets, err := build.GetSecretsFromEnv(pushOpts.SecretEnvs)
 			if err != nil {
 				return err
 			}
+			pushOpts.Secrets = secrets
 
-			if err := okteto.SetCurrentContext(k8sContext, namespace); err != nil {
+			dev, err := utils.LoadDevOrDefault(pushOpts.DevPath, pushOpts.AppName, pushOpts.Namespace, pushOpts.K8sContext)
+			if err != nil {
 				return err
 			}
 
-			if len(appName) > 0 && appName != dev.Name {
+			if err := okteto.SetCurrentContext(pushOpts.K8sContext, pushOpts.Namespace); err != nil {
+				return err
+			}
+
+			if len(pushOpts.AppName) > 0 && pushOpts.AppName != dev.Name {
 				return fmt.Errorf("app name provided does not match the name field in your okteto manifest")
 			}
 
+			if err := validateScanSeverity(dev, pushOpts); err != nil {
+				return err
+			}
+
+			if err := validateDeployStrategy(pushOpts.DeployStrategy); err != nil {
+				return err
+			}
+
 			c, _, err := okteto.GetK8sClient()
 			if err != nil {
 				return err
@@ -80,17 +127,17 @@ func Push(ctx context.Context) *cobra.Command {
 
 			oktetoRegistryURL := okteto.Context().Registry
 
-			if autoDeploy {
+			if pushOpts.AutoDeploy {
 				log.Warning(`The 'deploy' flag is deprecated and will be removed in a future release.
     Set the 'autocreate' field in your okteto manifest to get the same behavior.
     More information is available here: https://okteto.com/docs/reference/cli#up`)
 			}
 
 			if !dev.Autocreate {
-				dev.Autocreate = autoDeploy
+				dev.Autocreate = pushOpts.AutoDeploy
 			}
 
-			if err := runPush(ctx, dev, imageTag, oktetoRegistryURL, progress, noCache, c); err != nil {
+			if err := runPush(ctx, dev, pushOpts, oktetoRegistryURL, c); err != nil {
 				analytics.TrackPush(false, oktetoRegistryURL)
 				return err
 			}
@@ -103,19 +150,36 @@ func Push(ctx context.Context) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
-	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the push command is executed")
-	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the push command is executed")
-	cmd.Flags().StringVarP(&imageTag, "tag", "t", "", "image tag to build, push and redeploy")
-	cmd.Flags().BoolVarP(&autoDeploy, "deploy", "d", false, "create deployment when the app doesn't exist in a namespace")
-	cmd.Flags().StringVarP(&progress, "progress", "", "tty", "show plain/tty build output")
-	cmd.Flags().StringVar(&appName, "name", "", "name of the app to push to")
-	cmd.Flags().BoolVarP(&noCache, "no-cache", "", false, "do not use cache when building the image")
+	cmd.Flags().StringVarP(&pushOpts.DevPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&pushOpts.Namespace, "namespace", "n", "", "namespace where the push command is executed")
+	cmd.Flags().StringVarP(&pushOpts.K8sContext, "context", "c", "", "context where the push command is executed")
+	cmd.Flags().StringVarP(&pushOpts.ImageTag, "tag", "t", "", "image tag to build, push and redeploy")
+	cmd.Flags().BoolVarP(&pushOpts.AutoDeploy, "deploy", "d", false, "create deployment when the app doesn't exist in a namespace")
+	cmd.Flags().StringVarP(&pushOpts.Progress, "progress", "", "tty", "show plain/tty/rawjson build output")
+	cmd.Flags().StringVar(&pushOpts.AppName, "name", "", "name of the app to push to")
+	cmd.Flags().BoolVarP(&pushOpts.NoCache, "no-cache", "", false, "do not use cache when building the image")
+	cmd.Flags().StringArrayVar(&pushOpts.NoCacheFilter, "no-cache-filter", nil, "do not use cache when building the given stage of the Dockerfile (can be set more than once)")
+	cmd.Flags().BoolVarP(&pushOpts.Force, "force", "", false, "build even if the build context is your home directory or it is too large")
+	cmd.Flags().BoolVarP(&pushOpts.PullBase, "force-rebuild-base", "", false, "always re-resolve the base images of the Dockerfile, keeping the cache of the rest of layers")
+	cmd.Flags().BoolVarP(&pushOpts.PullBase, "pull", "", false, "alias of --force-rebuild-base")
+	cmd.Flags().StringArrayVar(&pushOpts.CacheFrom, "cache-from", nil, "cache source images, appended to the 'cache_from' values of your okteto manifest (can be set more than once)")
+	cmd.Flags().BoolVarP(&pushOpts.OCIMediaTypes, "oci-mediatypes", "", false, "push the image using OCI media types instead of Docker media types")
+	cmd.Flags().StringArrayVar(&pushOpts.SecretEnvs, "secret-env", nil, "environment variable exposed to the build as a secret mounted at /run/secrets/<name> (can be set more than once)")
+	cmd.Flags().StringArrayVar(&pushOpts.SecretCommands, "secret-command", nil, "command whose output is exposed to the build as a secret. Format: id=mysecret,cmd=<command> (can be set more than once)")
+	cmd.Flags().StringVarP(&pushOpts.OutputDigest, "output-digest", "", "", "path to a file where the digest of the pushed image is written")
+	cmd.Flags().BoolVarP(&pushOpts.Scan, "scan", "", false, "scan the pushed image for vulnerabilities before redeploying the app (enabled by the 'scan' field of your okteto manifest)")
+	cmd.Flags().StringVarP(&pushOpts.ScanSeverity, "scan-severity", "", "", "minimum severity of the vulnerabilities that fail the scan: UNKNOWN, LOW, MEDIUM, HIGH or CRITICAL (defaults to CRITICAL)")
+	cmd.Flags().StringVarP(&pushOpts.DeployStrategy, "deploy-strategy", "", "", "update strategy of the deployment for this push: 'recreate' stops the running pods first, e.g. to release a lock held by a singleton, and 'rolling' starts the new pods first. The original strategy is restored once the new revision is ready")
+	cmd.Flags().BoolVarP(&pushOpts.ShowDiff, "show-diff", "", false, "print the changes applied to the labels, annotations and pod template of your app before redeploying it")
+	cmd.Flags().StringVarP(&pushOpts.Container, "container", "", "", "name of the container or init container of the app that runs the pushed image (defaults to the 'container' field of your okteto manifest)")
+	cmd.Flags().BoolVarP(&pushOpts.Wait, "wait", "w", false, "wait until the pods of the new revision are ready (defaults to false)")
+	cmd.Flags().DurationVarP(&pushOpts.Timeout, "timeout", "", (5 * time.Minute), "the length of time to wait for the new revision to be ready, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
 	return cmd
 }
 
-func runPush(ctx context.Context, dev *model.Dev, imageTag, oktetoRegistryURL, progress string, noCache bool, c *kubernetes.Clientset) error {
+func runPush(ctx context.Context, dev *model.Dev, pushOpts *pushOptions, oktetoRegistryURL string, c *kubernetes.Clientset) error {
 	exists := true
+	imageTag := pushOpts.ImageTag
 	app, err := apps.Get(ctx, dev, dev.Namespace, c)
 
 	if err != nil {
@@ -154,16 +218,48 @@ func runPush(ctx context.Context, dev *model.Dev, imageTag, oktetoRegistryURL, p
 		return err
 	}
 
-	imageFromApp, err := getImageFromApp(trMap)
+	currentSpecs := map[string]string{}
+	if pushOpts.ShowDiff && exists {
+		for name, tr := range trMap {
+			if tr.App == nil {
+				continue
+			}
+			currentSpecs[name], err = apps.SerializeSpec(tr.App)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if pushOpts.DeployStrategy != "" {
+		if _, ok := app.(*apps.DeploymentApp); !ok {
+			return errors.UserError{
+				E:    fmt.Errorf("'--deploy-strategy' is not supported for %s '%s'", strings.ToLower(app.TypeMeta().Kind), app.ObjectMeta().Name),
+				Hint: "The update strategy can only be set for deployments",
+			}
+		}
+	}
+
+	imageFromApp, err := getImageFromApp(trMap, pushOpts.Container)
 	if err != nil {
 		return err
 	}
 
-	imageTag, err = buildImage(ctx, dev, imageTag, imageFromApp, oktetoRegistryURL, noCache, progress)
+	imageTag, digest, err := buildImage(ctx, dev, imageTag, imageFromApp, oktetoRegistryURL, pushOpts)
 	if err != nil {
 		return err
 	}
 
+	if digest != "" {
+		if err := outputImageDigest(imageTag, digest, pushOpts.OutputDigest); err != nil {
+			return err
+		}
+	}
+
+	if err := scanImage(ctx, dev, imageTag, digest, pushOpts); err != nil {
+		return err
+	}
+
 	spinner := utils.NewSpinner(fmt.Sprintf("Pushing source code to '%s'...", dev.Name))
 	spinner.Start()
 	defer spinner.Stop()
@@ -186,6 +282,19 @@ func runPush(ctx context.Context, dev *model.Dev, imageTag, oktetoRegistryURL, p
 		}
 	}
 
+	deployedApp, originalStrategy, err := getAppToDeploy(app, trMap, exists, imageTag, digest, pushOpts)
+	if err != nil {
+		return err
+	}
+
+	if pushOpts.ShowDiff && deployedApp != nil {
+		spinner.Stop()
+		if err := printAppDiff(deployedApp, currentSpecs); err != nil {
+			return err
+		}
+		spinner.Start()
+	}
+
 	go func() {
 		if app.ObjectMeta().Annotations[model.OktetoAutoCreateAnnotation] == model.OktetoPushCmd {
 			if err := services.CreateDev(ctx, dev, c); err != nil {
@@ -194,51 +303,168 @@ func runPush(ctx context.Context, dev *model.Dev, imageTag, oktetoRegistryURL, p
 			}
 		}
 
-		if !exists {
-			app.PodSpec().Containers[0].Image = imageTag
-			apps.SetLastBuiltAnnotation(app)
-			exit <- app.Deploy(ctx, c)
+		if deployedApp == nil {
+			exit <- nil
 			return
 		}
+		exit <- deployedApp.Deploy(ctx, c)
+	}()
+	select {
+	case <-stop:
+		log.Infof("CTRL+C received, starting shutdown sequence")
+		spinner.Stop()
+		return errors.ErrIntSig
+	case err := <-exit:
+		if err != nil {
+			log.Infof("exit signal received due to error: %s", err)
+			return err
+		}
+	}
 
-		for _, tr := range trMap {
-			if tr.App == nil {
-				continue
-			}
-			for _, rule := range tr.Rules {
-				devContainer := apps.GetDevContainer(tr.App.PodSpec(), rule.Container)
-				if devContainer == nil {
-					exit <- fmt.Errorf("%s '%s': container '%s' not found", app.TypeMeta().Kind, app.ObjectMeta().Name, rule.Container)
-					return
-				}
-				apps.SetLastBuiltAnnotation(app)
-				devContainer.Image = imageTag
-			}
+	// the original update strategy is restored once the new revision is rolled out, so it applies to the whole rollout
+	if deployedApp == nil || (!pushOpts.Wait && originalStrategy == nil) {
+		return nil
+	}
 
-			if err := tr.App.Deploy(ctx, c); err != nil {
-				exit <- err
-				return
-			}
-			exit <- nil
-			return
-		}
+	spinner.Update(fmt.Sprintf("Waiting for '%s' to be ready...", dev.Name))
+	go func() {
+		exit <- apps.WaitUntilRolledOut(ctx, dev, deployedApp, pushOpts.Timeout, c)
 	}()
 	select {
 	case <-stop:
 		log.Infof("CTRL+C received, starting shutdown sequence")
 		spinner.Stop()
+		if originalStrategy != nil {
+			log.Warning("The update strategy of '%s' was not restored to '%s'", dev.Name, originalStrategy.Type)
+		}
 		return errors.ErrIntSig
 	case err := <-exit:
+		if originalStrategy != nil {
+			if restoreErr := restoreDeployStrategy(ctx, deployedApp, *originalStrategy, c); restoreErr != nil {
+				log.Warning("Failed to restore the update strategy of '%s' to '%s': %s", dev.Name, originalStrategy.Type, restoreErr)
+			}
+		}
 		if err != nil {
 			log.Infof("exit signal received due to error: %s", err)
 			return err
 		}
 	}
 	return nil
+}
+
+// getAppToDeploy sets the pushed image in the app to redeploy and returns it.
+// It also returns the previous update strategy of the app if '--deploy-strategy' changed it
+func getAppToDeploy(app apps.App, trMap map[string]*apps.Translation, exists bool, imageTag, digest string, pushOpts *pushOptions) (apps.App, *appsv1.DeploymentStrategy, error) {
+	if !exists {
+		app.PodSpec().Containers[0].Image = imageTag
+		apps.SetLastBuiltAnnotation(app, digest)
+		if _, err := setDeployStrategy(app, pushOpts.DeployStrategy); err != nil {
+			return nil, nil, err
+		}
+		return app, nil, nil
+	}
 
+	for _, tr := range trMap {
+		if tr.App == nil {
+			continue
+		}
+		for _, rule := range tr.Rules {
+			devContainer, err := getPushContainer(tr, rule, pushOpts.Container)
+			if err != nil {
+				return nil, nil, err
+			}
+			apps.SetLastBuiltAnnotation(app, digest)
+			devContainer.Image = imageTag
+		}
+
+		previous, err := setDeployStrategy(tr.App, pushOpts.DeployStrategy)
+		if err != nil {
+			return nil, nil, err
+		}
+		return tr.App, previous, nil
+	}
+	return nil, nil, nil
+}
+
+// printAppDiff prints the changes of an app compared to its spec before the push, new apps are printed as additions
+func printAppDiff(app apps.App, currentSpecs map[string]string) error {
+	after, err := apps.SerializeSpec(app)
+	if err != nil {
+		return err
+	}
+	diff, err := apps.GetSpecDiff(app.ObjectMeta().Name, currentSpecs[app.ObjectMeta().Name], after)
+	if err != nil {
+		return err
+	}
+	if diff == "" {
+		log.Information("No changes in the spec of '%s' besides its new revision", app.ObjectMeta().Name)
+		return nil
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			log.Println(line)
+		case strings.HasPrefix(line, "+"):
+			log.Green("%s", line)
+		case strings.HasPrefix(line, "-"):
+			log.Red("%s", line)
+		default:
+			log.Println(line)
+		}
+	}
+	return nil
+}
+
+func validateDeployStrategy(strategy string) error {
+	switch strategy {
+	case "", deployStrategyRecreate, deployStrategyRolling:
+		return nil
+	default:
+		return errors.UserError{
+			E:    fmt.Errorf("invalid deploy strategy '%s'", strategy),
+			Hint: fmt.Sprintf("Accepted values are '%s' and '%s'", deployStrategyRecreate, deployStrategyRolling),
+		}
+	}
+}
+
+// setDeployStrategy sets the update strategy of a deployment.
+// It returns the previous strategy if it was changed, so it can be restored after the push
+func setDeployStrategy(app apps.App, strategy string) (*appsv1.DeploymentStrategy, error) {
+	if strategy == "" {
+		return nil, nil
+	}
+	d, ok := app.(*apps.DeploymentApp)
+	if !ok {
+		return nil, fmt.Errorf("'--deploy-strategy' is not supported for %s '%s'", strings.ToLower(app.TypeMeta().Kind), app.ObjectMeta().Name)
+	}
+
+	strategyType := appsv1.RollingUpdateDeploymentStrategyType
+	if strategy == deployStrategyRecreate {
+		strategyType = appsv1.RecreateDeploymentStrategyType
+	}
+	previous := d.SetStrategy(appsv1.DeploymentStrategy{Type: strategyType})
+	if previous.Type == strategyType {
+		d.SetStrategy(previous)
+		return nil, nil
+	}
+	log.Infof("update strategy of '%s' set from '%s' to '%s'", app.ObjectMeta().Name, previous.Type, strategyType)
+	return &previous, nil
+}
+
+func restoreDeployStrategy(ctx context.Context, app apps.App, strategy appsv1.DeploymentStrategy, c kubernetes.Interface) error {
+	d, ok := app.(*apps.DeploymentApp)
+	if !ok {
+		return nil
+	}
+	if err := d.Refresh(ctx, c); err != nil {
+		return err
+	}
+	d.SetStrategy(strategy)
+	log.Infof("restoring the update strategy of '%s' to '%s'", app.ObjectMeta().Name, strategy.Type)
+	return d.Deploy(ctx, c)
 }
 
-func buildImage(ctx context.Context, dev *model.Dev, imageTag, imageFromApp, oktetoRegistryURL string, noCache bool, progress string) (string, error) {
+func buildImage(ctx context.Context, dev *model.Dev, imageTag, imageFromApp, oktetoRegistryURL string, pushOpts *pushOptions) (string, string, error) {
 	log.Information("Running your build in %s...", okteto.Context().Buildkit)
 
 	if imageTag == "" {
@@ -247,25 +473,136 @@ func buildImage(ctx context.Context, dev *model.Dev, imageTag, imageFromApp, okt
 	buildTag := registry.GetDevImageTag(dev, imageTag, imageFromApp, oktetoRegistryURL)
 	log.Infof("pushing with image tag %s", buildTag)
 
-	buildArgs := model.SerializeBuildArgs(dev.Push.Args)
+	args, err := build.ExpandBuildArgs(ctx, dev.Push.Args)
+	if err != nil {
+		return "", "", err
+	}
+	buildArgs := model.SerializeBuildArgs(args)
+	cacheFrom := append([]string{}, dev.Push.CacheFrom...)
+	cacheFrom = append(cacheFrom, pushOpts.CacheFrom...)
 	buildOptions := build.BuildOptions{
-		Path:       dev.Push.Context,
-		File:       dev.Push.Dockerfile,
-		Tag:        buildTag,
-		Target:     dev.Push.Target,
-		NoCache:    noCache,
-		CacheFrom:  dev.Push.CacheFrom,
-		BuildArgs:  buildArgs,
-		OutputMode: progress,
+		Path:               dev.Push.Context,
+		File:               dev.Push.Dockerfile,
+		Tag:                buildTag,
+		Target:             dev.Push.Target,
+		NoCache:            pushOpts.NoCache,
+		NoCacheFilter:      pushOpts.NoCacheFilter,
+		Force:              pushOpts.Force,
+		EnableContextCheck: true,
+		PullBase:           pushOpts.PullBase,
+		CacheFrom:          cacheFrom,
+		BuildArgs:          buildArgs,
+		OutputMode:         pushOpts.Progress,
+		OCIMediaTypes:      pushOpts.OCIMediaTypes,
+		Secrets:            pushOpts.Secrets,
+		SecretCommands:     pushOpts.SecretCommands,
 	}
-	if err := build.Run(ctx, dev.Namespace, buildOptions); err != nil {
-		return "", err
+	digest, err := build.Run(ctx, dev.Namespace, buildOptions)
+	if err != nil {
+		return "", "", err
 	}
 
-	return buildTag, nil
+	return buildTag, digest, nil
 }
 
-func getImageFromApp(trMap map[string]*apps.Translation) (string, error) {
+func outputImageDigest(imageTag, digest, outputPath string) error {
+	if okteto.IsOktetoContext() {
+		imageTag = registry.ExpandOktetoDevRegistry(imageTag)
+		imageTag = registry.ExpandOktetoGlobalRegistry(imageTag)
+	}
+	imageWithDigest := registry.GetImageWithDigest(imageTag, digest)
+	log.Information("Image digest: %s", imageWithDigest)
+
+	if outputPath == "" {
+		return nil
+	}
+	if err := os.WriteFile(outputPath, []byte(imageWithDigest), 0644); err != nil {
+		return fmt.Errorf("failed to write the image digest to '%s': %w", outputPath, err)
+	}
+	return nil
+}
+
+// scanImage runs the vulnerability scan of the pushed image if it is enabled by '--scan' or the 'scan' field of the okteto manifest
+func scanImage(ctx context.Context, dev *model.Dev, imageTag, digest string, pushOpts *pushOptions) error {
+	if !pushOpts.Scan && dev.Scan == nil {
+		return nil
+	}
+
+	if okteto.IsOktetoContext() {
+		imageTag = registry.ExpandOktetoDevRegistry(imageTag)
+		imageTag = registry.ExpandOktetoGlobalRegistry(imageTag)
+	}
+	image := imageTag
+	if digest != "" {
+		image = registry.GetImageWithDigest(imageTag, digest)
+	}
+
+	if dev.Scan != nil && dev.Scan.Hook != "" {
+		log.Information("Running the scan hook for '%s'...", image)
+		return scan.RunHook(ctx, dev.Scan.Hook, image, imageTag, digest, getScanCredentials(), os.Stdout)
+	}
+
+	spinner := utils.NewSpinner(fmt.Sprintf("Scanning '%s' for vulnerabilities...", image))
+	spinner.Start()
+	report, err := newScanner(dev).Scan(ctx, image)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+	return scan.Check(report, getScanSeverity(dev, pushOpts))
+}
+
+func newScanner(dev *model.Dev) scan.Scanner {
+	scanner := &scan.TrivyScanner{Env: getScanCredentials()}
+	if dev.Scan != nil {
+		scanner.Command = dev.Scan.Command
+	}
+	return scanner
+}
+
+// getScanCredentials returns the environment variables with the credentials of the okteto registry for the scanners
+func getScanCredentials() []string {
+	if !okteto.IsOktetoContext() {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf("TRIVY_USERNAME=%s", okteto.Context().UserID),
+		fmt.Sprintf("TRIVY_PASSWORD=%s", okteto.Context().Token),
+	}
+}
+
+func getScanSeverity(dev *model.Dev, pushOpts *pushOptions) string {
+	if pushOpts.ScanSeverity != "" {
+		return pushOpts.ScanSeverity
+	}
+	if dev.Scan != nil && dev.Scan.Severity != "" {
+		return dev.Scan.Severity
+	}
+	return scan.DefaultSeverity
+}
+
+func validateScanSeverity(dev *model.Dev, pushOpts *pushOptions) error {
+	if !pushOpts.Scan && dev.Scan == nil {
+		return nil
+	}
+	return scan.ValidateSeverity(getScanSeverity(dev, pushOpts))
+}
+
+// getPushContainer returns the container of an app whose image is replaced by the push.
+// '--container' targets a container or an init container of the main app, e.g. a migration runner
+func getPushContainer(tr *apps.Translation, rule *model.TranslationRule, container string) (*apiv1.Container, error) {
+	name := rule.Container
+	if container != "" && tr.Dev == tr.MainDev {
+		name = container
+	}
+	c := apps.GetContainer(tr.App.PodSpec(), name)
+	if c == nil {
+		return nil, fmt.Errorf("%s '%s': '%s' is neither a container nor an init container", tr.App.TypeMeta().Kind, tr.App.ObjectMeta().Name, name)
+	}
+	return c, nil
+}
+
+func getImageFromApp(trMap map[string]*apps.Translation, container string) (string, error) {
 	imageFromApp := ""
 	for _, tr := range trMap {
 		if tr.App == nil {
@@ -275,9 +612,9 @@ func getImageFromApp(trMap map[string]*apps.Translation) (string, error) {
 			continue
 		}
 		for _, rule := range tr.Rules {
-			devContainer := apps.GetDevContainer(tr.App.PodSpec(), rule.Container)
-			if devContainer == nil {
-				return "", fmt.Errorf("%s '%s': container '%s' not found", tr.App.TypeMeta().Kind, tr.App.ObjectMeta().Name, rule.Container)
+			devContainer, err := getPushContainer(tr, rule, container)
+			if err != nil {
+				return "", err
 			}
 			if imageFromApp == "" {
 				imageFromApp = devContainer.Image
@@ -0,0 +1,159 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_validateDeployStrategy(t *testing.T) {
+	var tests = []struct {
+		name     string
+		strategy string
+		wantErr  bool
+	}{
+		{name: "empty", strategy: "", wantErr: false},
+		{name: "recreate", strategy: deployStrategyRecreate, wantErr: false},
+		{name: "rolling", strategy: deployStrategyRolling, wantErr: false},
+		{name: "invalid", strategy: "bluegreen", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateDeployStrategy(tt.strategy); (err != nil) != tt.wantErr {
+				t.Errorf("validateDeployStrategy(%q) error = %v, wantErr %t", tt.strategy, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_setDeployStrategy(t *testing.T) {
+	var tests = []struct {
+		name         string
+		app          apps.App
+		strategy     string
+		wantType     appsv1.DeploymentStrategyType
+		wantPrevious *appsv1.DeploymentStrategyType
+		wantErr      bool
+	}{
+		{
+			name:     "not-set",
+			app:      newStrategyDeployment(appsv1.RollingUpdateDeploymentStrategyType),
+			strategy: "",
+			wantType: appsv1.RollingUpdateDeploymentStrategyType,
+		},
+		{
+			name:     "same-strategy",
+			app:      newStrategyDeployment(appsv1.RecreateDeploymentStrategyType),
+			strategy: deployStrategyRecreate,
+			wantType: appsv1.RecreateDeploymentStrategyType,
+		},
+		{
+			name:         "recreate",
+			app:          newStrategyDeployment(appsv1.RollingUpdateDeploymentStrategyType),
+			strategy:     deployStrategyRecreate,
+			wantType:     appsv1.RecreateDeploymentStrategyType,
+			wantPrevious: strategyTypePtr(appsv1.RollingUpdateDeploymentStrategyType),
+		},
+		{
+			name:         "rolling",
+			app:          newStrategyDeployment(appsv1.RecreateDeploymentStrategyType),
+			strategy:     deployStrategyRolling,
+			wantType:     appsv1.RollingUpdateDeploymentStrategyType,
+			wantPrevious: strategyTypePtr(appsv1.RecreateDeploymentStrategyType),
+		},
+		{
+			name: "statefulset",
+			app: apps.NewStatefulSetApp(&appsv1.StatefulSet{
+				TypeMeta:   metav1.TypeMeta{Kind: "StatefulSet"},
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "test"},
+			}),
+			strategy: deployStrategyRecreate,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous, err := setDeployStrategy(tt.app, tt.strategy)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantPrevious == nil && previous != nil {
+				t.Errorf("expected no previous strategy, got '%s'", previous.Type)
+			}
+			if tt.wantPrevious != nil && (previous == nil || previous.Type != *tt.wantPrevious) {
+				t.Errorf("expected previous strategy '%s', got %v", *tt.wantPrevious, previous)
+			}
+
+			d := tt.app.(*apps.DeploymentApp)
+			if got := d.SetStrategy(appsv1.DeploymentStrategy{}); got.Type != tt.wantType {
+				t.Errorf("got strategy '%s', expected '%s'", got.Type, tt.wantType)
+			}
+		})
+	}
+}
+
+func Test_restoreDeployStrategy(t *testing.T) {
+	ctx := context.Background()
+	d := newStrategyDeployment(appsv1.RollingUpdateDeploymentStrategyType)
+	c := fake.NewSimpleClientset()
+	if err := d.Deploy(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	previous, err := setDeployStrategy(d, deployStrategyRecreate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Deploy(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := restoreDeployStrategy(ctx, d, *previous, c); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.AppsV1().Deployments("test").Get(ctx, "api", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Spec.Strategy.Type != appsv1.RollingUpdateDeploymentStrategyType {
+		t.Errorf("got strategy '%s', expected it restored to '%s'", got.Spec.Strategy.Type, appsv1.RollingUpdateDeploymentStrategyType)
+	}
+}
+
+func newStrategyDeployment(strategy appsv1.DeploymentStrategyType) *apps.DeploymentApp {
+	return apps.NewDeploymentApp(&appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+		Spec: appsv1.DeploymentSpec{
+			Strategy: appsv1.DeploymentStrategy{Type: strategy},
+		},
+	})
+}
+
+func strategyTypePtr(strategy appsv1.DeploymentStrategyType) *appsv1.DeploymentStrategyType {
+	return &strategy
+}
@@ -21,6 +21,7 @@ import (
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/k8s/pods"
 	"github.com/okteto/okteto/pkg/log"
@@ -36,10 +37,11 @@ func Restart() *cobra.Command {
 	var namespace string
 	var k8sContext string
 	var devPath string
+	var restartDev bool
 
 	cmd := &cobra.Command{
 		Use:   "restart",
-		Short: "Restarts the deployments listed in the services field of the okteto manifest",
+		Short: "Restarts the deployments listed in the services field of the okteto manifest, or the command of your development container",
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#restart"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
@@ -52,6 +54,10 @@ func Restart() *cobra.Command {
 				return err
 			}
 
+			if restartDev {
+				return requestCommandRestart(dev)
+			}
+
 			if len(dev.Services) == 0 {
 				return errors.ErrNoServicesinOktetoManifest
 			}
@@ -77,10 +83,31 @@ func Restart() *cobra.Command {
 	cmd.Flags().StringVarP(&devPath, "file", "f", utils.DefaultDevManifest, "path to the manifest file")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace where the restart command is executed")
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the restart command is executed")
+	cmd.Flags().BoolVarP(&restartDev, "dev", "", false, "restart the command of your development container without recreating it, keeping the file synchronization and port forwards")
 
 	return cmd
 }
 
+// requestCommandRestart asks the running 'okteto up' to relaunch the command of the development container
+func requestCommandRestart(dev *model.Dev) error {
+	state, err := config.GetState(dev)
+	if err != nil {
+		return err
+	}
+	if state != config.Ready {
+		return errors.UserError{
+			E:    fmt.Errorf("the command of your development container is not running"),
+			Hint: "Wait until 'okteto up' finishes synchronizing your files and try again",
+		}
+	}
+
+	if err := config.RequestCommandRestart(dev); err != nil {
+		return err
+	}
+	log.Success("Requested 'okteto up' to restart the command of your development container")
+	return nil
+}
+
 func executeRestart(ctx context.Context, dev *model.Dev, sn string) error {
 	log.Infof("restarting services")
 	client, _, err := okteto.GetK8sClient()
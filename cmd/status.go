@@ -38,6 +38,7 @@ func Status() *cobra.Command {
 	var k8sContext string
 	var showInfo bool
 	var watch bool
+	var restartSync bool
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Status of the synchronization process",
@@ -72,6 +73,14 @@ func Status() *cobra.Command {
 				log.Infof("error accessing the syncthing info file: %s", err)
 				return errors.ErrNotInDevMode
 			}
+
+			if restartSync {
+				if err := config.RequestSyncRestart(dev); err != nil {
+					return err
+				}
+				log.Success("Requested 'okteto up' to restart the file synchronization")
+				return nil
+			}
 			if showInfo {
 				log.Information("Local syncthing url: http://%s", sy.GUIAddress)
 				log.Information("Remote syncthing url: http://%s", sy.RemoteGUIAddress)
@@ -94,6 +103,7 @@ func Status() *cobra.Command {
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the up command is executing")
 	cmd.Flags().BoolVarP(&showInfo, "info", "i", false, "show syncthing links for troubleshooting the synchronization service")
 	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "watch for changes")
+	cmd.Flags().BoolVarP(&restartSync, "restart-sync", "", false, "restart the file synchronization of the running 'okteto up' without recreating the development container")
 	return cmd
 }
 
@@ -58,6 +58,12 @@ func (up *upContext) activate() error {
 	up.cleaned = make(chan string, 1)
 	up.hardTerminate = make(chan error, 1)
 
+	if !up.isRetry {
+		if err := up.deploy(ctx); err != nil {
+			return err
+		}
+	}
+
 	app, create, err := utils.GetApp(ctx, up.Dev, up.Client)
 	if err != nil {
 		return err
@@ -135,6 +141,11 @@ func (up *upContext) activate() error {
 
 	up.success = true
 
+	if up.Options.SyncOnce {
+		log.Information("Files synchronized once, stopping the file synchronization service")
+		return nil
+	}
+
 	go func() {
 		output := <-up.cleaned
 		log.Debugf("clean command output: %s", output)
@@ -163,20 +174,22 @@ func (up *upContext) activate() error {
 			}
 
 		}
-		divertURL := ""
+		divertURLs := []string{}
 		if up.Dev.Divert != nil {
-			username := okteto.GetSanitizedUsername()
-			name := model.DivertName(up.Dev.Divert.Ingress, username)
-			i, err := ingressesv1.Get(ctx, name, up.Dev.Namespace, up.Client)
-			if err != nil {
-				log.Errorf("error getting diverted ingress %s: %s", name, err.Error())
-			} else if len(i.Spec.Rules) > 0 {
-				divertURL = i.Spec.Rules[0].Host
+			for _, ingress := range up.Dev.Divert.Ingress {
+				name := model.DivertName(ingress, okteto.GetDivertKey(up.Dev))
+				i, err := ingressesv1.Get(ctx, name, up.Dev.Namespace, up.Client)
+				if err != nil {
+					log.Errorf("error getting diverted ingress %s: %s", name, err.Error())
+				} else if len(i.Spec.Rules) > 0 {
+					divertURLs = append(divertURLs, i.Spec.Rules[0].Host)
+				}
 			}
 		}
-		printDisplayContext(up.Dev, divertURL)
+		printDisplayContext(up.Dev, divertURLs)
 		durationActivateUp := time.Since(up.StartTime)
 		analytics.TrackDurationActivateUp(durationActivateUp)
+		up.waitForReverseTargets(ctx)
 		if hook == "yes" {
 			log.Information("Running start.sh hook...")
 			if err := up.runCommand(ctx, []string{"/var/okteto/cloudbin/start.sh"}); err != nil {
@@ -184,7 +197,12 @@ func (up *upContext) activate() error {
 				return
 			}
 		}
-		up.CommandResult <- up.runCommand(ctx, up.Dev.Command.Values)
+		command, err := up.getCommand(ctx)
+		if err != nil {
+			up.CommandResult <- err
+			return
+		}
+		up.CommandResult <- up.runCommandWithRestarts(ctx, command)
 	}()
 
 	prevError := up.waitUntilExitOrInterruptOrApply(ctx)
@@ -205,7 +223,7 @@ func (up *upContext) shouldRetry(ctx context.Context, err error) bool {
 	switch err {
 	case nil:
 		return false
-	case errors.ErrLostSyncthing:
+	case errors.ErrLostSyncthing, errors.ErrLostPortForward:
 		return true
 	case errors.ErrCommandFailed:
 		return !up.Sy.Ping(ctx, false)
@@ -233,6 +251,10 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 		return err
 	}
 
+	if err := secrets.CheckImagePullSecrets(ctx, up.Dev, up.Client); err != nil {
+		return err
+	}
+
 	if up.Dev.PersistentVolumeEnabled() {
 		if err := volumes.CreateForDev(ctx, up.Dev, up.Client, up.Options.DevPath); err != nil {
 			return err
@@ -280,7 +302,7 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 		}
 	}
 
-	pod, err := apps.GetRunningPodInLoop(ctx, up.Dev, devApp, up.Client)
+	pod, err := up.getDevPod(ctx, devApp)
 	if err != nil {
 		return err
 	}
@@ -377,7 +399,7 @@ func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context,
 				spinner.Update("Pulling images...")
 				spinner.Start()
 			case "Killing":
-				if app.TypeMeta().Kind == model.StatefulSet {
+				if app.TypeMeta().Kind == model.StatefulSet || app.TypeMeta().Kind == model.DaemonSet {
 					killing = true
 					continue
 				}
@@ -0,0 +1,68 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/okteto/okteto/pkg/k8s/apply"
+	"github.com/okteto/okteto/pkg/log"
+)
+
+// deploy runs the commands and applies the manifests of the deploy section before activating the development container
+func (up *upContext) deploy(ctx context.Context) error {
+	if up.Dev.Deploy == nil {
+		return nil
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(up.Options.DevPath))
+	if err != nil {
+		return err
+	}
+
+	for _, command := range up.Dev.Deploy.Commands {
+		log.Information("Running '%s'...", command)
+		if err := runDeployCommand(ctx, dir, command); err != nil {
+			return fmt.Errorf("error running deploy command '%s': %w", command, err)
+		}
+	}
+
+	if len(up.Dev.Deploy.Manifests) > 0 {
+		log.Information("Applying deploy manifests...")
+		if err := apply.Files(ctx, up.Dev.Namespace, up.Dev.Deploy.Manifests); err != nil {
+			return fmt.Errorf("error applying deploy manifests: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func runDeployCommand(ctx context.Context, dir, command string) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// envFilesPollInterval is the frequency to check if the env files of the development container have changed
+const envFilesPollInterval = 2 * time.Second
+
+// watchEnvFiles reloads the env files of the development container when they change.
+// If the environment of the development container changes, it returns errors.ErrApplyToApp
+// so the reconnection logic redeploys the development container with the new environment.
+// This recreates the development container and restarts its command
+func (up *upContext) watchEnvFiles(ctx context.Context) chan error {
+	result := make(chan error, 1)
+	if up.Options == nil || !up.Options.WatchEnvFiles || len(up.Dev.EnvFiles) == 0 {
+		return result
+	}
+
+	go func() {
+		t := time.NewTicker(envFilesPollInterval)
+		defer t.Stop()
+		modTimes := getEnvFilesModTimes(up.Dev)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				current := getEnvFilesModTimes(up.Dev)
+				if reflect.DeepEqual(modTimes, current) {
+					continue
+				}
+				modTimes = current
+				if reloadEnvFiles(up.Dev) {
+					log.Information("Environment file changed, redeploying your development container...")
+					result <- errors.ErrApplyToApp
+					return
+				}
+			}
+		}
+	}()
+	return result
+}
+
+func getEnvFilesModTimes(dev *model.Dev) map[string]time.Time {
+	result := map[string]time.Time{}
+	for _, path := range dev.EnvFiles {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		result[path] = info.ModTime()
+	}
+	return result
+}
+
+// reloadEnvFiles reloads the env files of the development container and returns if its environment has changed.
+// If the env files can't be loaded, the previous environment is kept
+func reloadEnvFiles(dev *model.Dev) bool {
+	previous := dev.Environment
+	if err := dev.LoadEnvFiles(); err != nil {
+		log.Warning("Environment file not reloaded: %s", err.Error())
+		dev.Environment = previous
+		return false
+	}
+	return !reflect.DeepEqual(previous, dev.Environment)
+}
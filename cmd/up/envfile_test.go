@@ -0,0 +1,54 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func Test_reloadEnvFiles(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(envFile, []byte("DEBUG=false\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	dev := &model.Dev{EnvFiles: model.EnvFiles{envFile}}
+	if err := dev.LoadEnvFiles(); err != nil {
+		t.Fatal(err)
+	}
+
+	if reloadEnvFiles(dev) {
+		t.Error("environment changed without modifying the env file")
+	}
+
+	if err := os.WriteFile(envFile, []byte("DEBUG=true\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if !reloadEnvFiles(dev) {
+		t.Error("environment didn't change after modifying the env file")
+	}
+
+	if err := os.WriteFile(envFile, []byte("DEBUG\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if reloadEnvFiles(dev) {
+		t.Error("environment changed with an invalid env file")
+	}
+	if len(dev.Environment) != 1 || dev.Environment[0].Value != "true" {
+		t.Errorf("previous environment was not kept: %+v", dev.Environment)
+	}
+}
@@ -17,8 +17,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
@@ -82,6 +84,115 @@ func (up *upContext) runCommand(ctx context.Context, cmd []string) error {
 	)
 }
 
+// shellFallbacks are the shells probed, in order, when the development command is a shell
+var shellFallbacks = []string{"bash", "sh", "ash"}
+
+// getCommand returns the command executed in the development container.
+// A shell is detected when '--shell' is set or the okteto manifest doesn't define a command
+func (up *upContext) getCommand(ctx context.Context) ([]string, error) {
+	if !up.Dev.EmptyCommand && (up.Options == nil || !up.Options.Shell) {
+		return up.Dev.Command.Values, nil
+	}
+	shell, err := up.getShell(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []string{shell}, nil
+}
+
+// getShell returns the first shell of shellFallbacks available in the development container
+func (up *upContext) getShell(ctx context.Context) (string, error) {
+	for _, shell := range shellFallbacks {
+		err := up.probeShell(ctx, shell)
+		if err == nil {
+			log.Infof("using shell '%s'", shell)
+			return shell, nil
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		log.Infof("shell '%s' is not available: %s", shell, err)
+	}
+	return "", errors.UserError{
+		E:    fmt.Errorf("none of the shells %s is available in your development container", strings.Join(shellFallbacks, ", ")),
+		Hint: "Set 'command' in your okteto manifest to a program available in your development image",
+	}
+}
+
+// probeShell returns an error if the shell can't be executed in the development container
+func (up *upContext) probeShell(ctx context.Context, shell string) error {
+	cmd := []string{shell, "-c", "exit 0"}
+	if up.Dev.RemoteModeEnabled() {
+		return ssh.Exec(ctx, up.Dev.Interface, up.Dev.RemotePort, false, strings.NewReader(""), io.Discard, io.Discard, cmd)
+	}
+	return exec.Exec(ctx, up.Client, up.RestConfig, up.Dev.Namespace, up.Pod.Name, up.Dev.Container, false, strings.NewReader(""), io.Discard, io.Discard, cmd)
+}
+
+// runCommandWithRetries runs the remote command, relaunching it up to 'ExecRetries' times when it fails.
+// Transient errors are not retried here, they are handled by the reconnection loop
+func (up *upContext) runCommandWithRetries(ctx context.Context, cmd []string) error {
+	return retryCommand(ctx, up.Options.ExecRetries, func() error {
+		return up.runCommand(ctx, cmd)
+	})
+}
+
+func retryCommand(ctx context.Context, maxRetries int, run func() error) error {
+	for retries := 0; ; retries++ {
+		err := run()
+		if err == nil || errors.IsTransient(err) || ctx.Err() != nil || retries >= maxRetries {
+			return err
+		}
+		log.Infof("command failed: %s", err)
+		log.Yellow("Command failed, relaunching it (retry %d of %d)...", retries+1, maxRetries)
+	}
+}
+
+// commandRestartPollInterval is how often the requests of 'okteto restart --dev' are checked
+var commandRestartPollInterval = 1 * time.Second
+
+// runCommandWithRestarts runs the remote command, relaunching it every time 'okteto restart --dev' requests it.
+// The file synchronization and the port forwards are not affected
+func (up *upContext) runCommandWithRestarts(ctx context.Context, cmd []string) error {
+	// requests made before the command was running are discarded
+	config.ConsumeCommandRestartRequest(up.Dev)
+
+	t := time.NewTicker(commandRestartPollInterval)
+	defer t.Stop()
+	for {
+		cmdCtx, cancel := context.WithCancel(ctx)
+		result := make(chan error, 1)
+		go func() {
+			result <- up.runCommandWithRetries(cmdCtx, cmd)
+		}()
+
+		restart := false
+		for !restart {
+			select {
+			case err := <-result:
+				cancel()
+				return err
+			case <-t.C:
+				if !config.ConsumeCommandRestartRequest(up.Dev) {
+					continue
+				}
+				if !up.Dev.RemoteModeEnabled() {
+					log.Warning("Restarting the development command is not supported when 'OKTETO_EXECUTE_SSH' is 'false'")
+					continue
+				}
+				restart = true
+			}
+		}
+
+		fmt.Println()
+		log.Information("Restarting your development command...")
+		cancel()
+		<-result
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
 func (up *upContext) checkOktetoStartError(ctx context.Context, msg string) error {
 	app, err := apps.Get(ctx, up.Dev, up.Dev.Namespace, up.Client)
 	if err != nil {
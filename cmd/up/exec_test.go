@@ -0,0 +1,95 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func Test_retryCommand(t *testing.T) {
+	var tests = []struct {
+		name       string
+		maxRetries int
+		errs       []error
+		canceled   bool
+		wantCalls  int
+		wantErr    bool
+	}{
+		{
+			name:       "success",
+			maxRetries: 3,
+			errs:       []error{nil},
+			wantCalls:  1,
+		},
+		{
+			name:       "success-after-retries",
+			maxRetries: 3,
+			errs:       []error{fmt.Errorf("exit status 1"), fmt.Errorf("exit status 1"), nil},
+			wantCalls:  3,
+		},
+		{
+			name:       "retries-exhausted",
+			maxRetries: 2,
+			errs:       []error{fmt.Errorf("exit status 1"), fmt.Errorf("exit status 1"), fmt.Errorf("exit status 1"), nil},
+			wantCalls:  3,
+			wantErr:    true,
+		},
+		{
+			name:       "no-retries",
+			maxRetries: 0,
+			errs:       []error{fmt.Errorf("exit status 1"), nil},
+			wantCalls:  1,
+			wantErr:    true,
+		},
+		{
+			name:       "transient-error",
+			maxRetries: 3,
+			errs:       []error{fmt.Errorf("connection reset by peer"), nil},
+			wantCalls:  1,
+			wantErr:    true,
+		},
+		{
+			name:       "canceled",
+			maxRetries: 3,
+			errs:       []error{fmt.Errorf("exit status 1"), nil},
+			canceled:   true,
+			wantCalls:  1,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.canceled {
+				cancel()
+			}
+
+			calls := 0
+			err := retryCommand(ctx, tt.maxRetries, func() error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, wantErr %t", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("got %d calls, expected %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
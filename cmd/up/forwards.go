@@ -16,6 +16,9 @@ package up
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
+	"time"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/k8s/forward"
@@ -36,7 +39,9 @@ func (up *upContext) forwards(ctx context.Context) error {
 	}
 
 	log.Infof("starting port forwards")
-	up.Forwarder = forward.NewPortForwardManager(ctx, up.Dev.Interface, up.RestConfig, up.Client, up.Dev.Namespace)
+	pf := forward.NewPortForwardManager(ctx, up.Dev.Interface, up.RestConfig, up.Client, up.Dev.Namespace)
+	pf.SetDisconnect(up.Disconnect)
+	up.Forwarder = pf
 
 	for idx, f := range up.Dev.Forward {
 		if f.Labels != nil {
@@ -66,11 +71,12 @@ func (up *upContext) forwards(ctx context.Context) error {
 func (up *upContext) sshForwards(ctx context.Context) error {
 	log.Infof("starting SSH port forwards")
 	f := forward.NewPortForwardManager(ctx, up.Dev.Interface, up.RestConfig, up.Client, up.Dev.Namespace)
+	f.SetDisconnect(up.Disconnect)
 	if err := f.Add(model.Forward{Local: up.Dev.RemotePort, Remote: up.Dev.SSHServerPort}); err != nil {
 		return err
 	}
 
-	up.Forwarder = ssh.NewForwardManager(ctx, fmt.Sprintf(":%d", up.Dev.RemotePort), up.Dev.Interface, "0.0.0.0", f, up.Dev.Namespace)
+	up.Forwarder = ssh.NewForwardManager(ctx, fmt.Sprintf(":%d", up.Dev.RemotePort), up.Dev.Interface, getRemoteInterface(up.Dev), f, up.Dev.Namespace)
 
 	if err := up.Forwarder.Add(model.Forward{Local: up.Sy.RemotePort, Remote: syncthing.ClusterPort}); err != nil {
 		return err
@@ -107,3 +113,52 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 
 	return up.Forwarder.Start(up.Pod.Name, up.Dev.Namespace)
 }
+
+// waitForReverseTargets holds the dev command until the local ports of the reverse forwards with 'waitForLocal' accept connections
+func (up *upContext) waitForReverseTargets(ctx context.Context) {
+	for _, r := range up.Dev.Reverse {
+		if !r.WaitForLocal {
+			continue
+		}
+		address := net.JoinHostPort(up.Dev.Interface, strconv.Itoa(r.Local))
+		log.Information("Waiting for %s to accept connections...", address)
+		if err := waitForLocalPort(ctx, address, up.Dev.Timeout.Default); err != nil {
+			log.Warning("%s. Running your command anyway", err.Error())
+		}
+	}
+}
+
+func waitForLocalPort(ctx context.Context, address string, timeout time.Duration) error {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	to := time.Now().Add(timeout)
+
+	for {
+		conn, err := net.DialTimeout("tcp", address, time.Second)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		log.Infof("reverse forward target %s is not ready: %s", address, err)
+
+		if time.Now().After(to) {
+			return fmt.Errorf("%s didn't accept connections after %s", address, timeout.String())
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// getRemoteInterface returns the interface where reverse forwards listen in the development container.
+// In host network mode they are bound to the node loopback so they are not exposed to the node network
+func getRemoteInterface(dev *model.Dev) string {
+	if dev.HostNetwork {
+		return "127.0.0.1"
+	}
+	return "0.0.0.0"
+}
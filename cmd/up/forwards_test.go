@@ -0,0 +1,38 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func Test_waitForLocalPort(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := l.Addr().String()
+
+	if err := waitForLocalPort(context.Background(), address, time.Second); err != nil {
+		t.Errorf("unexpected error waiting for a listening port: %s", err)
+	}
+
+	l.Close()
+	if err := waitForLocalPort(context.Background(), address, 100*time.Millisecond); err == nil {
+		t.Error("expected error waiting for a closed port")
+	}
+}
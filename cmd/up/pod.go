@@ -0,0 +1,78 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// getDevPod returns the pod of the development container.
+// On reconnects, the pod of the previous activation is reused if it's still alive and the development container wasn't updated,
+// which avoids listing all the pods of the namespace
+func (up *upContext) getDevPod(ctx context.Context, devApp apps.App) (*apiv1.Pod, error) {
+	generation := devApp.ObjectMeta().Generation
+	if pod := up.getCachedDevPod(ctx, generation, up.Client); pod != nil {
+		return pod, nil
+	}
+
+	pod, err := apps.GetRunningPodInLoop(ctx, up.Dev, devApp, up.Client)
+	if err != nil {
+		return nil, err
+	}
+	up.devPodGeneration = generation
+	return pod, nil
+}
+
+// getCachedDevPod returns the pod of the previous activation, or nil if it has to be resolved again
+func (up *upContext) getCachedDevPod(ctx context.Context, generation int64, c kubernetes.Interface) *apiv1.Pod {
+	if up.Pod == nil {
+		return nil
+	}
+
+	if up.devPodGeneration != generation {
+		log.Infof("development container was updated, resolving its pod again")
+		return nil
+	}
+
+	pod, err := c.CoreV1().Pods(up.Dev.Namespace).Get(ctx, up.Pod.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Infof("failed to get the cached pod '%s': %s", up.Pod.Name, err)
+		return nil
+	}
+
+	if pod.UID != up.Pod.UID {
+		log.Infof("pod '%s' was recreated, resolving the development container pod again", pod.Name)
+		return nil
+	}
+
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != apiv1.PodRunning {
+		log.Infof("pod '%s' is not running, resolving the development container pod again", pod.Name)
+		return nil
+	}
+
+	if err := pods.CheckCrashLoopBackOff(pod); err != nil {
+		log.Infof("%s, resolving the development container pod again", err)
+		return nil
+	}
+
+	log.Infof("reusing the development container pod '%s'", pod.Name)
+	return pod
+}
@@ -0,0 +1,134 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_getCachedDevPod(t *testing.T) {
+	now := metav1.Now()
+	cached := &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "dev-pod", Namespace: "ns", UID: types.UID("1")}}
+
+	var tests = []struct {
+		name       string
+		cached     *apiv1.Pod
+		generation int64
+		pod        *apiv1.Pod
+		expected   bool
+	}{
+		{
+			name:       "no-cached-pod",
+			cached:     nil,
+			generation: 1,
+			pod:        cached,
+			expected:   false,
+		},
+		{
+			name:       "reused",
+			cached:     cached,
+			generation: 1,
+			pod:        &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "dev-pod", Namespace: "ns", UID: types.UID("1")}, Status: apiv1.PodStatus{Phase: apiv1.PodRunning}},
+			expected:   true,
+		},
+		{
+			name:       "dev-mode-updated",
+			cached:     cached,
+			generation: 2,
+			pod:        cached,
+			expected:   false,
+		},
+		{
+			name:       "pod-deleted",
+			cached:     cached,
+			generation: 1,
+			pod:        nil,
+			expected:   false,
+		},
+		{
+			name:       "pod-recreated",
+			cached:     cached,
+			generation: 1,
+			pod:        &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "dev-pod", Namespace: "ns", UID: types.UID("2")}},
+			expected:   false,
+		},
+		{
+			name:       "pod-terminating",
+			cached:     cached,
+			generation: 1,
+			pod:        &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "dev-pod", Namespace: "ns", UID: types.UID("1"), DeletionTimestamp: &now}},
+			expected:   false,
+		},
+		{
+			name:       "pod-failed",
+			cached:     cached,
+			generation: 1,
+			pod:        &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "dev-pod", Namespace: "ns", UID: types.UID("1")}, Status: apiv1.PodStatus{Phase: apiv1.PodFailed}},
+			expected:   false,
+		},
+		{
+			name:       "pod-pending",
+			cached:     cached,
+			generation: 1,
+			pod:        &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "dev-pod", Namespace: "ns", UID: types.UID("1")}, Status: apiv1.PodStatus{Phase: apiv1.PodPending}},
+			expected:   false,
+		},
+		{
+			name:       "dev-container-crashing",
+			cached:     cached,
+			generation: 1,
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "dev-pod", Namespace: "ns", UID: types.UID("1")},
+				Status: apiv1.PodStatus{
+					Phase: apiv1.PodRunning,
+					ContainerStatuses: []apiv1.ContainerStatus{
+						{
+							Name:  "dev",
+							State: apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+						},
+					},
+				},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset()
+			if tt.pod != nil {
+				c = fake.NewSimpleClientset(tt.pod)
+			}
+			up := &upContext{
+				Dev:              &model.Dev{Name: "dev", Namespace: "ns"},
+				Pod:              tt.cached,
+				devPodGeneration: 1,
+			}
+			pod := up.getCachedDevPod(context.Background(), tt.generation, c)
+			if tt.expected && pod == nil {
+				t.Fatal("expected the cached pod to be reused")
+			}
+			if !tt.expected && pod != nil {
+				t.Fatalf("expected the pod to be resolved again, got '%s'", pod.Name)
+			}
+		})
+	}
+}
@@ -17,7 +17,6 @@ import (
 	"bufio"
 	"crypto/md5"
 	"fmt"
-	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -35,15 +34,14 @@ func addStignoreSecrets(dev *model.Dev) error {
 	output := ""
 	for i, folder := range dev.Sync.Folders {
 		stignorePath := filepath.Join(folder.LocalPath, ".stignore")
-		if !model.FileExists(stignorePath) {
-			continue
-		}
-		infile, err := os.Open(stignorePath)
+		lines, err := readStignore(stignorePath)
 		if err != nil {
 			return err
 		}
-		defer infile.Close()
-		reader := bufio.NewReader(infile)
+		defaultIgnores := getDefaultIgnores(lines, dev.Sync.GetDefaultIgnores())
+		if lines == nil && len(defaultIgnores) == 0 {
+			continue
+		}
 
 		stignoreName := fmt.Sprintf("stignore-%d", i+1)
 		transformedStignorePath := filepath.Join(config.GetAppHome(dev.Namespace, dev.Name), stignoreName)
@@ -56,16 +54,8 @@ func addStignoreSecrets(dev *model.Dev) error {
 		writer := bufio.NewWriter(outfile)
 		defer writer.Flush()
 
-		for {
-			bytes, _, err := reader.ReadLine()
-			if err != nil {
-				if err == io.EOF {
-					break
-				}
-				return err
-			}
-
-			line := strings.TrimSpace(string(bytes))
+		for _, line := range lines {
+			line = strings.TrimSpace(line)
 			if line == "" {
 				continue
 			}
@@ -83,6 +73,17 @@ func addStignoreSecrets(dev *model.Dev) error {
 			output = fmt.Sprintf("%s\n%s", output, line)
 		}
 
+		if len(defaultIgnores) > 0 {
+			log.Infof("ignoring %v by default in the remote folder '%s'", defaultIgnores, folder.RemotePath)
+		}
+		for _, pattern := range defaultIgnores {
+			_, err = writer.WriteString(fmt.Sprintf("(?d)%s\n", pattern))
+			if err != nil {
+				return err
+			}
+			output = fmt.Sprintf("%s\n%s", output, pattern)
+		}
+
 		dev.Secrets = append(
 			dev.Secrets,
 			model.Secret{
@@ -96,7 +97,20 @@ func addStignoreSecrets(dev *model.Dev) error {
 	return nil
 }
 
+// readStignore returns the lines of a '.stignore' file, or nil if it doesn't exist
+func readStignore(stignorePath string) ([]string, error) {
+	if !model.FileExists(stignorePath) {
+		return nil, nil
+	}
+	stignoreBytes, err := os.ReadFile(stignorePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %s", stignorePath, err.Error())
+	}
+	return strings.Split(string(stignoreBytes), "\n"), nil
+}
+
 func checkStignoreConfiguration(dev *model.Dev) error {
+	defaultIgnores := dev.Sync.GetDefaultIgnores()
 	for _, folder := range dev.Sync.Folders {
 		stignorePath := filepath.Join(folder.LocalPath, ".stignore")
 		gitPath := filepath.Join(folder.LocalPath, ".git")
@@ -108,7 +122,8 @@ func checkStignoreConfiguration(dev *model.Dev) error {
 		}
 
 		log.Infof("'.stignore' exists in folder '%s'", folder.LocalPath)
-		if !model.FileExists(gitPath) {
+		if len(defaultIgnores) > 0 || !model.FileExists(gitPath) {
+			// '.git' is already ignored by default in the remote folder
 			continue
 		}
 
@@ -171,3 +186,34 @@ func askIfUpdatingStignore(folder, stignorePath string) error {
 	}
 	return nil
 }
+
+// getDefaultIgnores returns the default patterns not mentioned in the lines of a '.stignore' file.
+// Patterns commented out or negated in the file are considered an explicit override and never ignored by default
+func getDefaultIgnores(stignoreLines, defaultIgnores []string) []string {
+	mentioned := map[string]bool{}
+	for _, line := range stignoreLines {
+		mentioned[getStignorePattern(line)] = true
+	}
+
+	result := []string{}
+	for _, pattern := range defaultIgnores {
+		if !mentioned[pattern] {
+			result = append(result, pattern)
+		}
+	}
+	return result
+}
+
+func getStignorePattern(line string) string {
+	pattern := strings.TrimSpace(line)
+	for {
+		trimmed := strings.TrimSpace(strings.TrimPrefix(pattern, "//"))
+		trimmed = strings.TrimPrefix(trimmed, "!")
+		trimmed = strings.TrimPrefix(trimmed, "(?d)")
+		trimmed = strings.TrimPrefix(trimmed, "(?i)")
+		if trimmed == pattern {
+			return pattern
+		}
+		pattern = trimmed
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func Test_getDefaultIgnores(t *testing.T) {
+	var tests = []struct {
+		name     string
+		lines    []string
+		defaults []string
+		expected []string
+	}{
+		{
+			name:     "disabled",
+			lines:    []string{"node_modules"},
+			defaults: nil,
+			expected: []string{},
+		},
+		{
+			name:     "no-stignore",
+			lines:    nil,
+			defaults: []string{".git", "*.swp"},
+			expected: []string{".git", "*.swp"},
+		},
+		{
+			name:     "already-ignored",
+			lines:    []string{"node_modules", ".git", "*.swp"},
+			defaults: []string{".git", "*.swp"},
+			expected: []string{},
+		},
+		{
+			name:     "overridden",
+			lines:    []string{"// .git", "!(?d)*.swp"},
+			defaults: []string{".git", "*.swp", ".DS_Store"},
+			expected: []string{".DS_Store"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getDefaultIgnores(tt.lines, tt.defaults)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_addStignoreSecretsDefaultIgnores(t *testing.T) {
+	t.Setenv("OKTETO_FOLDER", t.TempDir())
+	local := t.TempDir()
+	stignorePath := filepath.Join(local, ".stignore")
+	stignoreContent := "node_modules\n// .git\n"
+	if err := os.WriteFile(stignorePath, []byte(stignoreContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dev := &model.Dev{
+		Name:        "dev",
+		Namespace:   "ns",
+		Annotations: map[string]string{},
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{{LocalPath: local, RemotePath: "/app"}},
+		},
+	}
+	defaultSyncIgnores := model.DefaultSyncIgnores
+	model.DefaultSyncIgnores = []string{".git", "*.swp"}
+	defer func() { model.DefaultSyncIgnores = defaultSyncIgnores }()
+	if err := addStignoreSecrets(dev); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(stignorePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != stignoreContent {
+		t.Errorf("the local '.stignore' was modified: %q", string(got))
+	}
+
+	if len(dev.Secrets) != 1 {
+		t.Fatalf("expected 1 secret, got %d", len(dev.Secrets))
+	}
+	remote, err := os.ReadFile(dev.Secrets[0].LocalPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "(?d)node_modules\n(?d)// .git\n(?d)*.swp\n"
+	if string(remote) != expected {
+		t.Errorf("got remote '.stignore' %q, expected %q", string(remote), expected)
+	}
+}
@@ -26,7 +26,19 @@ import (
 	"github.com/okteto/okteto/pkg/syncthing"
 )
 
+const syncModeRestartAttempts = 3
+
+var syncModeRestartBackoff = 2 * time.Second
+
+var syncRestartPollInterval = 1 * time.Second
+
 func (up *upContext) initializeSyncthing() error {
+	if up.resetSyncthing && !up.isRetry {
+		if err := syncthing.RemoveDatabase(up.Dev); err != nil {
+			log.Infof("failed to remove the syncthing database: %s", err)
+		}
+	}
+
 	sy, err := syncthing.New(up.Dev)
 	if err != nil {
 		return err
@@ -74,6 +86,10 @@ func (up *upContext) sync(ctx context.Context) error {
     More information is available here: https://okteto.com/docs/reference/file-synchronization/`, minutes, seconds)
 	}
 
+	if up.Options.SyncOnce {
+		return nil
+	}
+
 	up.Sy.Type = "sendreceive"
 	up.Sy.IgnoreDelete = false
 	if err := up.Sy.UpdateConfig(); err != nil {
@@ -82,8 +98,91 @@ func (up *upContext) sync(ctx context.Context) error {
 
 	go up.Sy.Monitor(ctx, up.Disconnect)
 	go up.Sy.MonitorStatus(ctx, up.Disconnect)
-	log.Infof("restarting syncthing to update sync mode to sendreceive")
-	return up.Sy.Restart(ctx)
+	go up.Sy.MonitorConflicts(ctx)
+	if up.Options.WatchEvents {
+		go up.Sy.MonitorSyncedItems(ctx, printSyncedItem)
+	}
+	go up.watchSyncRestartRequests(ctx)
+	return up.restartSyncthing(ctx)
+}
+
+// watchSyncRestartRequests restarts the file synchronization when it's requested by 'okteto status --restart-sync'
+func (up *upContext) watchSyncRestartRequests(ctx context.Context) {
+	// requests made before the file synchronization was ready are discarded
+	config.ConsumeSyncRestartRequest(up.Dev)
+
+	t := time.NewTicker(syncRestartPollInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if config.ConsumeSyncRestartRequest(up.Dev) {
+				up.restartSync(ctx, up.Sy)
+			}
+		}
+	}
+}
+
+// restartSync restarts the local syncthing and forces the local state of the files to the remote syncthing.
+// The development container and the remote command are not affected
+func (up *upContext) restartSync(ctx context.Context, sy *syncthing.Syncthing) {
+	log.Information("Restarting the file synchronization...")
+	if err := sy.Restart(ctx); err != nil {
+		log.Warning("Failed to restart the file synchronization: %s", err)
+		return
+	}
+
+	if err := sy.WaitForPing(ctx, true); err != nil {
+		log.Warning("Failed to restart the file synchronization: %s", err)
+		return
+	}
+
+	if err := sy.Overwrite(ctx); err != nil {
+		log.Infof("failed to force the local state of the files: %s", err)
+	}
+
+	log.Success("File synchronization restarted")
+}
+
+// printSyncedItem prints a file synchronized by syncthing with its direction
+func printSyncedItem(item syncthing.SyncedItem) {
+	arrow := "↑"
+	if item.Direction == syncthing.SyncDirectionDown {
+		arrow = "↓"
+	}
+	if item.Error != "" {
+		log.Warning("%s %s (%s): %s", arrow, item.Path, item.Action, item.Error)
+		return
+	}
+	log.Information("%s %s (%s)", arrow, item.Path, item.Action)
+}
+
+// restartSyncthing restarts syncthing to apply the sendreceive sync mode.
+// Files are already synchronized at this point, so a persistent failure keeps the session with a warning
+func (up *upContext) restartSyncthing(ctx context.Context) error {
+	var err error
+	for attempt := 1; attempt <= syncModeRestartAttempts; attempt++ {
+		log.Infof("restarting syncthing to update sync mode to sendreceive")
+		if err = up.Sy.Restart(ctx); err == nil {
+			return nil
+		}
+		log.Infof("failed to restart syncthing (attempt %d/%d): %s", attempt, syncModeRestartAttempts, err)
+		if attempt == syncModeRestartAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(syncModeRestartBackoff):
+		}
+	}
+
+	log.Warning(`Failed to switch the file synchronization to two-way mode: %s
+    Changes in your local files are synchronized, but changes in your development container might not be synchronized back
+    Run '%s' + 'okteto up' to fix it`, err, utils.GetDownCommand(up.Options.DevPath))
+	return nil
 }
 
 func (up *upContext) startSyncthing(ctx context.Context) error {
@@ -0,0 +1,123 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+func Test_restartSyncthing(t *testing.T) {
+	syncModeRestartBackoff = time.Millisecond
+	tests := []struct {
+		name      string
+		fails     int
+		wantCalls int
+	}{
+		{
+			name:      "restarted",
+			fails:     0,
+			wantCalls: 1,
+		},
+		{
+			name:      "restarted-after-retry",
+			fails:     4,
+			wantCalls: 5,
+		},
+		{
+			name:      "restart-failed",
+			fails:     100,
+			wantCalls: 12,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls <= tt.fails {
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}))
+			defer server.Close()
+
+			up := &upContext{
+				Options: &UpOptions{},
+				Sy: &syncthing.Syncthing{
+					GUIAddress: strings.TrimPrefix(server.URL, "http://"),
+					Client:     &http.Client{},
+				},
+			}
+			if err := up.restartSyncthing(context.Background()); err != nil {
+				t.Fatalf("a failed restart shouldn't abort the session: %s", err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func Test_restartSync(t *testing.T) {
+	tests := []struct {
+		name      string
+		failPath  string
+		wantPaths []string
+	}{
+		{
+			name:      "restarted",
+			wantPaths: []string{"/rest/system/restart", "/rest/system/ping", "/rest/db/override"},
+		},
+		{
+			name:      "restart-failed",
+			failPath:  "/rest/system/restart",
+			wantPaths: []string{"/rest/system/restart", "/rest/system/restart", "/rest/system/restart", "/rest/system/restart"},
+		},
+		{
+			name:      "override-failed",
+			failPath:  "/rest/db/override",
+			wantPaths: []string{"/rest/system/restart", "/rest/system/ping", "/rest/db/override", "/rest/db/override", "/rest/db/override", "/rest/db/override"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths := []string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.URL.Path)
+				if r.URL.Path == tt.failPath {
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}))
+			defer server.Close()
+
+			up := &upContext{Options: &UpOptions{}}
+			sy := &syncthing.Syncthing{
+				GUIAddress: strings.TrimPrefix(server.URL, "http://"),
+				Client:     &http.Client{},
+				Folders:    []*syncthing.Folder{{Name: "1"}},
+			}
+			up.restartSync(context.Background(), sy)
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("got calls %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+}
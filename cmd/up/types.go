@@ -37,6 +37,7 @@ type upContext struct {
 	Client            *kubernetes.Clientset
 	RestConfig        *rest.Config
 	Pod               *apiv1.Pod
+	devPodGeneration  int64
 	Forwarder         forwarder
 	Disconnect        chan error
 	CommandResult     chan error
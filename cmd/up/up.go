@@ -32,6 +32,7 @@ import (
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/k8s/apps"
 	"github.com/okteto/okteto/pkg/k8s/diverts"
+	"github.com/okteto/okteto/pkg/k8s/namespaces"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -39,6 +40,7 @@ import (
 	"github.com/okteto/okteto/pkg/ssh"
 	"github.com/okteto/okteto/pkg/syncthing"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/spf13/cobra"
 )
@@ -47,14 +49,26 @@ import (
 const ReconnectingMessage = "Trying to reconnect to your cluster. File synchronization will automatically resume when the connection improves."
 
 type UpOptions struct {
-	DevPath    string
-	Namespace  string
-	K8sContext string
-	Remote     int
-	AutoDeploy bool
-	Build      bool
-	ForcePull  bool
-	Reset      bool
+	DevPath       string
+	Namespace     string
+	K8sContext    string
+	Remote        int
+	AutoDeploy    bool
+	Build         bool
+	ForcePull     bool
+	Reset         bool
+	ExecRetries   int
+	Profile       string
+	DryRun        bool
+	HostNetwork   bool
+	WatchEnvFiles bool
+	Shell         bool
+	WatchEvents   bool
+	BindAddress   string
+	SyncOnce      bool
+	Envs          []string
+	Command       string
+	Node          string
 }
 
 // Up starts a development container
@@ -63,7 +77,23 @@ func Up() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "up",
 		Short: "Activates your development container",
-		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#up"),
+		Long: fmt.Sprintf(`Activates your development container
+
+The exit code tells why the development session ended:
+    0: the session ended normally
+    1: okteto up failed before your development container was ready
+    2: your development command failed
+    3: the connection to your development container was lost and couldn't be recovered
+
+Reverse forwards with 'waitForLocal: true' hold your development command until their local port accepts connections.
+The wait is bounded by 'timeout.default' of your okteto manifest (60s unless OKTETO_TIMEOUT is set).
+When it expires, okteto up shows a warning and runs your command anyway.
+
+These patterns are never synchronized into your development container: %s
+Mention a pattern in your local '.stignore' file, commented out or negated like '!.gradle', to synchronize it.
+Set 'sync.defaultIgnores: false' in your okteto manifest to synchronize all of them.
+`, strings.Join(model.DefaultSyncIgnores, " ")),
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#up"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if okteto.InDevContainer() {
 				return errors.ErrNotInDevContainer
@@ -124,6 +154,24 @@ func Up() *cobra.Command {
 				return err
 			}
 
+			if upOptions.DryRun && dev.Divert == nil {
+				return errors.UserError{
+					E:    fmt.Errorf("'--dry-run' is only supported when your okteto manifest has a 'divert' section"),
+					Hint: "Remove the '--dry-run' flag and try again",
+				}
+			}
+
+			if err := validateSyncOnce(upOptions); err != nil {
+				return err
+			}
+
+			if upOptions.Shell && upOptions.Command != "" {
+				return errors.UserError{
+					E:    fmt.Errorf("'--shell' can't be used with '--command'"),
+					Hint: "Use '--command' to run your own shell, for example '--command zsh'",
+				}
+			}
+
 			if err := okteto.SetCurrentContext(dev.Context, dev.Namespace); err != nil {
 				return err
 			}
@@ -138,6 +186,10 @@ func Up() *cobra.Command {
 				return err
 			}
 
+			if upOptions.Reset {
+				log.Warning("The file synchronization database will be reset. All your files will be synchronized again")
+			}
+
 			if _, ok := os.LookupEnv("OKTETO_AUTODEPLOY"); ok {
 				upOptions.AutoDeploy = true
 			}
@@ -162,10 +214,18 @@ func Up() *cobra.Command {
 
 			err = up.start()
 
+			if upOptions.DryRun {
+				return err
+			}
+
 			if err := up.Client.CoreV1().PersistentVolumeClaims(dev.Namespace).Delete(ctx, fmt.Sprintf(model.DeprecatedOktetoVolumeNameTemplate, dev.Name), metav1.DeleteOptions{}); err != nil {
 				log.Infof("error deleting deprecated volume: %v", err)
 			}
 
+			if err == nil && up.success {
+				log.Information("Your development container is still running. Run 'okteto down' to deactivate it")
+			}
+
 			return err
 		},
 	}
@@ -178,11 +238,29 @@ func Up() *cobra.Command {
 	cmd.Flags().MarkHidden("deploy")
 	cmd.Flags().BoolVarP(&upOptions.Build, "build", "", false, "build on-the-fly the dev image using the info provided by the 'build' okteto manifest field")
 	cmd.Flags().BoolVarP(&upOptions.ForcePull, "pull", "", false, "force dev image pull")
+	cmd.Flags().BoolVarP(&upOptions.ForcePull, "pull-always", "", false, "set 'imagePullPolicy: Always' on the development container so the dev image is pulled on every activation (alias of --pull)")
 	cmd.Flags().BoolVarP(&upOptions.Reset, "reset", "", false, "reset the file synchronization database")
+	cmd.Flags().IntVarP(&upOptions.ExecRetries, "exec-retries", "", 0, "number of times the development command is relaunched if it fails")
+	cmd.Flags().BoolVarP(&upOptions.DryRun, "dry-run", "", false, "print the divert resources that would be created or updated and exit without activating your development container")
+	cmd.Flags().BoolVarP(&upOptions.HostNetwork, "host-network", "", false, "run the development container in the node network namespace, exposing every port of the node to it (the namespace must allow hostNetwork pods)")
+	cmd.Flags().BoolVarP(&upOptions.WatchEnvFiles, "watch-env-file", "", false, "redeploy the development container when the files of the 'envFile' field of your okteto manifest change, restarting your development command")
+	cmd.Flags().BoolVarP(&upOptions.Shell, "shell", "", false, "start a shell in the development container instead of the command of your okteto manifest, using the first available of bash, sh and ash")
+	cmd.Flags().BoolVarP(&upOptions.WatchEvents, "watch-events", "", false, "print the files synchronized on each sync cycle and their direction")
+	cmd.Flags().StringVarP(&upOptions.BindAddress, "bind-address", "", "", "local address where the ports of the 'forward' field of your okteto manifest listen, unless they define 'bindAddress' (defaults to the 'interface' field, localhost)")
+	cmd.Flags().BoolVarP(&upOptions.SyncOnce, "sync-once", "", false, "exit after the first file synchronization, without running the command of your okteto manifest")
+	cmd.Flags().StringVarP(&upOptions.Profile, "profile", "", "", "resource profile defined in 'resources.profiles' of your okteto manifest applied to the development container")
+	cmd.Flags().StringVarP(&upOptions.Command, "command", "", "", "run this command in the development container instead of the command of your okteto manifest, only for this session (can't be combined with --shell or --sync-once)")
+	cmd.Flags().StringVarP(&upOptions.Node, "node", "", "", "node where the development container runs when your okteto manifest refers to a daemonset (defaults to the first node running one of its pods)")
+	cmd.Flags().StringArrayVarP(&upOptions.Envs, "env", "e", []string{}, "set an environment variable of the development container with the form KEY=VALUE, taking precedence over your okteto manifest (can be set more than once)")
 	return cmd
 }
 
 func loadDevOrInit(upOptions *UpOptions) (*model.Dev, error) {
+	devPath, err := utils.FindDevManifest(upOptions.DevPath)
+	if err != nil {
+		return nil, err
+	}
+	upOptions.DevPath = devPath
 	dev, err := utils.LoadDev(upOptions.DevPath, upOptions.Namespace, upOptions.K8sContext)
 
 	if err == nil {
@@ -228,30 +306,151 @@ func loadDevOverrides(dev *model.Dev, upOptions *UpOptions) error {
 		dev.LoadForcePull()
 	}
 
+	if upOptions.Profile != "" {
+		if err := dev.ApplyResourceProfile(upOptions.Profile); err != nil {
+			return err
+		}
+	}
+
+	if upOptions.HostNetwork {
+		dev.HostNetwork = true
+	}
+
+	if upOptions.Command != "" {
+		dev.Command = model.NewCommand(upOptions.Command)
+		dev.EmptyCommand = false
+	}
+
+	if upOptions.Node != "" {
+		dev.Node = upOptions.Node
+		for _, s := range dev.Services {
+			s.Node = upOptions.Node
+		}
+	}
+
+	if len(upOptions.Envs) > 0 {
+		overrides, err := parseEnvFlags(upOptions.Envs)
+		if err != nil {
+			return err
+		}
+		dev.OverrideEnvironment(overrides)
+	}
+
+	for _, warning := range dev.SecurityWarnings() {
+		log.Warning(warning)
+	}
+
+	if dev.HostNetwork {
+		log.Warning("'hostNetwork' exposes the network of the node to your development container. Ports used by your development container must be free in the node")
+		if okteto.IsOktetoContext() {
+			log.Warning("'hostNetwork' might be disabled by the security policies of your Okteto cluster")
+		}
+	}
+
+	if upOptions.BindAddress != "" {
+		if err := model.ValidateBindAddress(upOptions.BindAddress); err != nil {
+			return errors.UserError{
+				E:    err,
+				Hint: "Use '--bind-address 0.0.0.0' to listen on all the interfaces of your machine",
+			}
+		}
+		for i := range dev.Forward {
+			if dev.Forward[i].BindAddress == "" && !dev.Forward[i].IsSocket() {
+				dev.Forward[i].BindAddress = upOptions.BindAddress
+			}
+		}
+	}
+	warnPublicForwards(dev)
+
 	dev.Username = okteto.Context().Username
 	dev.RegistryURL = okteto.Context().Registry
 
 	return nil
 }
 
+// validateSyncOnce returns an error if --sync-once is combined with flags of the interactive session
+func validateSyncOnce(upOptions *UpOptions) error {
+	if !upOptions.SyncOnce {
+		return nil
+	}
+	flags := []struct {
+		name string
+		set  bool
+	}{
+		{name: "--shell", set: upOptions.Shell},
+		{name: "--watch-events", set: upOptions.WatchEvents},
+		{name: "--watch-env-file", set: upOptions.WatchEnvFiles},
+		{name: "--remote", set: upOptions.Remote != 0},
+		{name: "--command", set: upOptions.Command != ""},
+	}
+	for _, flag := range flags {
+		if flag.set {
+			return errors.UserError{
+				E:    fmt.Errorf("'--sync-once' can't be used with '%s'", flag.name),
+				Hint: fmt.Sprintf("Remove the '%s' flag and try again", flag.name),
+			}
+		}
+	}
+	return nil
+}
+
+// parseEnvFlags returns the environment variables of the --env flags. A variable set more than once keeps the last value
+func parseEnvFlags(envs []string) (model.Environment, error) {
+	result := model.Environment{}
+	for _, env := range envs {
+		parts := strings.SplitN(env, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.UserError{
+				E:    fmt.Errorf("invalid value '%s' for '--env'", env),
+				Hint: "Use the form '--env KEY=VALUE'",
+			}
+		}
+		if errs := validation.IsEnvVarName(parts[0]); len(errs) > 0 {
+			return nil, errors.UserError{
+				E:    fmt.Errorf("invalid variable name '%s' for '--env': %s", parts[0], strings.Join(errs, ", ")),
+				Hint: "Use the form '--env KEY=VALUE'",
+			}
+		}
+		result = append(result, model.EnvVar{Name: parts[0], Value: parts[1]})
+	}
+	return result, nil
+}
+
+// warnPublicForwards warns about the forwards reachable from other machines of your network
+func warnPublicForwards(dev *model.Dev) {
+	for _, f := range dev.Forward {
+		if f.IsSocket() {
+			continue
+		}
+		address := f.BindAddress
+		if address == "" {
+			address = dev.Interface
+		}
+		if !model.IsLoopbackAddress(address) {
+			log.Warning("Port %d is forwarded on '%s' and is reachable by other machines of your network", f.Local, address)
+		}
+	}
+}
+
 func (up *upContext) start() error {
 	var err error
 	up.Client, up.RestConfig, err = okteto.GetK8sClient()
 	if err != nil {
-		kubecfg := config.GetOktetoContextKubeconfigPath()
-		log.Infof("failed to load okteto Kubeconfig: %s", err)
-		if up.Dev.Context == "" {
-			return fmt.Errorf("failed to load your okteto Kubeconfig %q", kubecfg)
-		}
-		return fmt.Errorf("failed to load your okteto Kubeconfig: %q context not found in %q", up.Dev.Context, kubecfg)
+		return err
 	}
 
 	ctx := context.Background()
 
 	if up.Dev.Divert != nil {
-		if err := diverts.Create(ctx, up.Dev, up.Client); err != nil {
+		if err := diverts.Validate(ctx, up.Dev, up.Client); err != nil {
 			return err
 		}
+		if err := diverts.Create(ctx, up.Dev, up.Options.DryRun, up.Client); err != nil {
+			return err
+		}
+		if up.Options.DryRun {
+			return nil
+		}
 	}
 
 	if err := createPIDFile(up.Dev.Namespace, up.Dev.Name); err != nil {
@@ -309,6 +508,13 @@ func (up *upContext) activateLoop() {
 		if err != nil {
 			log.Infof("activate failed with: %s", err)
 
+			if err == errors.ErrLostSyncthing || errors.IsTransient(err) {
+				if up.isNamespaceDeleted() {
+					up.Exit <- up.exitError(errors.ErrNamespaceDeleted)
+					return
+				}
+			}
+
 			if err == errors.ErrLostSyncthing {
 				isTransientError = false
 				iter = 0
@@ -320,7 +526,7 @@ func (up *upContext) activateLoop() {
 				continue
 			}
 
-			up.Exit <- err
+			up.Exit <- up.exitError(err)
 			return
 		}
 		up.Exit <- nil
@@ -328,6 +534,29 @@ func (up *upContext) activateLoop() {
 	}
 }
 
+// exitError sets the exit code of the error that ended 'okteto up'.
+// Errors after the development container was activated mean that the connection was lost
+func (up *upContext) exitError(err error) error {
+	if _, ok := err.(errors.CommandError); ok {
+		return errors.ExitError{E: err, Code: errors.ExitCodeCommandFailed}
+	}
+	if err == errors.ErrNamespaceDeleted || up.success {
+		return errors.ExitError{E: err, Code: errors.ExitCodeConnectionLost}
+	}
+	return err
+}
+
+// isNamespaceDeleted returns true if the namespace of the development container doesn't exist anymore
+func (up *upContext) isNamespaceDeleted() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if !namespaces.IsDeleted(ctx, up.Dev.Namespace, up.Client) {
+		return false
+	}
+	log.Infof("namespace '%s' no longer exists, stopping reconnection", up.Dev.Namespace)
+	return true
+}
+
 // waitUntilExitOrInterruptOrApply blocks execution until a stop signal is sent, a disconnect event or an error or the app is modify
 func (up *upContext) waitUntilExitOrInterruptOrApply(ctx context.Context) error {
 	for {
@@ -357,6 +586,10 @@ func (up *upContext) waitUntilExitOrInterruptOrApply(ctx context.Context) error
 		case err := <-up.applyToApps(ctx):
 			log.Infof("exiting by applyToAppsChan: %v", err)
 			return err
+
+		case err := <-up.watchEnvFiles(ctx):
+			log.Infof("exiting by watchEnvFiles: %v", err)
+			return err
 		}
 	}
 }
@@ -395,7 +628,11 @@ func (up *upContext) buildDevImage(ctx context.Context, app apps.App) error {
 	imageTag := registry.GetImageTag(up.Dev.Image.Name, up.Dev.Name, up.Dev.Namespace, oktetoRegistryURL)
 	log.Infof("building dev image tag %s", imageTag)
 
-	buildArgs := model.SerializeBuildArgs(up.Dev.Image.Args)
+	args, err := buildCMD.ExpandBuildArgs(ctx, up.Dev.Image.Args)
+	if err != nil {
+		return err
+	}
+	buildArgs := model.SerializeBuildArgs(args)
 
 	buildOptions := buildCMD.BuildOptions{
 		Path:       up.Dev.Image.Context,
@@ -406,7 +643,7 @@ func (up *upContext) buildDevImage(ctx context.Context, app apps.App) error {
 		BuildArgs:  buildArgs,
 		OutputMode: "tty",
 	}
-	if err := buildCMD.Run(ctx, up.Dev.Namespace, buildOptions); err != nil {
+	if _, err := buildCMD.Run(ctx, up.Dev.Namespace, buildOptions); err != nil {
 		return err
 	}
 	for _, s := range up.Dev.Services {
@@ -436,12 +673,15 @@ func (up *upContext) setDevContainer(app apps.App) error {
 }
 
 func (up *upContext) getInteractive() bool {
-	if len(up.Dev.Command.Values) == 0 {
+	if len(up.Dev.Command.Values) == 0 || up.Dev.EmptyCommand {
+		return true
+	}
+	if up.Options != nil && up.Options.Shell {
 		return true
 	}
 	if len(up.Dev.Command.Values) == 1 {
 		switch up.Dev.Command.Values[0] {
-		case "sh", "bash":
+		case "sh", "bash", "ash":
 			return true
 		default:
 			return false
@@ -507,24 +747,24 @@ func (up *upContext) shutdown() {
 
 }
 
-func printDisplayContext(dev *model.Dev, divertURL string) {
+func printDisplayContext(dev *model.Dev, divertURLs []string) {
 	log.Println(fmt.Sprintf("    %s   %s", log.BlueString("Context:"), dev.Context))
 	log.Println(fmt.Sprintf("    %s %s", log.BlueString("Namespace:"), dev.Namespace))
 	log.Println(fmt.Sprintf("    %s      %s", log.BlueString("Name:"), dev.Name))
 
 	if len(dev.Forward) > 0 {
 		if dev.Forward[0].Service {
-			log.Println(fmt.Sprintf("    %s   %d -> %s:%d", log.BlueString("Forward:"), dev.Forward[0].Local, dev.Forward[0].ServiceName, dev.Forward[0].Remote))
+			log.Println(fmt.Sprintf("    %s   %s -> %s:%d", log.BlueString("Forward:"), dev.Forward[0].LocalString(), dev.Forward[0].ServiceName, dev.Forward[0].Remote))
 		} else {
-			log.Println(fmt.Sprintf("    %s   %d -> %d", log.BlueString("Forward:"), dev.Forward[0].Local, dev.Forward[0].Remote))
+			log.Println(fmt.Sprintf("    %s   %s -> %d", log.BlueString("Forward:"), dev.Forward[0].LocalString(), dev.Forward[0].Remote))
 		}
 
 		for i := 1; i < len(dev.Forward); i++ {
 			if dev.Forward[i].Service {
-				log.Println(fmt.Sprintf("               %d -> %s:%d", dev.Forward[i].Local, dev.Forward[i].ServiceName, dev.Forward[i].Remote))
+				log.Println(fmt.Sprintf("               %s -> %s:%d", dev.Forward[i].LocalString(), dev.Forward[i].ServiceName, dev.Forward[i].Remote))
 				continue
 			}
-			log.Println(fmt.Sprintf("               %d -> %d", dev.Forward[i].Local, dev.Forward[i].Remote))
+			log.Println(fmt.Sprintf("               %s -> %d", dev.Forward[i].LocalString(), dev.Forward[i].Remote))
 		}
 	}
 
@@ -535,8 +775,11 @@ func printDisplayContext(dev *model.Dev, divertURL string) {
 		}
 	}
 
-	if divertURL != "" {
-		log.Println(fmt.Sprintf("    %s       %s", log.BlueString("URL:"), divertURL))
+	if len(divertURLs) > 0 {
+		log.Println(fmt.Sprintf("    %s       %s", log.BlueString("URL:"), divertURLs[0]))
+		for i := 1; i < len(divertURLs); i++ {
+			log.Println(fmt.Sprintf("               %s", divertURLs[i]))
+		}
 	}
 	fmt.Println()
 }
@@ -16,6 +16,7 @@ package up
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/okteto/okteto/pkg/errors"
@@ -97,8 +98,147 @@ func Test_printDisplayContext(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			printDisplayContext(tt.dev, "")
+			printDisplayContext(tt.dev, nil)
 		})
 	}
 
 }
+
+func Test_getCommandWithoutShell(t *testing.T) {
+	up := &upContext{
+		Dev:     &model.Dev{Command: model.Command{Values: []string{"yarn", "start"}}},
+		Options: &UpOptions{},
+	}
+	got, err := up.getCommand(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, []string{"yarn", "start"}) {
+		t.Errorf("got command %v, want [yarn start]", got)
+	}
+	if up.getInteractive() {
+		t.Error("'yarn start' shouldn't be interactive")
+	}
+
+	up.Options.Shell = true
+	if !up.getInteractive() {
+		t.Error("'--shell' should be interactive")
+	}
+}
+
+func Test_validateSyncOnce(t *testing.T) {
+	var tests = []struct {
+		name    string
+		options *UpOptions
+		wantErr bool
+	}{
+		{name: "disabled", options: &UpOptions{Shell: true}, wantErr: false},
+		{name: "sync-once", options: &UpOptions{SyncOnce: true, Reset: true}, wantErr: false},
+		{name: "shell", options: &UpOptions{SyncOnce: true, Shell: true}, wantErr: true},
+		{name: "watch-events", options: &UpOptions{SyncOnce: true, WatchEvents: true}, wantErr: true},
+		{name: "watch-env-file", options: &UpOptions{SyncOnce: true, WatchEnvFiles: true}, wantErr: true},
+		{name: "remote", options: &UpOptions{SyncOnce: true, Remote: 22000}, wantErr: true},
+		{name: "command", options: &UpOptions{SyncOnce: true, Command: "dlv debug"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSyncOnce(tt.options)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func Test_parseEnvFlags(t *testing.T) {
+	var tests = []struct {
+		name     string
+		envs     []string
+		expected model.Environment
+		wantErr  bool
+	}{
+		{
+			name:     "single",
+			envs:     []string{"FLAG=on"},
+			expected: model.Environment{{Name: "FLAG", Value: "on"}},
+		},
+		{
+			name:     "value-with-equals",
+			envs:     []string{"URL=http://db?user=okteto", "EMPTY="},
+			expected: model.Environment{{Name: "URL", Value: "http://db?user=okteto"}, {Name: "EMPTY", Value: ""}},
+		},
+		{name: "missing-value", envs: []string{"FLAG"}, wantErr: true},
+		{name: "missing-name", envs: []string{"=on"}, wantErr: true},
+		{name: "invalid-name", envs: []string{"1FLAG=on"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseEnvFlags(tt.envs)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func Test_exitError(t *testing.T) {
+	var tests = []struct {
+		name     string
+		err      error
+		success  bool
+		expected int
+	}{
+		{
+			name:     "setup-error",
+			err:      fmt.Errorf("the application doesn't exist"),
+			expected: errors.ExitCodeError,
+		},
+		{
+			name:     "user-error",
+			err:      errors.UserError{E: fmt.Errorf("invalid manifest"), Hint: "fix it"},
+			expected: errors.ExitCodeError,
+		},
+		{
+			name:     "command-failed",
+			err:      errors.CommandError{E: errors.ErrCommandFailed, Reason: fmt.Errorf("exit status 1")},
+			success:  true,
+			expected: errors.ExitCodeCommandFailed,
+		},
+		{
+			name:     "namespace-deleted",
+			err:      errors.ErrNamespaceDeleted,
+			expected: errors.ExitCodeConnectionLost,
+		},
+		{
+			name:     "error-after-activation",
+			err:      errors.ErrInsufficientSpace,
+			success:  true,
+			expected: errors.ExitCodeConnectionLost,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up := &upContext{success: tt.success}
+			err := up.exitError(tt.err)
+			if got := errors.ExitCode(err); got != tt.expected {
+				t.Errorf("expected exit code %d, got %d", tt.expected, got)
+			}
+			if err.Error() != tt.err.Error() {
+				t.Errorf("expected message '%s', got '%s'", tt.err.Error(), err.Error())
+			}
+		})
+	}
+}
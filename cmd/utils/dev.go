@@ -31,6 +31,7 @@ import (
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -40,15 +41,83 @@ const (
 	secondaryDevManifest = "okteto.yaml"
 )
 
-//LoadDev loads an okteto manifest checking "yml" and "yaml"
-func LoadDev(devPath, namespace, oktetoContext string) (*model.Dev, error) {
-	if !model.FileExists(devPath) {
-		if devPath == DefaultDevManifest {
-			if model.FileExists(secondaryDevManifest) {
-				return LoadDev(secondaryDevManifest, namespace, oktetoContext)
+// FindDevManifest returns the path of the okteto manifest to load.
+// If devPath is the default manifest, "okteto.yml" and "okteto.yaml" are searched in the current folder
+// and then in each of its parent folders, and the nearest one is returned.
+// If both exist in the same folder, the user selects one, or an error is returned when not running in a terminal.
+// The path of the manifest found is absolute, so resolving it again doesn't search or ask again.
+// devPath is returned if no manifest is found
+func FindDevManifest(devPath string) (string, error) {
+	if devPath != DefaultDevManifest {
+		return devPath, nil
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Infof("failed to get the current folder: %s", err)
+		return devPath, nil
+	}
+
+	dir := wd
+	for {
+		candidates := []string{}
+		for _, name := range []string{DefaultDevManifest, secondaryDevManifest} {
+			path := filepath.Join(dir, name)
+			if model.FileExists(path) {
+				candidates = append(candidates, path)
 			}
 		}
 
+		switch len(candidates) {
+		case 0:
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				return devPath, nil
+			}
+			dir = parent
+			continue
+		case 1:
+			if dir != wd {
+				log.Information("Using the okteto manifest %s", relativeToFolder(wd, candidates[0]))
+			}
+			return candidates[0], nil
+		}
+
+		options := []string{}
+		for _, path := range candidates {
+			options = append(options, relativeToFolder(wd, path))
+		}
+		if !isTerminal() {
+			return "", errors.UserError{
+				E:    fmt.Errorf("found multiple okteto manifests: %s", strings.Join(options, ", ")),
+				Hint: "Use the '--file' flag to select one of them",
+			}
+		}
+		log.Warning("Found multiple okteto manifests: %s", strings.Join(options, ", "))
+		selected, err := AskForOptions(options, "Select the okteto manifest you want to use:")
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(wd, selected), nil
+	}
+}
+
+// relativeToFolder returns path relative to folder, or path if it can't be made relative
+func relativeToFolder(folder, path string) string {
+	rel, err := filepath.Rel(folder, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
+
+//LoadDev loads an okteto manifest checking "yml" and "yaml"
+func LoadDev(devPath, namespace, oktetoContext string) (*model.Dev, error) {
+	devPath, err := FindDevManifest(devPath)
+	if err != nil {
+		return nil, err
+	}
+	if !model.FileExists(devPath) {
 		return nil, fmt.Errorf("'%s' does not exist. Generate it by executing 'okteto init'", devPath)
 	}
 
@@ -287,12 +356,34 @@ func LoadEnvironment(ctx context.Context, getSecrets bool) error {
 
 func GetDownCommand(devPath string) string {
 	okDownCommandHint := "okteto down -v"
+	if wd, err := os.Getwd(); err == nil && filepath.IsAbs(devPath) {
+		devPath = relativeToFolder(wd, devPath)
+	}
 	if DefaultDevManifest != devPath {
 		okDownCommandHint = fmt.Sprintf("okteto down -v -f %s", devPath)
 	}
 	return okDownCommandHint
 }
 
+// GetDevPod returns the running pod of the development container of an app in dev mode
+func GetDevPod(ctx context.Context, dev *model.Dev, c kubernetes.Interface) (*apiv1.Pod, error) {
+	app, err := apps.Get(ctx, dev, dev.Namespace, c)
+	if err != nil {
+		return nil, err
+	}
+	if !apps.IsDevModeOn(app) {
+		return nil, errors.UserError{
+			E:    fmt.Errorf("development mode is not enabled"),
+			Hint: "Run 'okteto up' to enable it and try again",
+		}
+	}
+	devApp := app.DevClone()
+	if err := devApp.Refresh(ctx, c); err != nil {
+		return nil, err
+	}
+	return devApp.GetRunningPod(ctx, c)
+}
+
 func GetApp(ctx context.Context, dev *model.Dev, c kubernetes.Interface) (apps.App, bool, error) {
 	app, err := apps.Get(ctx, dev, dev.Namespace, c)
 	if err != nil {
@@ -318,8 +409,9 @@ func GetApp(ctx context.Context, dev *model.Dev, c kubernetes.Interface) (apps.A
 		}
 	}
 	if dev.Divert != nil {
-		dev.Name = model.DivertName(dev.Name, okteto.GetSanitizedUsername())
-		return app.Divert(okteto.GetSanitizedUsername()), false, nil
+		divertKey := okteto.GetDivertKey(dev)
+		dev.Name = model.DivertName(dev.Name, divertKey)
+		return app.Divert(divertKey), false, nil
 	}
 	return app, false, nil
 }
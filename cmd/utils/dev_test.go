@@ -16,6 +16,7 @@ package utils
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/okteto/okteto/pkg/errors"
@@ -246,3 +247,143 @@ func Test_CheckIfRegularFile(t *testing.T) {
 		})
 	}
 }
+
+func Test_FindDevManifest(t *testing.T) {
+	root := t.TempDir()
+	subdir := filepath.Join(root, "services", "api")
+	if err := os.MkdirAll(subdir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, secondaryDevManifest), []byte("name: root"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir(subdir); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := isTerminal
+	isTerminal = func() bool { return false }
+	defer func() { isTerminal = previous }()
+
+	var tests = []struct {
+		name      string
+		devPath   string
+		create    string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "parent-of-parent",
+			devPath:  DefaultDevManifest,
+			expected: filepath.Join(root, secondaryDevManifest),
+		},
+		{
+			name:     "custom",
+			devPath:  "custom.yml",
+			expected: "custom.yml",
+		},
+		{
+			name:     "nearest",
+			devPath:  DefaultDevManifest,
+			create:   filepath.Join(root, "services", DefaultDevManifest),
+			expected: filepath.Join(root, "services", DefaultDevManifest),
+		},
+		{
+			name:     "current",
+			devPath:  DefaultDevManifest,
+			create:   filepath.Join(subdir, secondaryDevManifest),
+			expected: filepath.Join(subdir, secondaryDevManifest),
+		},
+		{
+			name:      "multiple",
+			devPath:   DefaultDevManifest,
+			create:    filepath.Join(subdir, DefaultDevManifest),
+			expectErr: true,
+		},
+		{
+			name:     "multiple-with-file",
+			devPath:  secondaryDevManifest,
+			expected: secondaryDevManifest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.create != "" {
+				if err := os.WriteFile(tt.create, []byte("name: api"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := FindDevManifest(tt.devPath)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't get expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.expected {
+				t.Errorf("got '%s', expected '%s'", got, tt.expected)
+			}
+
+			again, err := FindDevManifest(got)
+			if err != nil {
+				t.Fatalf("resolving '%s' again failed: %s", got, err)
+			}
+			if again != got {
+				t.Errorf("resolving '%s' again returned '%s'", got, again)
+			}
+		})
+	}
+}
+
+func Test_GetDownCommand(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name     string
+		devPath  string
+		expected string
+	}{
+		{
+			name:     "default",
+			devPath:  DefaultDevManifest,
+			expected: "okteto down -v",
+		},
+		{
+			name:     "default-absolute",
+			devPath:  filepath.Join(wd, DefaultDevManifest),
+			expected: "okteto down -v",
+		},
+		{
+			name:     "secondary-absolute",
+			devPath:  filepath.Join(wd, secondaryDevManifest),
+			expected: "okteto down -v -f " + secondaryDevManifest,
+		},
+		{
+			name:     "parent",
+			devPath:  filepath.Join(filepath.Dir(wd), DefaultDevManifest),
+			expected: "okteto down -v -f " + filepath.Join("..", DefaultDevManifest),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetDownCommand(tt.devPath); got != tt.expected {
+				t.Errorf("got '%s', expected '%s'", got, tt.expected)
+			}
+		})
+	}
+}
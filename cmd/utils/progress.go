@@ -16,6 +16,7 @@ package utils
 import (
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -36,6 +37,11 @@ func (cpb *ProgressBar) TrackProgress(src string, currentSize, totalSize int64,
 	cpb.lock.Lock()
 	defer cpb.lock.Unlock()
 
+	if !isTerminal() {
+		fmt.Fprintf(os.Stdout, "Downloading %s...\n", filepath.Base(src))
+		return stream
+	}
+
 	newPb := pb.New64(totalSize)
 	newPb.Set("prefix", fmt.Sprintf("%s ", filepath.Base(src)))
 	newPb.SetCurrent(currentSize)
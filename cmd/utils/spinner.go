@@ -28,6 +28,13 @@ import (
 
 var spinnerSupport bool
 
+var spinnerDisabled bool
+
+// isTerminal returns true if stdout is attached to a terminal
+var isTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
 //Spinner represents an okteto spinner
 type Spinner struct {
 	sp *sp.Spinner
@@ -35,7 +42,7 @@ type Spinner struct {
 
 //NewSpinner returns a new Spinner
 func NewSpinner(suffix string) *Spinner {
-	spinnerSupport = !loadBoolean("OKTETO_DISABLE_SPINNER")
+	spinnerSupport = !loadBoolean("OKTETO_DISABLE_SPINNER") && isTerminal()
 	s := sp.New(sp.CharSets[14], 100*time.Millisecond)
 	s.HideCursor = true
 	s.Suffix = fmt.Sprintf(" %s", suffix)
@@ -54,6 +61,11 @@ func NewSpinner(suffix string) *Spinner {
 	}
 }
 
+//DisableSpinner hides the spinners of the command, e.g. when its output must be machine readable
+func DisableSpinner() {
+	spinnerDisabled = true
+}
+
 func loadBoolean(k string) bool {
 	v := os.Getenv(k)
 	if v == "" {
@@ -70,6 +82,9 @@ func loadBoolean(k string) bool {
 
 //Start starts the spinner
 func (p *Spinner) Start() {
+	if spinnerDisabled {
+		return
+	}
 	if spinnerSupport {
 		if p.sp.FinalMSG == "" {
 			p.sp.FinalMSG = p.sp.Suffix
@@ -85,7 +100,7 @@ func (p *Spinner) Stop() {
 	if p.sp.FinalMSG != "" {
 		p.sp.FinalMSG = ""
 	}
-	if spinnerSupport {
+	if spinnerSupport && !spinnerDisabled {
 		p.sp.Stop()
 	}
 }
@@ -94,7 +109,7 @@ func (p *Spinner) Stop() {
 func (p *Spinner) Update(text string) {
 	p.sp.Suffix = fmt.Sprintf(" %s", ucFirst(text))
 	p.sp.FinalMSG = fmt.Sprintf(" %s", ucFirst(text))
-	if !spinnerSupport {
+	if !spinnerSupport && !spinnerDisabled {
 		fmt.Println(strings.TrimSpace(p.sp.Suffix))
 	}
 }
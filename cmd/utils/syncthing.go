@@ -16,6 +16,7 @@ package utils
 import (
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/vbauerster/mpb/v7"
 	decor "github.com/vbauerster/mpb/v7/decor"
@@ -26,15 +27,34 @@ type SyncthingProgress struct {
 	progressContainer *mpb.Progress
 	progressBar       *mpb.Bar
 	itemInSync        string
+
+	// plain prints the progress as text lines when stdout is not a terminal
+	plain        bool
+	out          io.Writer
+	lastReported int64
 }
 
+// plainProgressStep is the minimum increment of the progress printed in plain mode
+const plainProgressStep = 10
+
 // NewSyncthingProgressBar creates a new syncthing progress
 func NewSyncthingProgressBar(width int) *SyncthingProgress {
+	if !isTerminal() {
+		return newPlainSyncthingProgress(os.Stdout)
+	}
 	return &SyncthingProgress{
 		progressContainer: mpb.New(mpb.WithWidth(width)),
 	}
 }
 
+func newPlainSyncthingProgress(out io.Writer) *SyncthingProgress {
+	return &SyncthingProgress{
+		plain:        true,
+		out:          out,
+		lastReported: -1,
+	}
+}
+
 func (s *SyncthingProgress) initProgressBar() {
 	s.progressBar = s.progressContainer.Add(
 		100,
@@ -52,6 +72,9 @@ func (s *SyncthingProgress) initProgressBar() {
 // UpdateItemInSync updates the item in sync
 func (s *SyncthingProgress) UpdateItemInSync(lastItem string) {
 	s.itemInSync = lastItem
+	if s.plain {
+		return
+	}
 	if s.progressBar == nil {
 		s.initProgressBar()
 	}
@@ -59,6 +82,10 @@ func (s *SyncthingProgress) UpdateItemInSync(lastItem string) {
 
 // SetCurrent sets current progress of the syncthing progress bar
 func (s *SyncthingProgress) SetCurrent(v int64) {
+	if s.plain {
+		s.printPlainProgress(v)
+		return
+	}
 	if s.progressBar == nil {
 		s.initProgressBar()
 	}
@@ -67,12 +94,33 @@ func (s *SyncthingProgress) SetCurrent(v int64) {
 
 // Finish finishes the progress bar
 func (s *SyncthingProgress) Finish() {
+	if s.plain {
+		if s.lastReported >= 0 {
+			s.printPlainProgress(100)
+		}
+		return
+	}
 	if s.progressBar != nil {
 		s.progressBar.SetCurrent(100)
 	}
 	s.progressContainer.Wait()
 }
 
+func (s *SyncthingProgress) printPlainProgress(v int64) {
+	if v > 100 {
+		v = 100
+	}
+	if s.lastReported >= 0 && v < s.lastReported+plainProgressStep && (v < 100 || s.lastReported == 100) {
+		return
+	}
+	s.lastReported = v
+	if v == 100 {
+		fmt.Fprintln(s.out, "Files synchronized")
+		return
+	}
+	fmt.Fprintf(s.out, "Synchronizing your files... %d%%\n", v)
+}
+
 func NewLineBarFiller(filler mpb.BarFiller) mpb.BarFiller {
 	return mpb.BarFillerFunc(func(w io.Writer, reqWidth int, st decor.Statistics) {
 		w.Write([]byte("   "))
@@ -0,0 +1,58 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_plainSyncthingProgress(t *testing.T) {
+	var tests = []struct {
+		name     string
+		progress []int64
+		expected string
+	}{
+		{
+			name:     "no-progress",
+			progress: []int64{},
+			expected: "",
+		},
+		{
+			name:     "steps",
+			progress: []int64{0, 3, 9, 10, 15, 42, 100},
+			expected: "Synchronizing your files... 0%\nSynchronizing your files... 10%\nSynchronizing your files... 42%\nFiles synchronized\n",
+		},
+		{
+			name:     "finish-before-complete",
+			progress: []int64{55},
+			expected: "Synchronizing your files... 55%\nFiles synchronized\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			p := newPlainSyncthingProgress(&out)
+			for _, v := range tt.progress {
+				p.UpdateItemInSync("file.txt")
+				p.SetCurrent(v)
+			}
+			p.Finish()
+			if out.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, out.String())
+			}
+		})
+	}
+}
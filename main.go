@@ -15,6 +15,7 @@ package main
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"os"
 	"strings"
@@ -23,6 +24,7 @@ import (
 	"github.com/okteto/okteto/cmd"
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	initCMD "github.com/okteto/okteto/cmd/init"
+	"github.com/okteto/okteto/cmd/manifest"
 	"github.com/okteto/okteto/cmd/namespace"
 	"github.com/okteto/okteto/cmd/pipeline"
 	"github.com/okteto/okteto/cmd/preview"
@@ -31,8 +33,10 @@ import (
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/client"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -45,6 +49,7 @@ import (
 
 func init() {
 	log.SetLevel("warn")
+	log.AddMaskedWord(os.Getenv("OKTETO_TOKEN"))
 
 	// override client-go error handlers to downgrade the "logging before flag.Parse" error
 	errorHandlers := []func(error){
@@ -67,6 +72,15 @@ func main() {
 	ctx := context.Background()
 	log.Init(logrus.WarnLevel)
 	var logLevel string
+	var proxy string
+	var caBundle string
+	var kubeconfigPath string
+	var asUser string
+	var asGroups []string
+	var logFile string
+	var colorMode string
+	var noColor bool
+	var strictEnv bool
 
 	if err := analytics.Init(); err != nil {
 		log.Infof("error initializing okteto analytics: %s", err)
@@ -76,18 +90,71 @@ func main() {
 		Use:           fmt.Sprintf("%s COMMAND [ARG...]", config.GetBinaryName()),
 		Short:         "Manage development containers",
 		SilenceErrors: true,
-		PersistentPreRun: func(ccmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(ccmd *cobra.Command, args []string) error {
 			ccmd.SilenceUsage = true
+			if err := validateLogLevel(logLevel); err != nil {
+				return err
+			}
 			log.SetLevel(logLevel)
+			if ccmd.Flags().Changed("loglevel") || ccmd.Flags().Changed("log-level") {
+				log.SetOutputLevel(logLevel)
+			}
+			if noColor {
+				colorMode = log.ColorNever
+			}
+			if err := log.ConfigureColor(colorMode); err != nil {
+				return errors.UserError{
+					E:    err,
+					Hint: "Use '--color=always' to force colors or '--no-color' to disable them",
+				}
+			}
+			if logFile != "" {
+				if err := log.ConfigureLogFile(logFile, config.VersionString); err != nil {
+					return err
+				}
+			}
 			log.Infof("started %s", strings.Join(os.Args, " "))
-
+			model.SetStrictEnv(strictEnv)
+			if proxy != "" {
+				if err := okteto.SetProxy(proxy); err != nil {
+					return err
+				}
+			}
+			if caBundle != "" {
+				if err := okteto.SetCABundle(caBundle); err != nil {
+					return err
+				}
+			}
+			if kubeconfigPath != "" {
+				if _, err := os.Stat(kubeconfigPath); err != nil {
+					return errors.UserError{
+						E:    fmt.Errorf("error accessing the kubeconfig file '%s': %w", kubeconfigPath, err),
+						Hint: "Check the path given to '--kubeconfig'",
+					}
+				}
+				config.SetKubeconfigPath(kubeconfigPath)
+			}
+			if err := client.SetImpersonation(asUser, asGroups); err != nil {
+				return err
+			}
+			return nil
 		},
 		PersistentPostRun: func(ccmd *cobra.Command, args []string) {
 			log.Infof("finished %s", strings.Join(os.Args, " "))
 		},
 	}
 
-	root.PersistentFlags().StringVarP(&logLevel, "loglevel", "l", "warn", "amount of information outputted (debug, info, warn, error)")
+	root.PersistentFlags().StringVarP(&logLevel, "loglevel", "l", "warn", "amount of information outputted (debug, info, warn, error). 'warn' hides the progress messages and 'error' also hides the warnings")
+	root.PersistentFlags().StringVarP(&logLevel, "log-level", "", "warn", "alias of --loglevel")
+	root.PersistentFlags().StringVarP(&logFile, "log-file", "", "", "path to a file where the debug logs of the command are written, truncated on every run")
+	root.PersistentFlags().StringVarP(&colorMode, "color", "", log.ColorAuto, "when to color the output (auto, always, never), 'auto' disables colors if the output is not a terminal or NO_COLOR is set")
+	root.PersistentFlags().BoolVarP(&noColor, "no-color", "", false, "disable colors in the output, same as --color=never")
+	root.PersistentFlags().BoolVarP(&strictEnv, "strict-env", "", false, "fail if the okteto manifest uses an undefined environment variable without a default value")
+	root.PersistentFlags().StringVarP(&proxy, "proxy", "", os.Getenv(okteto.ProxyEnvVar), "proxy for the connections to the okteto API, the registry and the builder, NO_PROXY is honored (defaults to $OKTETO_PROXY)")
+	root.PersistentFlags().StringVarP(&caBundle, "ca-bundle", "", os.Getenv(okteto.CABundleEnvVar), "path to a PEM bundle of additional certificate authorities to trust, e.g. the one of a TLS intercepting proxy (defaults to $OKTETO_CA_BUNDLE)")
+	root.PersistentFlags().StringVarP(&kubeconfigPath, "kubeconfig", "", "", "path to the kubeconfig file used for the Kubernetes operations (defaults to $KUBECONFIG or ~/.kube/config)")
+	root.PersistentFlags().StringVarP(&asUser, "as", "", "", "username to impersonate in the Kubernetes operations, like 'kubectl --as' (your credentials need the 'impersonate' permission)")
+	root.PersistentFlags().StringArrayVarP(&asGroups, "as-group", "", []string{}, "group to impersonate in the Kubernetes operations, can be repeated, requires --as")
 	root.AddCommand(cmd.Analytics())
 	root.AddCommand(cmd.Version())
 	root.AddCommand(cmd.Login())
@@ -103,9 +170,11 @@ func main() {
 	root.AddCommand(up.Up())
 	root.AddCommand(cmd.Down())
 	root.AddCommand(cmd.Push(ctx))
+	root.AddCommand(manifest.Manifest())
 	root.AddCommand(cmd.Status())
 	root.AddCommand(cmd.Doctor())
 	root.AddCommand(cmd.Exec())
+	root.AddCommand(cmd.Logs())
 	root.AddCommand(preview.Preview(ctx))
 	root.AddCommand(cmd.Restart())
 	root.AddCommand(cmd.Update())
@@ -120,11 +189,27 @@ func main() {
 			message = string(tmp)
 		}
 		log.Fail(message)
-		if uErr, ok := err.(errors.UserError); ok {
+		var uErr errors.UserError
+		if stderrors.As(err, &uErr) {
 			if len(uErr.Hint) > 0 {
-				log.Hint("    %s", uErr.Hint)
+				log.Println(log.BlueString("    %s", uErr.Hint))
 			}
 		}
-		os.Exit(1)
+		log.CloseLogFile()
+		os.Exit(errors.ExitCode(err))
+	}
+	log.CloseLogFile()
+}
+
+// validateLogLevel returns an error if the level is not one of the levels accepted by --loglevel
+func validateLogLevel(level string) error {
+	switch level {
+	case "debug", "info", "warn", "error":
+		return nil
+	default:
+		return errors.UserError{
+			E:    fmt.Errorf("invalid log level '%s'", level),
+			Hint: "Accepted values are 'debug', 'info', 'warn' and 'error'",
+		}
 	}
 }
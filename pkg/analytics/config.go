@@ -16,7 +16,10 @@ package analytics
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/denisbrodbeck/machineid"
 	"github.com/okteto/okteto/pkg/config"
@@ -24,6 +27,9 @@ import (
 	"github.com/okteto/okteto/pkg/okteto"
 )
 
+// DisableEnvVar is the environment variable that disables analytics, whatever the value of the analytics file
+const DisableEnvVar = "OKTETO_DISABLE_ANALYTICS"
+
 var (
 	CloudContext      = "Cloud"
 	StagingContext    = "Staging"
@@ -36,6 +42,7 @@ var (
 type Analytics struct {
 	Enabled   bool   `json:"enabled"`
 	MachineID string `json:"machineID"`
+	URL       string `json:"url,omitempty"`
 }
 
 func getContextType(oktetoContext string) string {
@@ -52,7 +59,13 @@ func getContextType(oktetoContext string) string {
 	return KubernetesContext
 }
 
+// Init creates the analytics file the first time okteto runs.
+// Nothing is created if analytics are disabled with OKTETO_DISABLE_ANALYTICS
 func Init() error {
+	if DisabledByEnv() {
+		return nil
+	}
+
 	if fileExists() {
 		return nil
 	}
@@ -70,6 +83,26 @@ func Init() error {
 	return a.save()
 }
 
+// DisabledByEnv returns true if analytics are disabled with OKTETO_DISABLE_ANALYTICS
+func DisabledByEnv() bool {
+	value := os.Getenv(DisableEnvVar)
+	if value == "" {
+		return false
+	}
+
+	disabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Infof("invalid value for %s: %s", DisableEnvVar, value)
+		return true
+	}
+	return disabled
+}
+
+// isEnabled returns true if the events can be sent
+func isEnabled() bool {
+	return !DisabledByEnv() && !unreachable && get().Enabled
+}
+
 func fileExists() bool {
 	if _, err := os.Stat(config.GetAnalyticsPath()); err != nil {
 		if os.IsNotExist(err) {
@@ -158,6 +191,21 @@ func Enable() error {
 	return a.save()
 }
 
+// SetURL sets the endpoint where the events are sent, instead of Mixpanel.
+// An empty value restores the default endpoint
+func SetURL(endpoint string) error {
+	if endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("'%s' is not a valid analytics URL, it must be an http or https URL", endpoint)
+		}
+	}
+
+	a := get()
+	a.URL = strings.TrimSuffix(endpoint, "/")
+	return a.save()
+}
+
 func getTrackID() string {
 	if okteto.Context().UserID != "" {
 		return okteto.Context().UserID
@@ -75,3 +75,85 @@ func Test_Get(t *testing.T) {
 	}
 
 }
+
+func Test_DisabledByEnv(t *testing.T) {
+	var tests = []struct {
+		value string
+		want  bool
+	}{
+		{value: "", want: false},
+		{value: "1", want: true},
+		{value: "true", want: true},
+		{value: "0", want: false},
+		{value: "false", want: false},
+		{value: "yes", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv(DisableEnvVar, tt.value)
+			if got := DisabledByEnv(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_InitDisabledByEnv(t *testing.T) {
+	t.Setenv("OKTETO_FOLDER", t.TempDir())
+	t.Setenv(DisableEnvVar, "1")
+	currentAnalytics = &Analytics{Enabled: true}
+	defer func() {
+		currentAnalytics = &Analytics{Enabled: false, MachineID: "machine-id"}
+	}()
+
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	if fileExists() {
+		t.Error("analytics file created while analytics are disabled")
+	}
+	if isEnabled() {
+		t.Error("analytics enabled while analytics are disabled")
+	}
+}
+
+func Test_SetURL(t *testing.T) {
+	var tests = []struct {
+		url       string
+		expected  string
+		expectErr bool
+	}{
+		{url: "https://analytics.example.com/", expected: "https://analytics.example.com"},
+		{url: "http://localhost:8080", expected: "http://localhost:8080"},
+		{url: "", expected: ""},
+		{url: "analytics.example.com", expectErr: true},
+		{url: "ftp://analytics.example.com", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			t.Setenv("OKTETO_FOLDER", t.TempDir())
+			currentAnalytics = nil
+			defer func() {
+				currentAnalytics = &Analytics{Enabled: false, MachineID: "machine-id"}
+			}()
+
+			err := SetURL(tt.url)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't got expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			currentAnalytics = nil
+			if got := get().URL; got != tt.expected {
+				t.Errorf("got '%s', expected '%s'", got, tt.expected)
+			}
+		})
+	}
+}
@@ -16,10 +16,12 @@ package analytics
 import (
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"regexp"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dukex/mixpanel"
@@ -59,6 +61,7 @@ const (
 	previewDeployEvent       = "DeployPreview"
 	previewDestroyEvent      = "DestroyPreview"
 	execEvent                = "Exec"
+	logsEvent                = "Logs"
 	signupEvent              = "Signup"
 	contextEvent             = "Context"
 	disableEvent             = "Disable Analytics"
@@ -67,20 +70,39 @@ const (
 
 var (
 	mixpanelClient mixpanel.Mixpanel
+	clientOnce     sync.Once
+
+	// unreachable is set when the analytics endpoint can't be reached, and disables the rest of the events of the command
+	unreachable bool
 )
 
-func init() {
-	c := &http.Client{
-		Timeout: time.Second * 5,
-		Transport: &http.Transport{
-			Dial: (&net.Dialer{
-				Timeout: 5 * time.Second,
-			}).Dial,
-			TLSHandshakeTimeout: 5 * time.Second,
-		},
-	}
+// getClient returns the client that sends the events to the analytics endpoint, Mixpanel by default
+func getClient() mixpanel.Mixpanel {
+	clientOnce.Do(func() {
+		c := &http.Client{
+			Timeout: time.Second * 5,
+			Transport: &http.Transport{
+				Dial: (&net.Dialer{
+					Timeout: 5 * time.Second,
+				}).Dial,
+				TLSHandshakeTimeout: 5 * time.Second,
+			},
+		}
+
+		mixpanelClient = mixpanel.NewFromClient(c, mixpanelToken, get().URL)
+	})
+	return mixpanelClient
+}
 
-	mixpanelClient = mixpanel.NewFromClient(c, mixpanelToken, "")
+// handleSendError disables the rest of the events if the analytics endpoint is unreachable
+func handleSendError(err error) {
+	log.Infof("Failed to send analytics: %s", err)
+	if mErr, ok := err.(*mixpanel.MixpanelError); ok {
+		if _, ok := mErr.Err.(*url.Error); ok {
+			log.Infof("analytics endpoint is unreachable, analytics are disabled for this command")
+			unreachable = true
+		}
+	}
 }
 
 // TrackInit sends a tracking event to mixpanel when the user creates a manifest
@@ -178,6 +200,11 @@ func TrackExec(success bool) {
 	track(execEvent, success, nil)
 }
 
+// TrackLogs sends a tracking event to mixpanel when the user runs the logs command
+func TrackLogs(success bool) {
+	track(logsEvent, success, nil)
+}
+
 // TrackDown sends a tracking event to mixpanel when the user deactivates a development container
 func TrackDown(success bool) {
 	track(downEvent, success, nil)
@@ -244,17 +271,17 @@ func TrackDestroyStack(success bool) {
 
 // TrackLogin sends a tracking event to mixpanel when the user logs in
 func TrackLogin(success bool) {
-	if !get().Enabled {
-		return
-	}
-
 	track(loginEvent, success, nil)
 }
 
 // TrackSignup sends a tracking event to mixpanel when the user signs up
 func TrackSignup(success bool, userID string) {
-	if err := mixpanelClient.Alias(get().MachineID, userID); err != nil {
+	if !isEnabled() {
+		return
+	}
+	if err := getClient().Alias(get().MachineID, userID); err != nil {
 		log.Errorf("failed to alias %s to %s", get().MachineID, userID)
+		handleSendError(err)
 	}
 
 	track(signupEvent, success, nil)
@@ -262,9 +289,6 @@ func TrackSignup(success bool, userID string) {
 
 // TrackContext sends a tracking event to mixpanel when the user use context in
 func TrackContext(success bool) {
-	if !get().Enabled {
-		return
-	}
 	track(contextEvent, success, nil)
 }
 
@@ -283,7 +307,7 @@ func TrackStackWarnings(warnings []string) {
 }
 
 func track(event string, success bool, props map[string]interface{}) {
-	if !get().Enabled {
+	if !isEnabled() {
 		return
 	}
 	if !okteto.IsTelemetryEnabled() && !okteto.IsOktetoCloud() {
@@ -317,7 +341,7 @@ func track(event string, success bool, props map[string]interface{}) {
 	props["context"] = okteto.Context().Name
 
 	e := &mixpanel.Event{Properties: props}
-	if err := mixpanelClient.Track(getTrackID(), event, e); err != nil {
-		log.Infof("Failed to send analytics: %s", err)
+	if err := getClient().Track(getTrackID(), event, e); err != nil {
+		handleSendError(err)
 	}
 }
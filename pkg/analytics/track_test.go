@@ -14,7 +14,10 @@
 package analytics
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/okteto/okteto/pkg/okteto"
@@ -93,3 +96,39 @@ func Test_getTrackID(t *testing.T) {
 		})
 	}
 }
+
+func Test_trackCustomURL(t *testing.T) {
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.URL.Path
+		_, _ = w.Write([]byte("1"))
+	}))
+	defer server.Close()
+
+	currentAnalytics = &Analytics{Enabled: true, MachineID: "machine-id", URL: server.URL}
+	clientOnce = sync.Once{}
+	defer func() {
+		currentAnalytics = &Analytics{Enabled: false, MachineID: "machine-id"}
+		clientOnce = sync.Once{}
+		unreachable = false
+	}()
+
+	track(upEvent, true, nil)
+	select {
+	case path := <-received:
+		if path != "/track" {
+			t.Errorf("event sent to '%s', expected '/track'", path)
+		}
+	default:
+		t.Fatal("event wasn't sent to the analytics URL")
+	}
+
+	server.Close()
+	track(upEvent, true, nil)
+	if !unreachable {
+		t.Fatal("analytics weren't disabled with an unreachable URL")
+	}
+	if isEnabled() {
+		t.Error("analytics enabled with an unreachable URL")
+	}
+}
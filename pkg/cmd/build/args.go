@@ -0,0 +1,104 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// ExpandBuildArgs runs the commands of the build args with the format '$(command)' and replaces them by their output.
+// A '\$(' sequence is kept as a literal '$('
+func ExpandBuildArgs(ctx context.Context, args model.Environment) (model.Environment, error) {
+	result := model.Environment{}
+	for _, arg := range args {
+		value, err := expandCommands(ctx, arg.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error expanding build arg '%s': %w", arg.Name, err)
+		}
+		result = append(result, model.EnvVar{Name: arg.Name, Value: value})
+	}
+	return result, nil
+}
+
+func expandCommands(ctx context.Context, value string) (string, error) {
+	var result strings.Builder
+	for i := 0; i < len(value); i++ {
+		if strings.HasPrefix(value[i:], `\$(`) {
+			result.WriteString("$(")
+			i += 2
+			continue
+		}
+		if !strings.HasPrefix(value[i:], "$(") {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		end := findClosingParenthesis(value, i+2)
+		if end == -1 {
+			return "", fmt.Errorf("unterminated command in '%s'", value)
+		}
+		output, err := runBuildArgCommand(ctx, value[i+2:end])
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(output)
+		i = end
+	}
+	return result.String(), nil
+}
+
+// findClosingParenthesis returns the index of the parenthesis closing the one opened before start, or -1
+func findClosingParenthesis(value string, start int) int {
+	depth := 1
+	for i := start; i < len(value); i++ {
+		switch value[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// runBuildArgCommand runs a command in the local shell and returns its standard output without the trailing newlines
+func runBuildArgCommand(ctx context.Context, command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Env = os.Environ()
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	log.Infof("running build arg command '%s'", command)
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("error running '%s': %w: %s", command, err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func Test_ExpandBuildArgs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands of this test need a posix shell")
+	}
+
+	var tests = []struct {
+		name      string
+		args      model.Environment
+		expected  model.Environment
+		expectErr bool
+	}{
+		{
+			name:     "static",
+			args:     model.Environment{{Name: "KEY", Value: "value"}},
+			expected: model.Environment{{Name: "KEY", Value: "value"}},
+		},
+		{
+			name:     "command",
+			args:     model.Environment{{Name: "SHA", Value: "$(echo abc123)"}},
+			expected: model.Environment{{Name: "SHA", Value: "abc123"}},
+		},
+		{
+			name:     "command-in-the-middle",
+			args:     model.Environment{{Name: "VERSION", Value: "v-$(printf '1\n\n')-$(echo $(echo nested))"}},
+			expected: model.Environment{{Name: "VERSION", Value: "v-1-nested"}},
+		},
+		{
+			name:     "escaped",
+			args:     model.Environment{{Name: "LITERAL", Value: `\$(echo abc)`}},
+			expected: model.Environment{{Name: "LITERAL", Value: "$(echo abc)"}},
+		},
+		{
+			name:      "unterminated",
+			args:      model.Environment{{Name: "KEY", Value: "$(echo abc"}},
+			expectErr: true,
+		},
+		{
+			name:      "failed-command",
+			args:      model.Environment{{Name: "KEY", Value: "$(exit 1)"}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExpandBuildArgs(context.Background(), tt.args)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/docker/cli/cli/config/types"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/auth"
+	"github.com/okteto/okteto/pkg/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -74,6 +75,7 @@ func (ap *authProvider) Credentials(ctx context.Context, req *auth.CredentialsRe
 	if req.Host == oktetoRegistry {
 		res.Username = ap.config.AuthConfigs[oktetoRegistry].Username
 		res.Secret = ap.config.AuthConfigs[oktetoRegistry].Password
+		log.AddMaskedWord(res.Secret)
 		return res, nil
 	}
 
@@ -92,5 +94,6 @@ func (ap *authProvider) Credentials(ctx context.Context, req *auth.CredentialsRe
 		res.Username = ac.Username
 		res.Secret = ac.Password
 	}
+	log.AddMaskedWord(res.Secret)
 	return res, nil
 }
@@ -19,6 +19,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/containerd/containerd/platforms"
 	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/client"
 	"github.com/okteto/okteto/pkg/analytics"
@@ -31,42 +32,57 @@ import (
 
 //BuildOptions define the options available for build
 type BuildOptions struct {
-	BuildArgs  []string
-	CacheFrom  []string
-	File       string
-	NoCache    bool
-	OutputMode string
-	Path       string
-	Secrets    []string
-	Tag        string
-	Target     string
+	BuildArgs          []string
+	CacheFrom          []string
+	EnableContextCheck bool
+	File               string
+	Force              bool
+	NoCache            bool
+	NoCacheFilter      []string
+	OCIMediaTypes      bool
+	OutputMode         string
+	Path               string
+	Platform           string
+	PullBase           bool
+	SecretCommands     []string
+	Secrets            []string
+	Tag                string
+	Target             string
 }
 
-// Run runs the build sequence
-func Run(ctx context.Context, namespace string, buildOptions BuildOptions) error {
-	if okteto.Context().Buildkit == "" {
-		if err := buildWithDocker(ctx, buildOptions); err != nil {
-			return err
-		}
-	} else {
-		if err := buildWithOkteto(ctx, namespace, buildOptions); err != nil {
-			return err
+// Run runs the build sequence and returns the digest of the pushed image, if any
+func Run(ctx context.Context, namespace string, buildOptions BuildOptions) (string, error) {
+	if err := checkBuildContext(buildOptions); err != nil {
+		return "", err
+	}
+
+	if len(buildOptions.NoCacheFilter) > 0 {
+		if err := validateNoCacheFilter(buildOptions); err != nil {
+			return "", err
 		}
 	}
-	return nil
+
+	if err := validatePlatform(buildOptions.Platform); err != nil {
+		return "", err
+	}
+
+	if okteto.Context().Buildkit == "" {
+		return buildWithDocker(ctx, buildOptions)
+	}
+	return buildWithOkteto(ctx, namespace, buildOptions)
 }
 
-func buildWithOkteto(ctx context.Context, namespace string, buildOptions BuildOptions) error {
+func buildWithOkteto(ctx context.Context, namespace string, buildOptions BuildOptions) (string, error) {
 	log.Infof("building your image on %s", okteto.Context().Buildkit)
 	buildkitClient, err := getBuildkitClient(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if buildOptions.File != "" {
 		buildOptions.File, err = registry.GetDockerfile(buildOptions.File)
 		if err != nil {
-			return err
+			return "", err
 		}
 		defer os.Remove(buildOptions.File)
 	}
@@ -74,7 +90,7 @@ func buildWithOkteto(ctx context.Context, namespace string, buildOptions BuildOp
 	if buildOptions.Tag != "" {
 		err = validateImage(buildOptions.Tag)
 		if err != nil {
-			return err
+			return "", err
 		}
 	}
 
@@ -88,10 +104,10 @@ func buildWithOkteto(ctx context.Context, namespace string, buildOptions BuildOp
 	}
 	opt, err := getSolveOpt(buildOptions)
 	if err != nil {
-		return errors.Wrap(err, "failed to create build solver")
+		return "", errors.Wrap(err, "failed to create build solver")
 	}
 
-	err = solveBuild(ctx, buildkitClient, opt, buildOptions.OutputMode)
+	digest, err := solveBuild(ctx, buildkitClient, opt, buildOptions.OutputMode)
 	if err != nil {
 		log.Infof("Failed to build image: %s", err.Error())
 	}
@@ -100,41 +116,70 @@ func buildWithOkteto(ctx context.Context, namespace string, buildOptions BuildOp
   %s,
   Retrying ...`, buildOptions.Tag, err.Error())
 		success := true
-		err := solveBuild(ctx, buildkitClient, opt, buildOptions.OutputMode)
+		digest, err = solveBuild(ctx, buildkitClient, opt, buildOptions.OutputMode)
 		if err != nil {
 			success = false
 			log.Infof("Failed to build image: %s", err.Error())
 		}
 		err = registry.GetErrorMessage(err, buildOptions.Tag)
 		analytics.TrackBuildTransientError(okteto.Context().Buildkit, success)
-		return err
+		return digest, err
 	}
 
 	err = registry.GetErrorMessage(err, buildOptions.Tag)
-	return err
+	return digest, err
 }
 
 // https://github.com/docker/cli/blob/56e5910181d8ac038a634a203a4f3550bb64991f/cli/command/image/build.go#L209
-func buildWithDocker(ctx context.Context, buildOptions BuildOptions) error {
+func buildWithDocker(ctx context.Context, buildOptions BuildOptions) (string, error) {
+	if buildOptions.OCIMediaTypes {
+		log.Warning("OCI media types are not supported by your local docker daemon. Your image will be pushed using Docker media types")
+	}
+	if len(buildOptions.NoCacheFilter) > 0 && !buildOptions.NoCache {
+		log.Warning("'--no-cache-filter' is not supported by your local docker daemon. The cache of all the stages will be ignored")
+		buildOptions.NoCache = true
+	}
+	if strings.Contains(buildOptions.Platform, ",") {
+		return "", okErrors.UserError{
+			E:    fmt.Errorf("multi-platform builds are not supported by your local docker daemon"),
+			Hint: "Set a single platform in '--platform' or configure a BuildKit instance with 'okteto context --builder'",
+		}
+	}
 
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
-		return err
+		return "", err
 	}
 	if versions.GreaterThanOrEqualTo(cli.ClientVersion(), "1.39") {
 		err = buildWithDockerDaemonBuildkit(ctx, buildOptions, cli)
 		if err != nil {
-			return err
+			return "", err
 		}
 	} else {
 		err = buildWithDockerDaemon(ctx, buildOptions, cli)
 		if err != nil {
-			return err
+			return "", err
 		}
 	}
 	if buildOptions.Tag != "" {
 		return pushImage(ctx, buildOptions.Tag, cli)
 	}
+	return "", nil
+}
+
+// validatePlatform returns an error if platform is not a comma-separated list of platforms, e.g. 'linux/amd64,linux/arm64'
+func validatePlatform(platform string) error {
+	if platform == "" {
+		return nil
+	}
+	for _, p := range strings.Split(platform, ",") {
+		if _, err := platforms.Parse(p); err != nil {
+			return okErrors.UserError{
+				E:    fmt.Errorf("invalid platform '%s': %s", p, err),
+				Hint: "Use the format 'os/arch[/variant]', e.g. 'linux/amd64' or 'linux/arm64'",
+			}
+		}
+	}
 	return nil
 }
 
@@ -151,3 +196,36 @@ func validateImage(imageTag string) error {
 	}
 	return nil
 }
+
+// getUniqueCacheFrom returns the cache sources without duplicates, keeping the original order
+func getUniqueCacheFrom(cacheFrom []string) []string {
+	result := []string{}
+	seen := map[string]bool{}
+	for _, image := range cacheFrom {
+		if image == "" || seen[image] {
+			continue
+		}
+		seen[image] = true
+		result = append(result, image)
+	}
+	return result
+}
+
+// GetSecretsFromEnv returns the secrets exposing the given environment variables to the build.
+// Only the variable names are part of the secret definitions, values are read by the secret provider.
+func GetSecretsFromEnv(names []string) ([]string, error) {
+	result := []string{}
+	for _, name := range names {
+		if name == "" || strings.ContainsAny(name, ",=") {
+			return nil, fmt.Errorf("'%s' is not a valid environment variable name", name)
+		}
+		if _, ok := os.LookupEnv(name); !ok {
+			return nil, okErrors.UserError{
+				E:    fmt.Errorf("environment variable '%s' is not set", name),
+				Hint: fmt.Sprintf("Set '%s' before running the command", name),
+			}
+		}
+		result = append(result, fmt.Sprintf("id=%s,env=%s", name, name))
+	}
+	return result, nil
+}
@@ -40,12 +40,10 @@ import (
 	dockerRegistry "github.com/docker/docker/registry"
 	controlapi "github.com/moby/buildkit/api/services/control"
 	buildkitClient "github.com/moby/buildkit/client"
-	"github.com/moby/buildkit/cmd/buildctl/build"
 	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/auth/authprovider"
 	"github.com/moby/buildkit/session/filesync"
-	"github.com/moby/buildkit/util/progress/progressui"
 	"github.com/moby/buildkit/util/progress/progresswriter"
 	"github.com/moby/term"
 	"github.com/okteto/okteto/pkg/log"
@@ -67,11 +65,11 @@ func buildWithDockerDaemonBuildkit(ctx context.Context, buildOptions BuildOption
 
 	dockerAuthProvider := authprovider.NewDockerAuthProvider(os.Stderr)
 	s.Allow(dockerAuthProvider)
-	if len(buildOptions.Secrets) > 0 {
-		secretProvider, err := build.ParseSecret(buildOptions.Secrets)
-		if err != nil {
-			return errors.Wrapf(err, "could not parse secrets: %v", buildOptions.Secrets)
-		}
+	secretProvider, err := getSecretAttachable(buildOptions)
+	if err != nil {
+		return err
+	}
+	if secretProvider != nil {
 		s.Allow(secretProvider)
 	}
 	var (
@@ -124,6 +122,7 @@ func buildWithDockerDaemonBuildkit(ctx context.Context, buildOptions BuildOption
 			Dockerfile:    filepath.Base(buildOptions.File),
 			RemoteContext: remote,
 			SessionID:     s.ID(),
+			Platform:      buildOptions.Platform,
 		}
 		if buildOptions.Tag != "" {
 			dockerBuildOptions.Tags = append(dockerBuildOptions.Tags, buildOptions.Tag)
@@ -223,9 +222,8 @@ func displayStatus(out *os.File, eg *errgroup.Group, response types.ImageBuildRe
 		if cons, err := console.ConsoleFromFile(out); err == nil && (buildOutputMode == "auto" || buildOutputMode == "tty") {
 			c = cons
 		}
-		// not using shared context to not disrupt display but let it finish reporting errors
 		eg.Go(func() error {
-			return progressui.DisplaySolveStatus(context.TODO(), "", c, out, displayCh)
+			return displaySolveStatus(buildOutputMode, c, out, displayCh)
 		})
 		if s, ok := at.(interface {
 			SetLogger(progresswriter.Logger)
@@ -236,7 +234,12 @@ func displayStatus(out *os.File, eg *errgroup.Group, response types.ImageBuildRe
 		}
 	}
 	displayCh := make(chan *buildkitClient.SolveStatus)
-	displayStatus(os.Stderr, displayCh)
+	if buildOutputMode == RawJSONProgress {
+		// json lines are written to stdout so they can be consumed programmatically
+		displayStatus(os.Stdout, displayCh)
+	} else {
+		displayStatus(os.Stderr, displayCh)
+	}
 	defer close(displayCh)
 
 	buf := bytes.NewBuffer(nil)
@@ -381,9 +384,10 @@ func getDockerOptions(buildOptions BuildOptions) (types.ImageBuildOptions, error
 		ForceRemove:    true,
 		PullParent:     true,
 		Dockerfile:     buildOptions.File,
-		CacheFrom:      buildOptions.CacheFrom,
+		CacheFrom:      getUniqueCacheFrom(buildOptions.CacheFrom),
 		Target:         buildOptions.Target,
 		NoCache:        buildOptions.NoCache,
+		Platform:       buildOptions.Platform,
 	}
 	if buildOptions.Tag != "" {
 		opts.Tags = append(opts.Tags, buildOptions.Tag)
@@ -399,29 +403,29 @@ func getDockerOptions(buildOptions BuildOptions) (types.ImageBuildOptions, error
 	return opts, nil
 }
 
-func pushImage(ctx context.Context, tag string, client *client.Client) error {
+func pushImage(ctx context.Context, tag string, client *client.Client) (string, error) {
 	dockerCli, err := command.NewDockerCli()
 	if err != nil {
-		return fmt.Errorf("docker not found")
+		return "", fmt.Errorf("docker not found")
 	}
 	ref, err := reference.ParseNormalizedNamed(tag)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	repoInfo, err := dockerRegistry.ParseRepositoryInfo(ref)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	authConfig := ResolveAuthConfig(ctx, dockerCli, client, repoInfo)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	encodedAuth, err := command.EncodeAuthToBase64(authConfig)
 	if err != nil {
-		return err
+		return "", err
 	}
 	requestPrivilege := command.RegistryAuthenticationPrivilegedFunc(dockerCli, repoInfo.Index, "push")
 	options := types.ImagePushOptions{
@@ -431,10 +435,24 @@ func pushImage(ctx context.Context, tag string, client *client.Client) error {
 
 	responseBody, err := client.ImagePush(ctx, tag, options)
 	if err != nil {
-		return errors.Wrap(err, "could not push image")
+		return "", errors.Wrap(err, "could not push image")
 	}
 
-	return jsonmessage.DisplayJSONMessagesToStream(responseBody, dockerCli.Out(), nil)
+	defer responseBody.Close()
+
+	digest := ""
+	aux := func(msg jsonmessage.JSONMessage) {
+		var result types.PushResult
+		if err := json.Unmarshal(*msg.Aux, &result); err != nil {
+			log.Infof("failed to parse aux message: %s", err)
+			return
+		}
+		digest = result.Digest
+	}
+	if err := jsonmessage.DisplayJSONMessagesToStream(responseBody, dockerCli.Out(), aux); err != nil {
+		return "", err
+	}
+	return digest, nil
 }
 
 func ResolveAuthConfig(ctx context.Context, dockerCli *command.DockerCli, cli *client.Client, repoInfo *dockerRegistry.RepositoryInfo) types.AuthConfig {
@@ -1,10 +1,17 @@
 package build
 
 import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/moby/buildkit/client"
 	okErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/okteto"
 )
 
 func Test_validateImage(t *testing.T) {
@@ -47,3 +54,485 @@ func Test_validateImage(t *testing.T) {
 		})
 	}
 }
+
+func Test_getSolveOptOCIMediaTypes(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Name: "test",
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	dockerfile := filepath.Join(dir, "Dockerfile")
+	if err := os.WriteFile(dockerfile, []byte("FROM alpine"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		ociMediaTypes bool
+		want          string
+	}{
+		{
+			name:          "docker-media-types",
+			ociMediaTypes: false,
+			want:          "",
+		},
+		{
+			name:          "oci-media-types",
+			ociMediaTypes: true,
+			want:          "true",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt, err := getSolveOpt(BuildOptions{
+				Path:          dir,
+				File:          dockerfile,
+				Tag:           "okteto/test",
+				OCIMediaTypes: tt.ociMediaTypes,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := opt.Exports[0].Attrs["oci-mediatypes"]; got != tt.want {
+				t.Errorf("oci-mediatypes = '%s', want '%s'", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getSolveOptPullBase(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Name: "test",
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	dockerfile := filepath.Join(dir, "Dockerfile")
+	if err := os.WriteFile(dockerfile, []byte("FROM alpine"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		pullBase bool
+		want     string
+	}{
+		{
+			name:     "default-resolve-mode",
+			pullBase: false,
+			want:     "",
+		},
+		{
+			name:     "pull-resolve-mode",
+			pullBase: true,
+			want:     "pull",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt, err := getSolveOpt(BuildOptions{
+				Path:     dir,
+				File:     dockerfile,
+				Tag:      "okteto/test",
+				PullBase: tt.pullBase,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := opt.FrontendAttrs["image-resolve-mode"]; got != tt.want {
+				t.Errorf("image-resolve-mode = '%s', want '%s'", got, tt.want)
+			}
+			if _, ok := opt.FrontendAttrs["no-cache"]; ok {
+				t.Errorf("no-cache was set")
+			}
+		})
+	}
+}
+
+func Test_getSolveOptPlatform(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Name: "test",
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	dockerfile := filepath.Join(dir, "Dockerfile")
+	if err := os.WriteFile(dockerfile, []byte("FROM alpine"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		platform string
+		want     string
+		exists   bool
+	}{
+		{
+			name:     "no-platform",
+			platform: "",
+			exists:   false,
+		},
+		{
+			name:     "single-platform",
+			platform: "linux/arm64",
+			want:     "linux/arm64",
+			exists:   true,
+		},
+		{
+			name:     "multiple-platforms",
+			platform: "linux/amd64,linux/arm64",
+			want:     "linux/amd64,linux/arm64",
+			exists:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt, err := getSolveOpt(BuildOptions{
+				Path:     dir,
+				File:     dockerfile,
+				Tag:      "okteto/test",
+				Platform: tt.platform,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := opt.FrontendAttrs["platform"]
+			if ok != tt.exists {
+				t.Fatalf("platform set = %t, want %t", ok, tt.exists)
+			}
+			if got != tt.want {
+				t.Errorf("platform = '%s', want '%s'", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validatePlatform(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		wantErr  bool
+	}{
+		{
+			name:     "empty",
+			platform: "",
+			wantErr:  false,
+		},
+		{
+			name:     "single",
+			platform: "linux/amd64",
+			wantErr:  false,
+		},
+		{
+			name:     "variant",
+			platform: "linux/arm/v7",
+			wantErr:  false,
+		},
+		{
+			name:     "multiple",
+			platform: "linux/amd64,linux/arm64",
+			wantErr:  false,
+		},
+		{
+			name:     "spaces",
+			platform: "linux/amd64, linux/arm64",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid",
+			platform: "linux/amd64,not/a/valid/platform",
+			wantErr:  true,
+		},
+		{
+			name:     "empty-item",
+			platform: "linux/amd64,",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePlatform(tt.platform); (err != nil) != tt.wantErr {
+				t.Errorf("validatePlatform() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_getSolveOptNoCacheFilter(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Name: "test",
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	dockerfile := filepath.Join(dir, "Dockerfile")
+	if err := os.WriteFile(dockerfile, []byte("FROM alpine AS deps\nFROM alpine AS Build\nFROM alpine"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		noCache       bool
+		noCacheFilter []string
+		want          string
+		wantErr       bool
+	}{
+		{
+			name:          "single-stage",
+			noCacheFilter: []string{"deps"},
+			want:          "deps",
+		},
+		{
+			name:          "several-stages",
+			noCacheFilter: []string{"deps", "build"},
+			want:          "deps,build",
+		},
+		{
+			name:          "no-cache-wins",
+			noCache:       true,
+			noCacheFilter: []string{"deps"},
+			want:          "",
+		},
+		{
+			name:          "unknown-stage",
+			noCacheFilter: []string{"test"},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buildOptions := BuildOptions{
+				Path:          dir,
+				File:          dockerfile,
+				Tag:           "okteto/test",
+				NoCache:       tt.noCache,
+				NoCacheFilter: tt.noCacheFilter,
+			}
+			err := validateNoCacheFilter(buildOptions)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error for an unknown stage")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			opt, err := getSolveOpt(buildOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, ok := opt.FrontendAttrs["no-cache"]; !ok || got != tt.want {
+				t.Errorf("no-cache = '%s', want '%s'", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getUniqueCacheFrom(t *testing.T) {
+	got := getUniqueCacheFrom([]string{"base", "previous", "", "base"})
+	want := []string{"base", "previous"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("build.getUniqueCacheFrom = %v, want %v", got, want)
+	}
+}
+
+func Test_GetSecretsFromEnv(t *testing.T) {
+	os.Setenv("OKTETO_TEST_SECRET", "value")
+	defer os.Unsetenv("OKTETO_TEST_SECRET")
+	os.Unsetenv("OKTETO_TEST_MISSING_SECRET")
+
+	got, err := GetSecretsFromEnv([]string{"OKTETO_TEST_SECRET"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"id=OKTETO_TEST_SECRET,env=OKTETO_TEST_SECRET"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("build.GetSecretsFromEnv = %v, want %v", got, want)
+	}
+
+	if _, err := GetSecretsFromEnv([]string{"OKTETO_TEST_MISSING_SECRET"}); err == nil {
+		t.Error("expected error for an unset environment variable")
+	}
+	if _, err := GetSecretsFromEnv([]string{"A=B"}); err == nil {
+		t.Error("expected error for an invalid environment variable name")
+	}
+}
+
+func Test_displayRawJSONStatus(t *testing.T) {
+	ch := make(chan *client.SolveStatus)
+	go func() {
+		ch <- &client.SolveStatus{Vertexes: []*client.Vertex{{Name: "[1/2] FROM alpine", Cached: true}}}
+		ch <- &client.SolveStatus{Logs: []*client.VertexLog{{Data: []byte("hello")}}}
+		close(ch)
+	}()
+
+	var out bytes.Buffer
+	if err := displayRawJSONStatus(&out, ch); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 json lines, got %d: %s", len(lines), out.String())
+	}
+	var status client.SolveStatus
+	if err := json.Unmarshal([]byte(lines[0]), &status); err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Vertexes) != 1 || status.Vertexes[0].Name != "[1/2] FROM alpine" || !status.Vertexes[0].Cached {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func Test_getContextSize(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Dockerfile":              "FROM alpine",
+		"main.go":                 "package main",
+		"node_modules/a/index.js": "module.exports = {}",
+		"node_modules/b/index.js": "module.exports = {}",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	size, count, err := getContextSize(dir, 1024, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 4 || size != 61 {
+		t.Errorf("got %d files and %d bytes, expected 4 files and 61 bytes", count, size)
+	}
+
+	if _, _, err := getContextSize(dir, 1024, 3); err != errContextTooLarge {
+		t.Errorf("expected errContextTooLarge, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".dockerignore"), []byte("node_modules\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	size, count, err = getContextSize(dir, 1024, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 || size != 36 {
+		t.Errorf("got %d files and %d bytes, expected 3 files and 36 bytes", count, size)
+	}
+}
+
+func Test_checkBuildContext(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		name        string
+		options     BuildOptions
+		expectedErr bool
+	}{
+		{
+			name:        "enabled",
+			options:     BuildOptions{Path: home, EnableContextCheck: true},
+			expectedErr: true,
+		},
+		{
+			name:    "forced",
+			options: BuildOptions{Path: home, EnableContextCheck: true, Force: true},
+		},
+		{
+			name:    "not-enabled",
+			options: BuildOptions{Path: home},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBuildContext(tt.options)
+			if tt.expectedErr && err == nil {
+				t.Fatal("expected error checking the home directory")
+			}
+			if !tt.expectedErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func Test_getExcludedSizes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Dockerfile":              "FROM alpine",
+		"main.go":                 "package main",
+		"debug.log":               "0123456789",
+		"node_modules/a/index.js": "module.exports = {}",
+		"node_modules/b/index.js": "module.exports = {}",
+		"node_modules/c/keep.js":  "keep",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sizes, err := getExcludedSizes(dir, []string{"node_modules", "*.log", "!node_modules/c", "*.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]int64{
+		"node_modules": 38,
+		"*.log":        10,
+	}
+	if len(sizes) != len(expected) {
+		t.Fatalf("got %v, expected %v", sizes, expected)
+	}
+	for p, size := range expected {
+		if sizes[p] != size {
+			t.Errorf("pattern '%s' excluded %d bytes, expected %d", p, sizes[p], size)
+		}
+	}
+}
+
+func Test_formatSize(t *testing.T) {
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{size: 0, want: "0 B"},
+		{size: 1023, want: "1023 B"},
+		{size: 1024, want: "1.0 KB"},
+		{size: 1536, want: "1.5 KB"},
+		{size: 100 * 1024 * 1024, want: "100.0 MB"},
+		{size: 3 * 1024 * 1024 * 1024, want: "3.0 GB"},
+	}
+	for _, tt := range tests {
+		if got := formatSize(tt.size); got != tt.want {
+			t.Errorf("formatSize(%d) = '%s', want '%s'", tt.size, got, tt.want)
+		}
+	}
+}
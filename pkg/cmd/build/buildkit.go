@@ -24,10 +24,8 @@ import (
 
 	"github.com/containerd/console"
 	"github.com/moby/buildkit/client"
-	"github.com/moby/buildkit/cmd/buildctl/build"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/auth/authprovider"
-	"github.com/moby/buildkit/util/progress/progressui"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -39,6 +37,9 @@ import (
 
 const (
 	frontend = "dockerfile.v0"
+
+	// exporterImageDigestKey is the key of the image digest in the exporter response
+	exporterImageDigestKey = "containerimage.digest"
 )
 
 // getSolveOpt returns the buildkit solve options
@@ -72,6 +73,14 @@ func getSolveOpt(buildOptions BuildOptions) (*client.SolveOpt, error) {
 	}
 	if buildOptions.NoCache {
 		frontendAttrs["no-cache"] = ""
+	} else if len(buildOptions.NoCacheFilter) > 0 {
+		frontendAttrs["no-cache"] = strings.Join(buildOptions.NoCacheFilter, ",")
+	}
+	if buildOptions.PullBase {
+		frontendAttrs["image-resolve-mode"] = "pull"
+	}
+	if buildOptions.Platform != "" {
+		frontendAttrs["platform"] = buildOptions.Platform
 	}
 	for _, buildArg := range buildOptions.BuildArgs {
 		kv := strings.SplitN(buildArg, "=", 2)
@@ -87,11 +96,11 @@ func getSolveOpt(buildOptions BuildOptions) (*client.SolveOpt, error) {
 		attachable = append(attachable, authprovider.NewDockerAuthProvider(os.Stderr))
 	}
 
-	if len(buildOptions.Secrets) > 0 {
-		secretProvider, err := build.ParseSecret(buildOptions.Secrets)
-		if err != nil {
-			return nil, err
-		}
+	secretProvider, err := getSecretAttachable(buildOptions)
+	if err != nil {
+		return nil, err
+	}
+	if secretProvider != nil {
 		attachable = append(attachable, secretProvider)
 	}
 	opt := &client.SolveOpt{
@@ -103,17 +112,21 @@ func getSolveOpt(buildOptions BuildOptions) (*client.SolveOpt, error) {
 	}
 
 	if buildOptions.Tag != "" {
+		exportAttrs := map[string]string{
+			"name": buildOptions.Tag,
+			"push": "true",
+		}
+		if buildOptions.OCIMediaTypes {
+			exportAttrs["oci-mediatypes"] = "true"
+		}
 		opt.Exports = []client.ExportEntry{
 			{
-				Type: "image",
-				Attrs: map[string]string{
-					"name": buildOptions.Tag,
-					"push": "true",
-				},
+				Type:  "image",
+				Attrs: exportAttrs,
 			},
 		}
 	}
-	for _, cacheFromImage := range buildOptions.CacheFrom {
+	for _, cacheFromImage := range getUniqueCacheFrom(buildOptions.CacheFrom) {
 		opt.CacheImports = append(
 			opt.CacheImports,
 			client.CacheOptionsEntry{
@@ -141,6 +154,10 @@ func getBuildkitClient(ctx context.Context) (*client.Client, error) {
 		if err != nil {
 			return nil, fmt.Errorf("certificate decoding error: %w", err)
 		}
+		if bundle := okteto.GetCABundle(); bundle != nil {
+			// trust the certificate authorities of a proxy intercepting the connection to the builder
+			certBytes = append(append(certBytes, '\n'), bundle...)
+		}
 
 		if err := os.WriteFile(config.GetCertificatePath(), certBytes, 0600); err != nil {
 			return nil, err
@@ -184,13 +201,17 @@ func getClientForOktetoCluster(ctx context.Context) (*client.Client, error) {
 	return c, nil
 }
 
-func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, progress string) error {
+func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, progress string) (string, error) {
 	ch := make(chan *client.SolveStatus)
 	eg, ctx := errgroup.WithContext(ctx)
+	var digest string
 	eg.Go(func() error {
-		var err error
-		_, err = c.Solve(ctx, nil, *opt, ch)
-		return errors.Wrap(err, "build failed")
+		resp, err := c.Solve(ctx, nil, *opt, ch)
+		if err != nil {
+			return errors.Wrap(err, "build failed")
+		}
+		digest = resp.ExporterResponse[exporterImageDigestKey]
+		return nil
 	})
 
 	eg.Go(func() error {
@@ -200,9 +221,11 @@ func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, pro
 				c = cn
 			}
 		}
-		// not using shared context to not disrupt display but let it finish reporting errors
-		return progressui.DisplaySolveStatus(context.TODO(), "", c, os.Stdout, ch)
+		return displaySolveStatus(progress, c, os.Stdout, ch)
 	})
 
-	return eg.Wait()
+	if err := eg.Wait(); err != nil {
+		return "", err
+	}
+	return digest, nil
 }
@@ -0,0 +1,255 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// maxContextSize is the size of a build context considered too large to be uploaded without confirmation
+	maxContextSize int64 = 1024 * 1024 * 1024
+
+	// maxContextFiles is the number of files of a build context considered too large to be uploaded without confirmation
+	maxContextFiles = 100000
+
+	// warnContextSize is the size of a build context that is uploaded with a warning about its size
+	warnContextSize int64 = 100 * 1024 * 1024
+
+	// maxReportedPatterns is the number of '.dockerignore' patterns reported when the build context is large
+	maxReportedPatterns = 3
+)
+
+var errContextTooLarge = fmt.Errorf("build context too large")
+
+// checkBuildContext returns an error if the build context is the home directory of the user or it is too large, excluding the files ignored by '.dockerignore'.
+// It's skipped unless the build enables it, and when the build is forced
+func checkBuildContext(buildOptions BuildOptions) error {
+	if !buildOptions.EnableContextCheck || buildOptions.Force {
+		return nil
+	}
+	path := buildOptions.Path
+	if path == "" {
+		return nil
+	}
+	if uri, err := url.ParseRequestURI(path); err == nil && uri.Scheme != "" && uri.Host != "" {
+		return nil
+	}
+
+	contextDir, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	if home, err := os.UserHomeDir(); err == nil && filepath.Clean(home) == contextDir {
+		log.Warning("The build context '%s' is your home directory", contextDir)
+		return errors.UserError{
+			E:    fmt.Errorf("the build context is your home directory"),
+			Hint: "Run the command from the folder of your Dockerfile or use '--force' to build it anyway",
+		}
+	}
+
+	size, files, err := getContextSize(contextDir, maxContextSize, maxContextFiles)
+	if err == errContextTooLarge {
+		log.Warning("The build context '%s' has more than %d files or %d MB", contextDir, maxContextFiles, maxContextSize/1024/1024)
+		reportExcludedPatterns(contextDir)
+		return errors.UserError{
+			E:    fmt.Errorf("the build context '%s' is too large", contextDir),
+			Hint: "Add the files not needed by your Dockerfile to '.dockerignore' or use '--force' to build it anyway",
+		}
+	}
+	if err != nil {
+		log.Infof("failed to estimate the size of the build context: %s", err)
+		return nil
+	}
+	log.Infof("build context '%s': %d files, %d bytes", contextDir, files, size)
+	log.Information("Sending build context: %s (%d files)", formatSize(size), files)
+	if size > warnContextSize {
+		log.Warning("The build context '%s' is larger than %d MB, the build will be slower", contextDir, warnContextSize/1024/1024)
+		reportExcludedPatterns(contextDir)
+	}
+	return nil
+}
+
+// reportExcludedPatterns logs the '.dockerignore' patterns of a build context that exclude the most bytes
+func reportExcludedPatterns(contextDir string) {
+	excludes, err := readDockerignore(contextDir)
+	if err != nil {
+		log.Infof("failed to read '.dockerignore': %s", err)
+		return
+	}
+	if len(excludes) == 0 {
+		log.Hint("    Add a '.dockerignore' file to '%s' to exclude the files not needed by your Dockerfile", contextDir)
+		return
+	}
+
+	sizes, err := getExcludedSizes(contextDir, excludes)
+	if err != nil {
+		log.Infof("failed to estimate the size of the files excluded by '.dockerignore': %s", err)
+		return
+	}
+	patterns := make([]string, 0, len(sizes))
+	for p := range sizes {
+		patterns = append(patterns, p)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if sizes[patterns[i]] == sizes[patterns[j]] {
+			return patterns[i] < patterns[j]
+		}
+		return sizes[patterns[i]] > sizes[patterns[j]]
+	})
+	if len(patterns) > maxReportedPatterns {
+		patterns = patterns[:maxReportedPatterns]
+	}
+	if len(patterns) == 0 {
+		log.Hint("    The patterns of '.dockerignore' don't exclude any file of the build context")
+		return
+	}
+	log.Information("Patterns of '.dockerignore' excluding the most:")
+	for _, p := range patterns {
+		log.Information("    '%s': %s", p, formatSize(sizes[p]))
+	}
+}
+
+// getExcludedSizes returns the size of the files of a build context excluded by each of its '.dockerignore' patterns.
+// Each excluded file is accounted to the last pattern matching it, following the '.dockerignore' precedence rules
+func getExcludedSizes(contextDir string, excludes []string) (map[string]int64, error) {
+	pm, err := fileutils.NewPatternMatcher(excludes)
+	if err != nil {
+		return nil, err
+	}
+	matchers := []*fileutils.PatternMatcher{}
+	names := []string{}
+	for _, p := range pm.Patterns() {
+		if p.Exclusion() {
+			continue
+		}
+		m, err := fileutils.NewPatternMatcher([]string{p.String()})
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+		names = append(names, p.String())
+	}
+
+	sizes := map[string]int64{}
+	err = filepath.WalkDir(contextDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(contextDir, path)
+		if err != nil {
+			return err
+		}
+		ignored, err := pm.Matches(rel)
+		if err != nil || !ignored {
+			return err
+		}
+
+		for i := len(matchers) - 1; i >= 0; i-- {
+			match, err := matchers[i].Matches(rel)
+			if err != nil {
+				return err
+			}
+			if !match {
+				continue
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			sizes[names[i]] += info.Size()
+			break
+		}
+		return nil
+	})
+	return sizes, err
+}
+
+// formatSize returns a human readable representation of a size in bytes
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// getContextSize returns the size and number of files of a build context not ignored by its '.dockerignore' file.
+// It returns errContextTooLarge as soon as any of the limits is exceeded
+func getContextSize(contextDir string, maxSize int64, maxFiles int) (int64, int, error) {
+	excludes, err := readDockerignore(contextDir)
+	if err != nil {
+		return 0, 0, err
+	}
+	pm, err := fileutils.NewPatternMatcher(excludes)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var size int64
+	files := 0
+	err = filepath.WalkDir(contextDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(contextDir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+
+		ignored, err := pm.Matches(rel)
+		if err != nil {
+			return err
+		}
+		if ignored {
+			if d.IsDir() && !pm.Exclusions() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files++
+		size += info.Size()
+		if files > maxFiles || size > maxSize {
+			return errContextTooLarge
+		}
+		return nil
+	})
+	return size, files, err
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	okErrors "github.com/okteto/okteto/pkg/errors"
+)
+
+// getDockerfileStages returns the names of the named stages of a Dockerfile
+func getDockerfileStages(dockerfile string) ([]string, error) {
+	f, err := os.Open(dockerfile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Dockerfile '%s': %w", dockerfile, err)
+	}
+	defer f.Close()
+
+	ast, err := parser.Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Dockerfile '%s': %w", dockerfile, err)
+	}
+	stages, _, err := instructions.Parse(ast.AST)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Dockerfile '%s': %w", dockerfile, err)
+	}
+
+	result := []string{}
+	for _, stage := range stages {
+		if stage.Name != "" {
+			result = append(result, stage.Name)
+		}
+	}
+	return result, nil
+}
+
+// validateNoCacheFilter returns an error if a stage of the no cache filter is not defined in the Dockerfile
+func validateNoCacheFilter(buildOptions BuildOptions) error {
+	if uri, err := url.ParseRequestURI(buildOptions.Path); err == nil && uri.Scheme != "" && uri.Host != "" {
+		// the Dockerfile of a remote build context can't be inspected
+		return nil
+	}
+	dockerfile := buildOptions.File
+	if dockerfile == "" {
+		dockerfile = filepath.Join(buildOptions.Path, "Dockerfile")
+	}
+	stages, err := getDockerfileStages(dockerfile)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range buildOptions.NoCacheFilter {
+		found := false
+		for _, stage := range stages {
+			if strings.EqualFold(name, stage) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			hint := fmt.Sprintf("'%s' doesn't define any named stage", dockerfile)
+			if len(stages) > 0 {
+				hint = fmt.Sprintf("The stages defined in '%s' are: %s", dockerfile, strings.Join(stages, ", "))
+			}
+			return okErrors.UserError{
+				E:    fmt.Errorf("stage '%s' of '--no-cache-filter' is not defined in '%s'", name, dockerfile),
+				Hint: hint,
+			}
+		}
+	}
+	return nil
+}
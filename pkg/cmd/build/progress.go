@@ -0,0 +1,54 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/containerd/console"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/util/progress/progressui"
+	"github.com/okteto/okteto/pkg/log"
+)
+
+// RawJSONProgress emits the build status events as JSON lines
+const RawJSONProgress = "rawjson"
+
+// displaySolveStatus renders the build status events according to the progress mode
+func displaySolveStatus(progress string, c console.Console, out io.Writer, ch chan *client.SolveStatus) error {
+	if progress == RawJSONProgress {
+		return displayRawJSONStatus(out, ch)
+	}
+	// not using shared context to not disrupt display but let it finish reporting errors
+	return progressui.DisplaySolveStatus(context.TODO(), "", c, out, ch)
+}
+
+// displayRawJSONStatus writes every status event as a JSON line.
+// The channel is always drained so the build is never blocked by a failed write
+func displayRawJSONStatus(out io.Writer, ch chan *client.SolveStatus) error {
+	encoder := json.NewEncoder(out)
+	var result error
+	for s := range ch {
+		if result != nil {
+			continue
+		}
+		if err := encoder.Encode(s); err != nil {
+			log.Infof("failed to write build status: %s", err)
+			result = err
+		}
+	}
+	return result
+}
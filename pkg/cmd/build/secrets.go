@@ -0,0 +1,200 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/secrets"
+	"github.com/moby/buildkit/session/secrets/secretsprovider"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/pkg/errors"
+)
+
+// SecretProvider resolves the value of a build secret when the build requests it
+type SecretProvider interface {
+	GetSecret(ctx context.Context) ([]byte, error)
+}
+
+// CommandSecretProvider exposes the standard output of a local command as a build secret
+type CommandSecretProvider struct {
+	ID      string
+	Command string
+}
+
+// GetSecret runs the command and returns its standard output
+func (p *CommandSecretProvider) GetSecret(ctx context.Context) ([]byte, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", p.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", p.Command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Env = os.Environ()
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	log.Infof("running command for build secret '%s'", p.ID)
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error running the command of build secret '%s': %w: %s", p.ID, err, strings.TrimSpace(stderr.String()))
+	}
+	if stdout.Len() > secretsprovider.MaxSecretSize {
+		return nil, fmt.Errorf("build secret '%s' is too big: max size is %d bytes", p.ID, secretsprovider.MaxSecretSize)
+	}
+	return stdout.Bytes(), nil
+}
+
+// ParseSecretCommand parses a secret command definition with the format 'id=mysecret,cmd=command'
+func ParseSecretCommand(value string) (*CommandSecretProvider, error) {
+	fields, err := csv.NewReader(strings.NewReader(value)).Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret command '%s': %w", value, err)
+	}
+	p := &CommandSecretProvider{}
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid secret command '%s': field '%s' must be a key=value pair", value, field)
+		}
+		switch strings.ToLower(parts[0]) {
+		case "id":
+			p.ID = parts[1]
+		case "cmd":
+			p.Command = parts[1]
+		default:
+			return nil, fmt.Errorf("invalid secret command '%s': unexpected key '%s'", value, parts[0])
+		}
+	}
+	if p.ID == "" {
+		return nil, fmt.Errorf("invalid secret command '%s': 'id' is required", value)
+	}
+	if p.Command == "" {
+		return nil, fmt.Errorf("invalid secret command '%s': 'cmd' is required", value)
+	}
+	return p, nil
+}
+
+// getSecretProviders returns the secret providers defined by the secret commands of the build
+func getSecretProviders(secretCommands []string) (map[string]SecretProvider, error) {
+	providers := map[string]SecretProvider{}
+	for _, value := range secretCommands {
+		p, err := ParseSecretCommand(value)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := providers[p.ID]; ok {
+			return nil, fmt.Errorf("build secret '%s' is defined more than once", p.ID)
+		}
+		providers[p.ID] = p
+	}
+	return providers, nil
+}
+
+// secretStore resolves build secrets from the secret providers and falls back to the file and env secrets
+type secretStore struct {
+	providers map[string]SecretProvider
+	fallback  secrets.SecretStore
+}
+
+func (s *secretStore) GetSecret(ctx context.Context, id string) ([]byte, error) {
+	var value []byte
+	var err error
+	if p, ok := s.providers[id]; ok {
+		value, err = p.GetSecret(ctx)
+	} else if s.fallback != nil {
+		value, err = s.fallback.GetSecret(ctx, id)
+	} else {
+		return nil, errors.WithStack(secrets.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.AddMaskedWord(string(value))
+	return value, nil
+}
+
+// getSecretAttachable returns the session attachable exposing the secrets of the build, or nil if the build has no secrets
+func getSecretAttachable(buildOptions BuildOptions) (session.Attachable, error) {
+	if len(buildOptions.Secrets) == 0 && len(buildOptions.SecretCommands) == 0 {
+		return nil, nil
+	}
+	providers, err := getSecretProviders(buildOptions.SecretCommands)
+	if err != nil {
+		return nil, err
+	}
+	sources := []secretsprovider.Source{}
+	for _, value := range buildOptions.Secrets {
+		source, err := parseSecretSource(value)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := providers[source.ID]; ok {
+			return nil, fmt.Errorf("build secret '%s' is defined more than once", source.ID)
+		}
+		sources = append(sources, source)
+	}
+	store := &secretStore{providers: providers}
+	if len(sources) > 0 {
+		store.fallback, err = secretsprovider.NewStore(sources)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse secrets: %v", buildOptions.Secrets)
+		}
+	}
+	return secretsprovider.NewSecretProvider(store), nil
+}
+
+// parseSecretSource parses a secret definition with the same format as 'docker build --secret'
+func parseSecretSource(value string) (secretsprovider.Source, error) {
+	source := secretsprovider.Source{}
+	fields, err := csv.NewReader(strings.NewReader(value)).Read()
+	if err != nil {
+		return source, errors.Wrap(err, "failed to parse csv secret")
+	}
+	var typ string
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return source, errors.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+		key, value := strings.ToLower(parts[0]), parts[1]
+		switch key {
+		case "type":
+			if value != "file" && value != "env" {
+				return source, errors.Errorf("unsupported secret type %q", value)
+			}
+			typ = value
+		case "id":
+			source.ID = value
+		case "source", "src":
+			source.FilePath = value
+		case "env":
+			source.Env = value
+		default:
+			return source, errors.Errorf("unexpected key '%s' in '%s'", key, field)
+		}
+	}
+	if typ == "env" && source.Env == "" {
+		source.Env = source.FilePath
+		source.FilePath = ""
+	}
+	return source, nil
+}
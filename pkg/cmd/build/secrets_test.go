@@ -0,0 +1,142 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/moby/buildkit/session/secrets"
+	"github.com/moby/buildkit/session/secrets/secretsprovider"
+)
+
+func Test_ParseSecretCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *CommandSecretProvider
+		wantErr bool
+	}{
+		{
+			name:  "ok",
+			value: "id=token,cmd=vault read -field=value secret/token",
+			want:  &CommandSecretProvider{ID: "token", Command: "vault read -field=value secret/token"},
+		},
+		{
+			name:  "quoted-command-with-commas",
+			value: `id=token,"cmd=echo a,b"`,
+			want:  &CommandSecretProvider{ID: "token", Command: "echo a,b"},
+		},
+		{
+			name:    "missing-id",
+			value:   "cmd=echo secret",
+			wantErr: true,
+		},
+		{
+			name:    "missing-cmd",
+			value:   "id=token",
+			wantErr: true,
+		},
+		{
+			name:    "unknown-key",
+			value:   "id=token,src=/tmp/token",
+			wantErr: true,
+		},
+		{
+			name:    "not-key-value",
+			value:   "id=token,cmd",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSecretCommand(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error parsing '%s'", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != *tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getSecretAttachable(t *testing.T) {
+	if _, err := getSecretAttachable(BuildOptions{SecretCommands: []string{"id=a,cmd=echo a", "id=a,cmd=echo b"}}); err == nil {
+		t.Error("expected error for duplicated secret commands")
+	}
+	if _, err := getSecretAttachable(BuildOptions{Secrets: []string{"id=a,env=A"}, SecretCommands: []string{"id=a,cmd=echo a"}}); err == nil {
+		t.Error("expected error for secret defined as command and env")
+	}
+	got, err := getSecretAttachable(BuildOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Error("expected no attachable for a build without secrets")
+	}
+}
+
+func Test_secretStore(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test uses a POSIX shell")
+	}
+	t.Setenv("OKTETO_TEST_SECRET", "from-env")
+	providers, err := getSecretProviders([]string{"id=cmd,cmd=printf from-cmd", "id=fail,cmd=exit 1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := parseSecretSource("id=env,env=OKTETO_TEST_SECRET")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fallback, err := secretsprovider.NewStore([]secretsprovider.Source{source})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &secretStore{providers: providers, fallback: fallback}
+	ctx := context.Background()
+
+	value, err := store.GetSecret(ctx, "cmd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != "from-cmd" {
+		t.Errorf("got '%s', want 'from-cmd'", value)
+	}
+
+	value, err = store.GetSecret(ctx, "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != "from-env" {
+		t.Errorf("got '%s', want 'from-env'", value)
+	}
+
+	if _, err := store.GetSecret(ctx, "fail"); err == nil {
+		t.Error("expected error for a failing secret command")
+	}
+
+	if _, err := store.GetSecret(ctx, "missing"); !errors.Is(err, secrets.ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"k8s.io/client-go/kubernetes"
+)
+
+const checkTimeout = 10 * time.Second
+
+// Check is the result of a diagnostic check of the common failures of 'okteto up'
+type Check struct {
+	Name string
+	Err  error
+	Hint string
+}
+
+// RunChecks runs the diagnostic checks of the common failures of 'okteto up'
+func RunChecks(ctx context.Context, dev *model.Dev, c kubernetes.Interface) []Check {
+	checks := []Check{checkSyncthing()}
+	checks = append(checks, checkForwardPorts(dev)...)
+	checks = append(checks, checkKubernetesAPI(c))
+	if okteto.IsOktetoContext() {
+		checks = append(checks, checkOktetoAPI(ctx))
+		if okteto.Context().Registry != "" {
+			client := &http.Client{Transport: okteto.GetTransport(okteto.Context().Name)}
+			checks = append(checks, checkRegistry(ctx, fmt.Sprintf("https://%s/v2/", okteto.Context().Registry), client))
+		}
+	}
+	return checks
+}
+
+func checkSyncthing() Check {
+	check := Check{Name: "Syncthing is installed"}
+	if !syncthing.IsInstalled() {
+		check.Err = fmt.Errorf("syncthing is not installed")
+		check.Hint = "Run 'okteto up' to install it"
+		return check
+	}
+	if syncthing.ShouldUpgrade() {
+		check.Err = fmt.Errorf("syncthing is older than the minimum version %s", syncthing.GetMinimumVersion().String())
+		check.Hint = "Run 'okteto up' to upgrade it"
+	}
+	return check
+}
+
+// checkForwardPorts checks that the local ports of the 'forward' field are available.
+// The check is skipped if 'okteto up' is running, since the ports are in use by okteto
+func checkForwardPorts(dev *model.Dev) []Check {
+	if _, err := config.GetState(dev); err == nil {
+		log.Infof("'okteto up' is running, skipping the forward ports check")
+		return nil
+	}
+
+	result := []Check{}
+	for _, f := range dev.Forward {
+		if f.IsSocket() {
+			check := Check{Name: fmt.Sprintf("Local socket %s is available", f.Socket)}
+			if err := model.CheckSocketAvailable(f.Socket); err != nil {
+				check.Err = err
+				check.Hint = fmt.Sprintf("Stop the process listening on %s or update the 'forward' field of your okteto manifest", f.Socket)
+			}
+			result = append(result, check)
+			continue
+		}
+		address := f.BindAddress
+		if address == "" {
+			address = dev.Interface
+		}
+		check := Check{Name: fmt.Sprintf("Local port %s:%d is available", address, f.Local)}
+		if !model.IsPortAvailable(address, f.Local) {
+			check.Err = fmt.Errorf("local port %s:%d is already in use", address, f.Local)
+			check.Hint = fmt.Sprintf("Stop the process listening on port %d or update the 'forward' field of your okteto manifest", f.Local)
+		}
+		result = append(result, check)
+	}
+	return result
+}
+
+func checkKubernetesAPI(c kubernetes.Interface) Check {
+	check := Check{Name: "Kubernetes API is reachable"}
+	if _, err := c.Discovery().ServerVersion(); err != nil {
+		check.Err = err
+		check.Hint = "Check your network connection and the credentials of your kubeconfig"
+	}
+	return check
+}
+
+func checkOktetoAPI(ctx context.Context) Check {
+	check := Check{Name: fmt.Sprintf("Okteto API at %s is reachable", okteto.Context().Name)}
+	oktetoClient, err := okteto.NewOktetoClient()
+	if err == nil {
+		ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+		defer cancel()
+		_, err = oktetoClient.ListNamespaces(ctx)
+	}
+	if err != nil {
+		check.Err = err
+		check.Hint = "Check your network connection or run 'okteto context' to log in again"
+	}
+	return check
+}
+
+// checkRegistry checks that the registry answers its API version check. A 401 response is expected without credentials
+func checkRegistry(ctx context.Context, url string, client *http.Client) Check {
+	check := Check{Name: "Okteto Registry is reachable"}
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		check.Err = err
+		return check
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		check.Err = err
+		check.Hint = "Check your network connection and your proxy settings"
+		return check
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusUnauthorized {
+		check.Err = fmt.Errorf("unexpected response from %s: %d", url, resp.StatusCode)
+		check.Hint = "Check your network connection and your proxy settings"
+	}
+	return check
+}
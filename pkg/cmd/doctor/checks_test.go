@@ -0,0 +1,93 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_checkForwardPorts(t *testing.T) {
+	t.Setenv("OKTETO_FOLDER", t.TempDir())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	taken := listener.Addr().(*net.TCPAddr).Port
+
+	free, err := model.GetAvailablePort("127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev := &model.Dev{
+		Name:      "dev",
+		Namespace: "ns",
+		Interface: "127.0.0.1",
+		Forward:   []model.Forward{{Local: free, Remote: 8080}, {Local: taken, Remote: 8081}},
+	}
+
+	checks := checkForwardPorts(dev)
+	if len(checks) != 2 {
+		t.Fatalf("expected 2 checks, got %d", len(checks))
+	}
+	if checks[0].Err != nil {
+		t.Errorf("port %d should be available: %s", free, checks[0].Err)
+	}
+	if checks[1].Err == nil {
+		t.Errorf("port %d should be in use", taken)
+	}
+}
+
+func Test_checkKubernetesAPI(t *testing.T) {
+	if check := checkKubernetesAPI(fake.NewSimpleClientset()); check.Err != nil {
+		t.Fatalf("unexpected error: %s", check.Err)
+	}
+}
+
+func Test_checkRegistry(t *testing.T) {
+	var tests = []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "ok", status: http.StatusOK, wantErr: false},
+		{name: "unauthorized", status: http.StatusUnauthorized, wantErr: false},
+		{name: "bad-gateway", status: http.StatusBadGateway, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			check := checkRegistry(context.Background(), server.URL+"/v2/", server.Client())
+			if tt.wantErr && check.Err == nil {
+				t.Fatal("expected error")
+			}
+			if !tt.wantErr && check.Err != nil {
+				t.Fatalf("unexpected error: %s", check.Err)
+			}
+		})
+	}
+}
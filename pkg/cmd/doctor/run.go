@@ -187,6 +187,12 @@ func generateManifestFile(devPath string) (string, error) {
 		dev.Push.Args = nil
 	}
 
+	for _, b := range dev.Build {
+		if b != nil {
+			b.Args = nil
+		}
+	}
+
 	for i := range dev.Services {
 		dev.Services[i].Environment = nil
 
@@ -0,0 +1,65 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package down
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+// Flush waits until the local and remote folders of the running 'okteto up' converge.
+// It returns an error if there are unresolved conflicts. It's a no-op if the file synchronization isn't running
+func Flush(ctx context.Context, dev *model.Dev) error {
+	sy, err := syncthing.Load(dev)
+	if err != nil {
+		log.Infof("skipping the final synchronization: %s", err)
+		return nil
+	}
+	if !sy.Ping(ctx, true) || !sy.Ping(ctx, false) {
+		log.Infof("skipping the final synchronization: syncthing is not running")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dev.Timeout.Default)
+	defer cancel()
+	reporter := make(chan float64)
+	go func() {
+		for range reporter {
+		}
+	}()
+	if err := sy.WaitForCompletion(ctx, dev, reporter); err != nil {
+		return errors.UserError{
+			E:    fmt.Errorf("failed to synchronize your files before deactivating your development container: %w", err),
+			Hint: "Run 'okteto down --flush=false' to deactivate it without waiting for the file synchronization",
+		}
+	}
+
+	conflicts, err := sy.GetConflicts()
+	if err != nil {
+		return fmt.Errorf("failed to check the synchronization conflicts: %w", err)
+	}
+	if len(conflicts) > 0 {
+		return errors.UserError{
+			E:    fmt.Errorf("there are %d unresolved synchronization conflicts:\n    - %s", len(conflicts), strings.Join(conflicts, "\n    - ")),
+			Hint: "Resolve the conflicts and remove the conflict copies, or run 'okteto down --flush=false' to deactivate your development container anyway",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package down
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+func TestFlushWithoutSyncthing(t *testing.T) {
+	t.Setenv("OKTETO_FOLDER", t.TempDir())
+	dev := &model.Dev{Name: "dev", Namespace: "namespace"}
+
+	if err := Flush(context.Background(), dev); err != nil {
+		t.Fatalf("unexpected error without syncthing info file: %s", err)
+	}
+
+	sy := &syncthing.Syncthing{GUIAddress: "localhost:1", RemoteGUIAddress: "localhost:1"}
+	if err := sy.SaveConfig(dev); err != nil {
+		t.Fatal(err)
+	}
+	if err := Flush(context.Background(), dev); err != nil {
+		t.Fatalf("unexpected error with syncthing not running: %s", err)
+	}
+}
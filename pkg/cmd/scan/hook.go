@@ -0,0 +1,56 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+)
+
+// RunHook runs the scan hook command of the okteto manifest against image, writing its output to out.
+// The image is available to the command in the OKTETO_SCAN_IMAGE, OKTETO_SCAN_TAG and OKTETO_SCAN_DIGEST environment variables.
+// It returns an error if the command exits with a non-zero code
+func RunHook(ctx context.Context, command, image, tag, digest string, env []string, out io.Writer) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Env = append(
+		cmd.Env,
+		fmt.Sprintf("OKTETO_SCAN_IMAGE=%s", image),
+		fmt.Sprintf("OKTETO_SCAN_TAG=%s", tag),
+		fmt.Sprintf("OKTETO_SCAN_DIGEST=%s", digest),
+	)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	log.Infof("running scan hook: %s", command)
+	if err := cmd.Run(); err != nil {
+		return errors.UserError{
+			E:    fmt.Errorf("the scan hook failed for image '%s': %w", image, err),
+			Hint: "Check the output of 'scan.hook' above, fix the reported issues and try again",
+		}
+	}
+	log.Success("The scan hook passed for image '%s'", image)
+	return nil
+}
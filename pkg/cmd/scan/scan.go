@@ -0,0 +1,186 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// DefaultCommand is the scanner invoked when the okteto manifest doesn't define one
+	DefaultCommand = "trivy"
+
+	// DefaultSeverity is the severity that fails a scan when the okteto manifest doesn't define one
+	DefaultSeverity = "CRITICAL"
+
+	// maxReportedVulnerabilities is the number of blocking vulnerabilities listed when a scan fails
+	maxReportedVulnerabilities = 10
+)
+
+var severities = []string{"UNKNOWN", "LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
+// Vulnerability represents a vulnerability found in an image
+type Vulnerability struct {
+	ID       string `json:"VulnerabilityID"`
+	Package  string `json:"PkgName"`
+	Severity string `json:"Severity"`
+}
+
+// Report represents the result of scanning an image
+type Report struct {
+	Image           string
+	Vulnerabilities []Vulnerability
+}
+
+// Scanner scans an image for vulnerabilities
+type Scanner interface {
+	Scan(ctx context.Context, image string) (*Report, error)
+}
+
+// TrivyScanner runs a trivy-compatible command line scanner
+type TrivyScanner struct {
+	Command string
+	Env     []string
+}
+
+type trivyOutput struct {
+	Results []struct {
+		Target          string          `json:"Target"`
+		Vulnerabilities []Vulnerability `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// Scan runs '<command> image --format json --quiet <image>' and parses its output
+func (s *TrivyScanner) Scan(ctx context.Context, image string) (*Report, error) {
+	command := s.Command
+	if command == "" {
+		command = DefaultCommand
+	}
+	if _, err := exec.LookPath(command); err != nil {
+		return nil, errors.UserError{
+			E:    fmt.Errorf("vulnerability scanner '%s' not found", command),
+			Hint: "Install trivy or set 'scan.command' in your okteto manifest to a trivy-compatible scanner",
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, "image", "--format", "json", "--quiet", image)
+	cmd.Env = append(os.Environ(), s.Env...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	log.Infof("running vulnerability scanner: %s", cmd.String())
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error scanning image '%s': %w: %s", image, err, strings.TrimSpace(stderr.String()))
+	}
+	return parseTrivyOutput(image, stdout.Bytes())
+}
+
+func parseTrivyOutput(image string, output []byte) (*Report, error) {
+	out := trivyOutput{}
+	if err := json.Unmarshal(output, &out); err != nil {
+		return nil, fmt.Errorf("error parsing the output of the vulnerability scanner: %w", err)
+	}
+	report := &Report{Image: image}
+	for _, r := range out.Results {
+		report.Vulnerabilities = append(report.Vulnerabilities, r.Vulnerabilities...)
+	}
+	return report, nil
+}
+
+// ValidateSeverity returns an error if severity is not a valid severity threshold
+func ValidateSeverity(severity string) error {
+	if severityLevel(severity) < 0 {
+		return fmt.Errorf("invalid scan severity '%s': must be one of %s", severity, strings.Join(severities, ", "))
+	}
+	return nil
+}
+
+func severityLevel(severity string) int {
+	severity = strings.ToUpper(severity)
+	for i, s := range severities {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+// Summary returns the number of vulnerabilities by severity
+func (r *Report) Summary() map[string]int {
+	result := map[string]int{}
+	for _, v := range r.Vulnerabilities {
+		result[strings.ToUpper(v.Severity)]++
+	}
+	return result
+}
+
+// Blocking returns the vulnerabilities with a severity equal or higher than threshold
+func (r *Report) Blocking(threshold string) []Vulnerability {
+	level := severityLevel(threshold)
+	result := []Vulnerability{}
+	for _, v := range r.Vulnerabilities {
+		if severityLevel(v.Severity) >= level {
+			result = append(result, v)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return severityLevel(result[i].Severity) > severityLevel(result[j].Severity)
+	})
+	return result
+}
+
+// Check prints a summary of report and returns an error if it has vulnerabilities with a severity equal or higher than threshold
+func Check(report *Report, threshold string) error {
+	if err := ValidateSeverity(threshold); err != nil {
+		return err
+	}
+
+	printReport(report)
+
+	blocking := report.Blocking(threshold)
+	if len(blocking) == 0 {
+		log.Success("No vulnerabilities with severity %s or higher found in '%s'", strings.ToUpper(threshold), report.Image)
+		return nil
+	}
+	for i, v := range blocking {
+		if i == maxReportedVulnerabilities {
+			log.Information("... and %d more", len(blocking)-maxReportedVulnerabilities)
+			break
+		}
+		log.Information("%s %s (%s)", v.Severity, v.ID, v.Package)
+	}
+	return errors.UserError{
+		E:    fmt.Errorf("image '%s' has %d vulnerabilities with severity %s or higher", report.Image, len(blocking), strings.ToUpper(threshold)),
+		Hint: "Fix the vulnerabilities or raise the threshold with '--scan-severity'",
+	}
+}
+
+func printReport(report *Report) {
+	summary := report.Summary()
+	counts := []string{}
+	for i := len(severities) - 1; i >= 0; i-- {
+		counts = append(counts, fmt.Sprintf("%s: %d", severities[i], summary[severities[i]]))
+	}
+	log.Information("Vulnerability scan of '%s': %s", report.Image, strings.Join(counts, ", "))
+}
@@ -0,0 +1,158 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"testing"
+)
+
+func Test_parseTrivyOutput(t *testing.T) {
+	output := []byte(`{
+  "Results": [
+    {
+      "Target": "okteto/app (alpine 3.14)",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2021-0001", "PkgName": "openssl", "Severity": "CRITICAL"},
+        {"VulnerabilityID": "CVE-2021-0002", "PkgName": "busybox", "Severity": "LOW"}
+      ]
+    },
+    {
+      "Target": "app/package-lock.json"
+    }
+  ]
+}`)
+	report, err := parseTrivyOutput("okteto/app", output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Vulnerabilities) != 2 {
+		t.Fatalf("expected 2 vulnerabilities, got %d", len(report.Vulnerabilities))
+	}
+	if report.Vulnerabilities[0].ID != "CVE-2021-0001" || report.Vulnerabilities[0].Package != "openssl" {
+		t.Errorf("wrong vulnerability: %+v", report.Vulnerabilities[0])
+	}
+
+	if _, err := parseTrivyOutput("okteto/app", []byte("not json")); err == nil {
+		t.Error("expected error parsing invalid output")
+	}
+}
+
+func Test_Check(t *testing.T) {
+	report := &Report{
+		Image: "okteto/app",
+		Vulnerabilities: []Vulnerability{
+			{ID: "CVE-2021-0001", Severity: "HIGH"},
+			{ID: "CVE-2021-0002", Severity: "LOW"},
+		},
+	}
+	var tests = []struct {
+		name      string
+		threshold string
+		expectErr bool
+	}{
+		{
+			name:      "critical",
+			threshold: "CRITICAL",
+			expectErr: false,
+		},
+		{
+			name:      "high",
+			threshold: "HIGH",
+			expectErr: true,
+		},
+		{
+			name:      "lowercase",
+			threshold: "medium",
+			expectErr: true,
+		},
+		{
+			name:      "invalid",
+			threshold: "SEVERE",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(report, tt.threshold)
+			if tt.expectErr && err == nil {
+				t.Error("expected error")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func Test_Blocking(t *testing.T) {
+	report := &Report{
+		Vulnerabilities: []Vulnerability{
+			{ID: "CVE-1", Severity: "HIGH"},
+			{ID: "CVE-2", Severity: "LOW"},
+			{ID: "CVE-3", Severity: "CRITICAL"},
+		},
+	}
+	blocking := report.Blocking("HIGH")
+	if len(blocking) != 2 {
+		t.Fatalf("expected 2 blocking vulnerabilities, got %d", len(blocking))
+	}
+	if blocking[0].ID != "CVE-3" {
+		t.Errorf("expected critical vulnerabilities first, got %s", blocking[0].ID)
+	}
+}
+
+func Test_RunHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands of this test need a posix shell")
+	}
+
+	var tests = []struct {
+		name           string
+		command        string
+		expectedOutput string
+		expectErr      bool
+	}{
+		{
+			name:           "passed",
+			command:        `echo "scanning $OKTETO_SCAN_IMAGE $OKTETO_SCAN_TAG $OKTETO_SCAN_DIGEST"`,
+			expectedOutput: "scanning okteto/app@sha256:123 okteto/app sha256:123\n",
+		},
+		{
+			name:           "failed",
+			command:        `echo "critical vulnerability found" >&2; exit 1`,
+			expectedOutput: "critical vulnerability found\n",
+			expectErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := RunHook(context.Background(), tt.command, "okteto/app@sha256:123", "okteto/app", "sha256:123", nil, &out)
+			if tt.expectErr && err == nil {
+				t.Fatal("expected error")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if out.String() != tt.expectedOutput {
+				t.Errorf("expected output %q, got %q", tt.expectedOutput, out.String())
+			}
+		})
+	}
+}
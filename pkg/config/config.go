@@ -56,6 +56,9 @@ const (
 	Failed    UpState = "failed"
 	stateFile         = "okteto.state"
 
+	restartSyncFile    = "okteto.restart-sync"
+	restartCommandFile = "okteto.restart-command"
+
 	//OktetoContextVariableName defines the kubeconfig context of okteto commands
 	OktetoContextVariableName = "OKTETO_CONTEXT"
 )
@@ -177,6 +180,59 @@ func GetState(dev *model.Dev) (UpState, error) {
 	return result, nil
 }
 
+// RequestSyncRestart asks the running 'okteto up' of a given dev environment to restart its file synchronization
+func RequestSyncRestart(dev *model.Dev) error {
+	return request(dev, restartSyncFile, "sync restart")
+}
+
+// ConsumeSyncRestartRequest returns true and deletes the request if a sync restart was requested for a given dev environment
+func ConsumeSyncRestartRequest(dev *model.Dev) bool {
+	return consumeRequest(dev, restartSyncFile, "sync restart")
+}
+
+// RequestCommandRestart asks the running 'okteto up' of a given dev environment to restart its development command
+func RequestCommandRestart(dev *model.Dev) error {
+	return request(dev, restartCommandFile, "command restart")
+}
+
+// ConsumeCommandRestartRequest returns true and deletes the request if a command restart was requested for a given dev environment
+func ConsumeCommandRestartRequest(dev *model.Dev) bool {
+	return consumeRequest(dev, restartCommandFile, "command restart")
+}
+
+func request(dev *model.Dev, file, name string) error {
+	if dev.Namespace == "" {
+		return fmt.Errorf("can't request a %s, namespace is empty", name)
+	}
+
+	if dev.Name == "" {
+		return fmt.Errorf("can't request a %s, name is empty", name)
+	}
+
+	s := filepath.Join(GetAppHome(dev.Namespace, dev.Name), file)
+	if err := os.WriteFile(s, []byte{}, 0644); err != nil {
+		return fmt.Errorf("failed to request a %s: %s", name, err)
+	}
+
+	return nil
+}
+
+func consumeRequest(dev *model.Dev, file, name string) bool {
+	if dev.Namespace == "" || dev.Name == "" {
+		return false
+	}
+
+	s := filepath.Join(GetAppHome(dev.Namespace, dev.Name), file)
+	if err := os.Remove(s); err != nil {
+		if !os.IsNotExist(err) {
+			log.Infof("failed to delete %s request: %s", name, err)
+		}
+		return false
+	}
+
+	return true
+}
+
 // GetUserHomeDir returns the OS home dir
 func GetUserHomeDir() string {
 	if v, ok := os.LookupEnv("OKTETO_HOME"); ok {
@@ -219,8 +275,24 @@ func homedirWindows() (string, error) {
 	return home, nil
 }
 
-// GetKubeconfigPath returns the path to the kubeconfig file, taking the KUBECONFIG env var into consideration
+// kubeconfigPath is the kubeconfig file given by the --kubeconfig flag
+var kubeconfigPath string
+
+// SetKubeconfigPath overrides the path to the kubeconfig file, it takes precedence over the KUBECONFIG env var
+func SetKubeconfigPath(path string) {
+	kubeconfigPath = path
+}
+
+// IsKubeconfigPathSet returns true if the path to the kubeconfig file was set with SetKubeconfigPath
+func IsKubeconfigPathSet() bool {
+	return kubeconfigPath != ""
+}
+
+// GetKubeconfigPath returns the path to the kubeconfig file, taking the --kubeconfig flag and the KUBECONFIG env var into consideration
 func GetKubeconfigPath() string {
+	if kubeconfigPath != "" {
+		return kubeconfigPath
+	}
 	home := GetUserHomeDir()
 	kubeconfig := filepath.Join(home, ".kube", "config")
 	kubeconfigEnv := os.Getenv("KUBECONFIG")
@@ -17,6 +17,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
 )
 
 func TestGetUserHomeDir(t *testing.T) {
@@ -145,3 +147,79 @@ func TestGetAppHome(t *testing.T) {
 		t.Errorf("expected %s, got %s", expected, got)
 	}
 }
+
+func TestSyncRestartRequest(t *testing.T) {
+	t.Setenv("OKTETO_FOLDER", t.TempDir())
+	dev := &model.Dev{Name: "dev", Namespace: "ns"}
+
+	if ConsumeSyncRestartRequest(dev) {
+		t.Fatal("consumed a sync restart that wasn't requested")
+	}
+
+	if err := RequestSyncRestart(dev); err != nil {
+		t.Fatal(err)
+	}
+
+	if !ConsumeSyncRestartRequest(dev) {
+		t.Fatal("didn't consume the requested sync restart")
+	}
+
+	if ConsumeSyncRestartRequest(dev) {
+		t.Fatal("consumed the same sync restart twice")
+	}
+
+	if err := RequestSyncRestart(&model.Dev{Name: "dev"}); err == nil {
+		t.Fatal("expected error for an empty namespace")
+	}
+}
+
+func TestCommandRestartRequest(t *testing.T) {
+	t.Setenv("OKTETO_FOLDER", t.TempDir())
+	dev := &model.Dev{Name: "dev", Namespace: "ns"}
+
+	if err := RequestSyncRestart(dev); err != nil {
+		t.Fatal(err)
+	}
+	if ConsumeCommandRestartRequest(dev) {
+		t.Fatal("consumed a sync restart as a command restart")
+	}
+
+	if err := RequestCommandRestart(dev); err != nil {
+		t.Fatal(err)
+	}
+
+	if !ConsumeCommandRestartRequest(dev) {
+		t.Fatal("didn't consume the requested command restart")
+	}
+
+	if ConsumeCommandRestartRequest(dev) {
+		t.Fatal("consumed the same command restart twice")
+	}
+
+	if err := RequestCommandRestart(&model.Dev{Namespace: "ns"}); err == nil {
+		t.Fatal("expected error for an empty name")
+	}
+}
+
+func TestGetKubeconfigPath(t *testing.T) {
+	dir := t.TempDir()
+	envPath := filepath.Join(dir, "env-config")
+	flagPath := filepath.Join(dir, "flag-config")
+	t.Setenv("KUBECONFIG", envPath)
+	defer SetKubeconfigPath("")
+
+	if got := GetKubeconfigPath(); got != envPath {
+		t.Errorf("got %s, want %s", got, envPath)
+	}
+	if IsKubeconfigPathSet() {
+		t.Error("kubeconfig path is set")
+	}
+
+	SetKubeconfigPath(flagPath)
+	if got := GetKubeconfigPath(); got != flagPath {
+		t.Errorf("got %s, want %s", got, flagPath)
+	}
+	if !IsKubeconfigPathSet() {
+		t.Error("kubeconfig path is not set")
+	}
+}
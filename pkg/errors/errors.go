@@ -41,6 +41,43 @@ func (u CommandError) Error() string {
 	return fmt.Sprintf("%s: %s", u.E.Error(), strings.ToLower(u.Reason.Error()))
 }
 
+// Exit codes of the okteto process
+const (
+	// ExitCodeError is the exit code of the errors without a more specific exit code
+	ExitCodeError = 1
+
+	// ExitCodeCommandFailed is the exit code of 'okteto up' when the development command fails
+	ExitCodeCommandFailed = 2
+
+	// ExitCodeConnectionLost is the exit code of 'okteto up' when the connection to the development container is lost
+	ExitCodeConnectionLost = 3
+)
+
+// ExitError is an error that sets the exit code of the okteto process
+type ExitError struct {
+	E    error
+	Code int
+}
+
+// Error returns the error message
+func (e ExitError) Error() string {
+	return e.E.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e ExitError) Unwrap() error {
+	return e.E
+}
+
+// ExitCode returns the exit code of the okteto process for err
+func ExitCode(err error) int {
+	var exitErr ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitCodeError
+}
+
 var (
 	// ErrCommandFailed is raised when the command execution failed
 	ErrCommandFailed = errors.New("command execution failed")
@@ -87,9 +124,15 @@ var (
 	// ErrLostSyncthing is raised when we lose connectivity with syncthing
 	ErrLostSyncthing = fmt.Errorf("synchronization service is disconnected")
 
+	// ErrLostPortForward is raised when a port forward to the development container can't be re-established
+	ErrLostPortForward = fmt.Errorf("port forward to the development container is disconnected")
+
 	// ErrNotInDevMode is raised when the deployment is not in dev mode
 	ErrNotInDevMode = fmt.Errorf("deployment is not in development mode anymore")
 
+	// ErrNamespaceDeleted raised if the namespace is deleted in the middle of the "okteto up" sequence
+	ErrNamespaceDeleted = fmt.Errorf("namespace no longer exists")
+
 	// ErrDevPodDeleted raised if dev pod is deleted in the middle of the "okteto up" sequence
 	ErrDevPodDeleted = fmt.Errorf("development container has been removed")
 
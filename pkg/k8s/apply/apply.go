@@ -0,0 +1,88 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/k8s/client"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest"
+)
+
+const fieldManager = "okteto"
+
+// Files applies the kubernetes manifests of the given files in a namespace, creating or updating their resources
+func Files(ctx context.Context, namespace string, files []string) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	kubeconfigFile, kubeContext, err := okteto.GetK8sKubeconfig()
+	if err != nil {
+		return err
+	}
+	flags := newConfigFlags(kubeconfigFile, kubeContext, namespace)
+
+	r := resource.NewBuilder(flags).
+		Unstructured().
+		ContinueOnError().
+		NamespaceParam(namespace).DefaultNamespace().
+		FilenameParam(false, &resource.FilenameOptions{Filenames: files}).
+		Flatten().
+		Do()
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	force := true
+	return r.Visit(func(info *resource.Info, err error) error {
+		if err != nil {
+			return err
+		}
+		data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, info.Object)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s '%s': %w", info.Mapping.GroupVersionKind.Kind, info.Name, err)
+		}
+
+		helper := resource.NewHelper(info.Client, info.Mapping).WithFieldManager(fieldManager)
+		if _, err := helper.Patch(info.Namespace, info.Name, types.ApplyPatchType, data, &metav1.PatchOptions{Force: &force}); err != nil {
+			return fmt.Errorf("failed to apply %s '%s': %w", info.Mapping.GroupVersionKind.Kind, info.Name, err)
+		}
+		log.Infof("applied %s '%s'", info.Mapping.GroupVersionKind.Kind, info.Name)
+		return nil
+	})
+}
+
+// newConfigFlags returns the client configuration used to apply manifests.
+// Its rest config is configured like the rest of kubernetes clients of the okteto CLI, including the impersonated identity
+func newConfigFlags(kubeconfigFile, kubeContext, namespace string) *genericclioptions.ConfigFlags {
+	flags := genericclioptions.NewConfigFlags(true)
+	flags.KubeConfig = &kubeconfigFile
+	flags.Context = &kubeContext
+	flags.Namespace = &namespace
+	flags.WrapConfigFn = func(cfg *rest.Config) *rest.Config {
+		client.ConfigureRestConfig(cfg)
+		return cfg
+	}
+	return flags
+}
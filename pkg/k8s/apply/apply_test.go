@@ -0,0 +1,62 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/k8s/client"
+)
+
+func Test_newConfigFlagsImpersonation(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	kubeconfigContent := []byte(`apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- cluster:
+    server: https://dev.example.com
+  name: dev
+contexts:
+- context:
+    cluster: dev
+    user: dev
+  name: dev
+users:
+- name: dev
+  user:
+    token: dev-token
+`)
+	if err := os.WriteFile(kubeconfig, kubeconfigContent, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.SetImpersonation("jane", []string{"admins"}); err != nil {
+		t.Fatal(err)
+	}
+	defer client.SetImpersonation("", nil)
+
+	cfg, err := newConfigFlags(kubeconfig, "dev", "test").ToRESTConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "https://dev.example.com" {
+		t.Errorf("got host '%s', want 'https://dev.example.com'", cfg.Host)
+	}
+	if cfg.Impersonate.UserName != "jane" || len(cfg.Impersonate.Groups) != 1 || cfg.Impersonate.Groups[0] != "admins" {
+		t.Errorf("wrong impersonation config: %+v", cfg.Impersonate)
+	}
+}
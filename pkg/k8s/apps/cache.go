@@ -0,0 +1,150 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"sync"
+
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// serviceAppsCache keeps the services fetched by GetTranslations during a command invocation,
+// so computing the translations of the same app again doesn't look them up again.
+// Entries are keyed by the UID of the app and are only valid for the resource version they were computed for,
+// so they are dropped when the app or any of its services is re-fetched, deployed or destroyed after a change
+var serviceAppsCache = &appsCache{entries: map[types.UID]*appsCacheEntry{}}
+
+type appsCache struct {
+	mu      sync.Mutex
+	entries map[types.UID]*appsCacheEntry
+}
+
+type appsCacheEntry struct {
+	resourceVersion string
+	namespace       string
+	services        []string
+	apps            []App
+}
+
+func (ac *appsCache) get(dev *model.Dev, app App) ([]App, bool) {
+	meta := app.ObjectMeta()
+	if meta.UID == "" || meta.ResourceVersion == "" {
+		return nil, false
+	}
+
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	entry, ok := ac.entries[meta.UID]
+	if !ok {
+		return nil, false
+	}
+	if entry.resourceVersion != meta.ResourceVersion {
+		delete(ac.entries, meta.UID)
+		return nil, false
+	}
+	if entry.namespace != dev.Namespace || !sameServices(entry.services, dev) {
+		return nil, false
+	}
+	return copyApps(entry.apps)
+}
+
+func (ac *appsCache) set(dev *model.Dev, app App, apps []App) {
+	meta := app.ObjectMeta()
+	if meta.UID == "" || meta.ResourceVersion == "" {
+		return
+	}
+	copied, ok := copyApps(apps)
+	if !ok {
+		return
+	}
+
+	services := make([]string, 0, len(dev.Services))
+	for _, s := range dev.Services {
+		services = append(services, s.Name)
+	}
+
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.entries[meta.UID] = &appsCacheEntry{
+		resourceVersion: meta.ResourceVersion,
+		namespace:       dev.Namespace,
+		services:        services,
+		apps:            copied,
+	}
+}
+
+// invalidate drops the entries of the app with the given UID, and the entries that include it as a service,
+// unless they were computed for the given resource version. An empty resource version drops all of them
+func (ac *appsCache) invalidate(uid types.UID, resourceVersion string) {
+	if uid == "" {
+		return
+	}
+
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	for key, entry := range ac.entries {
+		if key == uid && entry.resourceVersion != resourceVersion {
+			delete(ac.entries, key)
+			continue
+		}
+		for _, app := range entry.apps {
+			meta := app.ObjectMeta()
+			if meta.UID == uid && meta.ResourceVersion != resourceVersion {
+				delete(ac.entries, key)
+				break
+			}
+		}
+	}
+}
+
+// sameServices returns if the services of a development container are the ones a cache entry was computed for
+func sameServices(services []string, dev *model.Dev) bool {
+	if len(services) != len(dev.Services) {
+		return false
+	}
+	for i, s := range dev.Services {
+		if services[i] != s.Name {
+			return false
+		}
+	}
+	return true
+}
+
+// copyApps deep copies a list of apps, as translations modify them
+func copyApps(apps []App) ([]App, bool) {
+	result := make([]App, 0, len(apps))
+	for _, app := range apps {
+		copied := copyApp(app)
+		if copied == nil {
+			return nil, false
+		}
+		result = append(result, copied)
+	}
+	return result, true
+}
+
+func copyApp(app App) App {
+	switch a := app.(type) {
+	case *DeploymentApp:
+		return NewDeploymentApp(a.d.DeepCopy())
+	case *StatefulSetApp:
+		return NewStatefulSetApp(a.sfs.DeepCopy())
+	case *DaemonSetApp:
+		return NewDaemonSetApp(a.ds.DeepCopy(), a.node)
+	case *CronJobApp:
+		return NewCronJobApp(a.cj.DeepCopy())
+	}
+	return nil
+}
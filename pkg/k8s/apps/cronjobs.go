@@ -0,0 +1,213 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/cronjobs"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+)
+
+// jobNameLabel is the label set by the job controller on the pods of a job
+const jobNameLabel = "job-name"
+
+// CronJobApp develops on the job template of a cronjob.
+// Setting its replicas to 0 suspends its schedule, and its dev clone is a deployment running a single pod of the job template
+type CronJobApp struct {
+	cj *batchv1.CronJob
+}
+
+func NewCronJobApp(cj *batchv1.CronJob) *CronJobApp {
+	return &CronJobApp{cj: cj}
+}
+
+func (i *CronJobApp) TypeMeta() metav1.TypeMeta {
+	return i.cj.TypeMeta
+}
+
+func (i *CronJobApp) ObjectMeta() metav1.ObjectMeta {
+	if i.cj.ObjectMeta.Annotations == nil {
+		i.cj.ObjectMeta.Annotations = map[string]string{}
+	}
+	if i.cj.ObjectMeta.Labels == nil {
+		i.cj.ObjectMeta.Labels = map[string]string{}
+	}
+	return i.cj.ObjectMeta
+}
+
+func (i *CronJobApp) Replicas() int32 {
+	if cronjobs.IsSuspended(i.cj) {
+		return 0
+	}
+	return 1
+}
+
+func (i *CronJobApp) SetReplicas(n int32) {
+	i.cj.Spec.Suspend = pointer.BoolPtr(n == 0)
+}
+
+func (i *CronJobApp) TemplateObjectMeta() metav1.ObjectMeta {
+	template := &i.cj.Spec.JobTemplate.Spec.Template
+	if template.ObjectMeta.Annotations == nil {
+		template.ObjectMeta.Annotations = map[string]string{}
+	}
+	if template.ObjectMeta.Labels == nil {
+		template.ObjectMeta.Labels = map[string]string{}
+	}
+	return template.ObjectMeta
+}
+
+func (i *CronJobApp) PodSpec() *apiv1.PodSpec {
+	return &i.cj.Spec.JobTemplate.Spec.Template.Spec
+}
+
+// DevClone returns a deployment running the job template of the cronjob, so the development container is restarted if it exits.
+// The restart policy and the deadline of the job template are not allowed in deployments
+func (i *CronJobApp) DevClone() App {
+	template := i.cj.Spec.JobTemplate.Spec.Template.DeepCopy()
+	template.Spec.RestartPolicy = apiv1.RestartPolicyAlways
+	template.Spec.ActiveDeadlineSeconds = nil
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	template.Labels[model.DevCloneLabel] = string(i.cj.UID)
+
+	clone := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        model.DevCloneName(i.cj.Name),
+			Namespace:   i.cj.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32Ptr(1),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					model.DevCloneLabel: string(i.cj.UID),
+				},
+			},
+			Template: *template,
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+		},
+	}
+	clone.Labels[model.DevCloneLabel] = string(i.cj.UID)
+	for k, v := range i.cj.Labels {
+		clone.Labels[k] = v
+	}
+	for k, v := range i.cj.Annotations {
+		clone.Annotations[k] = v
+	}
+	return NewDeploymentApp(clone)
+}
+
+func (i *CronJobApp) CheckConditionErrors(dev *model.Dev) error {
+	return nil
+}
+
+// GetRunningPod returns a pod of the jobs currently run by the cronjob
+func (i *CronJobApp) GetRunningPod(ctx context.Context, c kubernetes.Interface) (*apiv1.Pod, error) {
+	for _, job := range i.cj.Status.Active {
+		pod, err := pods.GetBySelector(ctx, i.cj.Namespace, map[string]string{jobNameLabel: job.Name}, c)
+		if err == nil {
+			return pod, nil
+		}
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (i *CronJobApp) IsRolledOut() (bool, error) {
+	return true, nil
+}
+
+func (i *CronJobApp) RestoreOriginal() error {
+	return nil
+}
+
+func (i *CronJobApp) Refresh(ctx context.Context, c kubernetes.Interface) error {
+	cj, err := cronjobs.Get(ctx, i.cj.Name, i.cj.Namespace, c)
+	if err == nil {
+		i.cj = cj
+		serviceAppsCache.invalidate(cj.UID, cj.ResourceVersion)
+	}
+	return err
+}
+
+func (i *CronJobApp) Watch(ctx context.Context, result chan error, c kubernetes.Interface) {
+	optsWatch := metav1.ListOptions{
+		Watch:         true,
+		FieldSelector: fmt.Sprintf("metadata.name=%s", i.cj.Name),
+	}
+
+	watcher, err := c.BatchV1().CronJobs(i.cj.Namespace).Watch(ctx, optsWatch)
+	if err != nil {
+		result <- err
+		return
+	}
+
+	for {
+		select {
+		case e := <-watcher.ResultChan():
+			cj, ok := e.Object.(*batchv1.CronJob)
+			if !ok {
+				watcher, err = c.BatchV1().CronJobs(i.cj.Namespace).Watch(ctx, optsWatch)
+				if err != nil {
+					result <- err
+					return
+				}
+				continue
+			}
+			if cj.Generation != i.cj.Generation {
+				result <- errors.ErrApplyToApp
+				return
+			}
+		case err := <-ctx.Done():
+			log.Debugf("call to up.applyToApp cancelled: %v", err)
+			return
+		}
+	}
+}
+
+func (i *CronJobApp) Deploy(ctx context.Context, c kubernetes.Interface) error {
+	cj, err := cronjobs.Deploy(ctx, i.cj, c)
+	if err == nil {
+		i.cj = cj
+		serviceAppsCache.invalidate(cj.UID, cj.ResourceVersion)
+	}
+	return err
+}
+
+func (i *CronJobApp) Destroy(ctx context.Context, c kubernetes.Interface) error {
+	serviceAppsCache.invalidate(i.cj.UID, "")
+	return cronjobs.Destroy(ctx, i.cj.Name, i.cj.Namespace, c)
+}
+
+func (i *CronJobApp) Divert(username string) App {
+	return &CronJobApp{cj: cronjobs.TranslateDivert(username, i.cj)}
+}
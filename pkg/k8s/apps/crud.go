@@ -16,10 +16,14 @@ package apps
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/cronjobs"
+	"github.com/okteto/okteto/pkg/k8s/daemonsets"
 	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/pods"
 	"github.com/okteto/okteto/pkg/k8s/statefulsets"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
@@ -28,6 +32,16 @@ import (
 )
 
 func Get(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Interface) (App, error) {
+	app, err := get(ctx, dev, namespace, c)
+	if err != nil {
+		return nil, err
+	}
+	meta := app.ObjectMeta()
+	serviceAppsCache.invalidate(meta.UID, meta.ResourceVersion)
+	return app, nil
+}
+
+func get(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Interface) (App, error) {
 	d, err := deployments.GetByDev(ctx, dev, namespace, c)
 
 	if err == nil {
@@ -39,13 +53,35 @@ func Get(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Int
 	}
 
 	sfs, err := statefulsets.GetByDev(ctx, dev, namespace, c)
+	if err == nil {
+		return &StatefulSetApp{sfs: sfs}, nil
+	}
+
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	ds, err := daemonsets.GetByDev(ctx, dev, namespace, c)
+	if err == nil {
+		node, err := daemonsets.GetDevNode(ctx, ds, dev.Node, c)
+		if err != nil {
+			return nil, err
+		}
+		return NewDaemonSetApp(ds, node), nil
+	}
+
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	cj, err := cronjobs.GetByDev(ctx, dev, namespace, c)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return nil, fmt.Errorf("the application '%s' referred by your okteto manifest doesn't exist", dev.Name)
 		}
 		return nil, err
 	}
-	return &StatefulSetApp{sfs: sfs}, nil
+	return NewCronJobApp(cj), nil
 }
 
 //IsDevModeOn returns if a statefulset is in devmode
@@ -53,9 +89,80 @@ func IsDevModeOn(app App) bool {
 	return app.ObjectMeta().Labels[model.DevLabel] == "true"
 }
 
-//SetLastBuiltAnnotation sets the app timestamp
-func SetLastBuiltAnnotation(app App) {
+// ListDevModeOn returns the deployments, statefulsets, daemonsets and cronjobs of a namespace in dev mode, skipping their dev clones
+func ListDevModeOn(ctx context.Context, namespace string, c kubernetes.Interface) ([]App, error) {
+	selector := fmt.Sprintf("%s=true", model.DevLabel)
+	candidates := []App{}
+	dList, err := deployments.List(ctx, namespace, selector, c)
+	if err != nil {
+		return nil, err
+	}
+	for i := range dList {
+		candidates = append(candidates, NewDeploymentApp(&dList[i]))
+	}
+	sfsList, err := statefulsets.List(ctx, namespace, selector, c)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sfsList {
+		candidates = append(candidates, NewStatefulSetApp(&sfsList[i]))
+	}
+	dsList, err := daemonsets.List(ctx, namespace, selector, c)
+	if err != nil {
+		return nil, err
+	}
+	for i := range dsList {
+		candidates = append(candidates, NewDaemonSetApp(&dsList[i], dsList[i].Annotations[model.DevNodeAnnotation]))
+	}
+	cjList, err := cronjobs.List(ctx, namespace, selector, c)
+	if err != nil {
+		return nil, err
+	}
+	for i := range cjList {
+		candidates = append(candidates, NewCronJobApp(&cjList[i]))
+	}
+
+	clones := map[string]bool{}
+	for _, app := range candidates {
+		clones[fmt.Sprintf("%T/%s", app, model.DevCloneName(app.ObjectMeta().Name))] = true
+	}
+	result := []App{}
+	for _, app := range candidates {
+		if _, ok := app.ObjectMeta().Labels[model.DevCloneLabel]; ok {
+			continue
+		}
+		if clones[fmt.Sprintf("%T/%s", app, app.ObjectMeta().Name)] {
+			continue
+		}
+		result = append(result, app)
+	}
+	return result, nil
+}
+
+// GetDevName returns the name of the development container of an app in dev mode.
+// It defaults to the name of the app if its dev clone doesn't exist
+func GetDevName(ctx context.Context, app App, c kubernetes.Interface) string {
+	clone := app.DevClone()
+	if err := clone.Refresh(ctx, c); err != nil {
+		log.Infof("failed to get the dev clone of '%s': %s", app.ObjectMeta().Name, err)
+		return app.ObjectMeta().Name
+	}
+	labels := clone.TemplateObjectMeta().Labels
+	if name := labels[model.InteractiveDevLabel]; name != "" {
+		return name
+	}
+	if name := labels[model.DetachedDevLabel]; name != "" {
+		return name
+	}
+	return app.ObjectMeta().Name
+}
+
+//SetLastBuiltAnnotation sets the app timestamp and, if known, the digest of the built image
+func SetLastBuiltAnnotation(app App, digest string) {
 	app.ObjectMeta().Annotations[model.LastBuiltAnnotation] = time.Now().UTC().Format(model.TimeFormat)
+	if digest != "" {
+		app.ObjectMeta().Annotations[model.LastBuiltDigestAnnotation] = digest
+	}
 }
 
 // GetRunningPodInLoop returns the dev pod for an app and loops until it success
@@ -100,6 +207,53 @@ func GetRunningPodInLoop(ctx context.Context, dev *model.Dev, app App, c kuberne
 	}
 }
 
+// WaitUntilRolledOut waits until the latest revision of an app is ready, failing if its pods are crashing
+func WaitUntilRolledOut(ctx context.Context, dev *model.Dev, app App, timeout time.Duration, c kubernetes.Interface) error {
+	ticker := time.NewTicker(1 * time.Second)
+	to := time.Now().Add(timeout)
+
+	for retries := 0; ; retries++ {
+		if err := app.Refresh(ctx, c); err != nil {
+			return err
+		}
+		if err := app.CheckConditionErrors(dev); err != nil {
+			return err
+		}
+
+		rolledOut, err := app.IsRolledOut()
+		if err != nil {
+			return err
+		}
+		if rolledOut {
+			return nil
+		}
+
+		pod, err := app.GetRunningPod(ctx, c)
+		if err == nil {
+			if err := pods.CheckCrashLoopBackOff(pod); err != nil {
+				return err
+			}
+		} else if !errors.IsNotFound(err) {
+			return err
+		}
+
+		if timeout > 0 && time.Now().After(to) {
+			return fmt.Errorf("%s '%s' was not ready after %s", strings.ToLower(app.TypeMeta().Kind), app.ObjectMeta().Name, timeout.String())
+		}
+
+		select {
+		case <-ticker.C:
+			if retries%5 == 0 {
+				log.Infof("%s '%s' is not rolled out yet, will retry", app.TypeMeta().Kind, app.ObjectMeta().Name)
+			}
+			continue
+		case <-ctx.Done():
+			log.Debug("call to apps.WaitUntilRolledOut cancelled")
+			return ctx.Err()
+		}
+	}
+}
+
 //GetTranslations fills all the deployments pointed by a development container
 func GetTranslations(ctx context.Context, dev *model.Dev, app App, reset bool, c kubernetes.Interface) (map[string]*Translation, error) {
 	mainTr := &Translation{
@@ -110,7 +264,7 @@ func GetTranslations(ctx context.Context, dev *model.Dev, app App, reset bool, c
 	}
 	result := map[string]*Translation{app.ObjectMeta().Name: mainTr}
 
-	if err := loadServiceTranslations(ctx, dev, reset, result, c); err != nil {
+	if err := loadServiceTranslations(ctx, dev, app, reset, result, c); err != nil {
 		return nil, err
 	}
 
@@ -130,13 +284,14 @@ func GetTranslations(ctx context.Context, dev *model.Dev, app App, reset bool, c
 	return result, nil
 }
 
-func loadServiceTranslations(ctx context.Context, dev *model.Dev, reset bool, result map[string]*Translation, c kubernetes.Interface) error {
-	for _, s := range dev.Services {
-		app, err := Get(ctx, s, dev.Namespace, c)
-		if err != nil {
-			return err
-		}
+func loadServiceTranslations(ctx context.Context, dev *model.Dev, app App, reset bool, result map[string]*Translation, c kubernetes.Interface) error {
+	serviceApps, err := getServiceApps(ctx, dev, app, c)
+	if err != nil {
+		return err
+	}
 
+	for i, s := range dev.Services {
+		app := serviceApps[i]
 		rule := s.ToTranslationRule(dev, reset)
 
 		if _, ok := result[app.ObjectMeta().Name]; ok {
@@ -155,6 +310,24 @@ func loadServiceTranslations(ctx context.Context, dev *model.Dev, reset bool, re
 	return nil
 }
 
+// getServiceApps returns the apps of the services of a development container, in the same order as dev.Services
+func getServiceApps(ctx context.Context, dev *model.Dev, app App, c kubernetes.Interface) ([]App, error) {
+	if serviceApps, ok := serviceAppsCache.get(dev, app); ok {
+		return serviceApps, nil
+	}
+
+	serviceApps := []App{}
+	for _, s := range dev.Services {
+		serviceApp, err := Get(ctx, s, dev.Namespace, c)
+		if err != nil {
+			return nil, err
+		}
+		serviceApps = append(serviceApps, serviceApp)
+	}
+	serviceAppsCache.set(dev, app, serviceApps)
+	return serviceApps, nil
+}
+
 //TranslateDevMode translates the deployment manifests to put them in dev mode
 func TranslateDevMode(trMap map[string]*Translation) error {
 	for _, tr := range trMap {
@@ -21,9 +21,12 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
 )
 
 func TestMain(m *testing.M) {
@@ -130,6 +133,106 @@ func TestGetDeployment(t *testing.T) {
 	}
 }
 
+func TestGetDaemonSet(t *testing.T) {
+	ctx := context.Background()
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "agent",
+			Namespace: "test",
+			UID:       "ds-uid",
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}},
+		},
+	}
+	pod := func(name, node string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "test",
+				Labels:          map[string]string{"app": "agent"},
+				OwnerReferences: []metav1.OwnerReference{{UID: "ds-uid"}},
+			},
+			Spec: v1.PodSpec{NodeName: node},
+		}
+	}
+
+	var tests = []struct {
+		name        string
+		node        string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "default-node",
+			expected: "node-a",
+		},
+		{
+			name:     "selected-node",
+			node:     "node-b",
+			expected: "node-b",
+		},
+		{
+			name:        "node-without-pods",
+			node:        "node-c",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := pod("other", "node-0")
+			other.Labels = map[string]string{"app": "other"}
+			clientset := fake.NewSimpleClientset(ds, pod("agent-x", "node-b"), pod("agent-y", "node-a"), other)
+			dev := &model.Dev{Name: "agent", Namespace: "test", Node: tt.node}
+			app, err := Get(ctx, dev, "test", clientset)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			dsApp, ok := app.(*DaemonSetApp)
+			if !ok {
+				t.Fatalf("expected a daemonset app, got %T", app)
+			}
+			if dsApp.Node() != tt.expected {
+				t.Fatalf("expected node '%s', got '%s'", tt.expected, dsApp.Node())
+			}
+		})
+	}
+}
+
+func TestGetCronJob(t *testing.T) {
+	ctx := context.Background()
+	cj := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "report",
+			Namespace: "test",
+		},
+	}
+
+	clientset := fake.NewSimpleClientset(cj)
+
+	dev := &model.Dev{
+		Name:      "report",
+		Namespace: "test",
+	}
+	app, err := Get(ctx, dev, "test", clientset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := app.(*CronJobApp); !ok {
+		t.Fatalf("expected a cronjob app, got %T", app)
+	}
+	if app.ObjectMeta().Name != "report" {
+		t.Fatal("not retrieved correctly")
+	}
+}
+
 func TestValidateMountPaths(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -266,3 +369,138 @@ func TestValidateMountPaths(t *testing.T) {
 	}
 
 }
+
+func TestListDevModeOn(t *testing.T) {
+	devLabels := map[string]string{model.DevLabel: "true"}
+	c := fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test", Labels: devLabels}},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "api-okteto",
+				Namespace: "test",
+				Labels:    map[string]string{model.DevLabel: "true", model.DevCloneLabel: "uid"},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{model.InteractiveDevLabel: "backend"}},
+				},
+			},
+		},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "sandbox", Namespace: "test", Labels: devLabels}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "sandbox-okteto", Namespace: "test", Labels: devLabels}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other", Labels: devLabels}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "test", Labels: devLabels}},
+	)
+
+	ctx := context.Background()
+	result, err := ListDevModeOn(ctx, "test", c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{"api": "backend", "sandbox": "sandbox", "db": "db"}
+	if len(result) != len(expected) {
+		t.Fatalf("expected %d apps, got %d", len(expected), len(result))
+	}
+	for _, app := range result {
+		name, ok := expected[app.ObjectMeta().Name]
+		if !ok {
+			t.Fatalf("unexpected app '%s'", app.ObjectMeta().Name)
+		}
+		if got := GetDevName(ctx, app, c); got != name {
+			t.Errorf("expected dev name '%s' for '%s', got '%s'", name, app.ObjectMeta().Name, got)
+		}
+	}
+}
+
+func TestGetTranslationsCache(t *testing.T) {
+	ctx := context.Background()
+	deployment := func(name, uid string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "test",
+				UID:             types.UID(uid),
+				ResourceVersion: "1",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: pointer.Int32Ptr(1),
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{{Name: name, Image: name}},
+					},
+				},
+			},
+		}
+	}
+	clientset := fake.NewSimpleClientset(deployment("worker", "worker-uid"))
+	dev := &model.Dev{
+		Name:      "api",
+		Namespace: "test",
+		Image:     &model.BuildInfo{},
+		Services: []*model.Dev{
+			{Name: "worker", Namespace: "test", Image: &model.BuildInfo{}},
+		},
+	}
+	app := NewDeploymentApp(deployment("api", "api-uid"))
+
+	trMap, err := GetTranslations(ctx, dev, app, false, clientset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := len(clientset.Actions())
+	if calls == 0 {
+		t.Fatal("services not fetched")
+	}
+	trMap["worker"].App.SetReplicas(0)
+
+	trMap, err = GetTranslations(ctx, dev, app, false, clientset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clientset.Actions()) != calls {
+		t.Fatalf("services fetched again: %v", clientset.Actions()[calls:])
+	}
+	if trMap["worker"].App.Replicas() == 0 {
+		t.Fatal("cached service modified by a previous translation")
+	}
+	if trMap["api"].App != app {
+		t.Fatal("main translation doesn't use the given app")
+	}
+
+	calls = len(clientset.Actions())
+	app.d.ResourceVersion = "2"
+	if _, err := GetTranslations(ctx, dev, app, false, clientset); err != nil {
+		t.Fatal(err)
+	}
+	if len(clientset.Actions()) == calls {
+		t.Fatal("services not fetched after the app changed")
+	}
+
+	calls = len(clientset.Actions())
+	reloaded := *dev
+	if _, err := GetTranslations(ctx, &reloaded, NewDeploymentApp(app.d.DeepCopy()), false, clientset); err != nil {
+		t.Fatal(err)
+	}
+	if len(clientset.Actions()) != calls {
+		t.Fatalf("services fetched again for the same app version: %v", clientset.Actions()[calls:])
+	}
+
+	worker := deployment("worker", "worker-uid")
+	worker.ResourceVersion = "2"
+	worker.Spec.Template.Spec.Containers[0].Image = "worker:2"
+	if _, err := clientset.AppsV1().Deployments("test").Update(ctx, worker, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewDeploymentApp(deployment("worker", "worker-uid")).Refresh(ctx, clientset); err != nil {
+		t.Fatal(err)
+	}
+	trMap, err = GetTranslations(ctx, dev, app, false, clientset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image := trMap["worker"].App.PodSpec().Containers[0].Image; image != "worker:2" {
+		t.Fatalf("stale service after it changed: got image '%s'", image)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/daemonsets"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DaemonSetApp develops on the pod of a daemonset running on a single node.
+// Setting its replicas to 0 excludes the node from the daemonset, and its dev clone only runs on that node
+type DaemonSetApp struct {
+	ds   *appsv1.DaemonSet
+	node string
+}
+
+func NewDaemonSetApp(ds *appsv1.DaemonSet, node string) *DaemonSetApp {
+	return &DaemonSetApp{ds: ds, node: node}
+}
+
+func (i *DaemonSetApp) TypeMeta() metav1.TypeMeta {
+	return i.ds.TypeMeta
+}
+
+func (i *DaemonSetApp) ObjectMeta() metav1.ObjectMeta {
+	if i.ds.ObjectMeta.Annotations == nil {
+		i.ds.ObjectMeta.Annotations = map[string]string{}
+	}
+	if i.ds.ObjectMeta.Labels == nil {
+		i.ds.ObjectMeta.Labels = map[string]string{}
+	}
+	return i.ds.ObjectMeta
+}
+
+// Node returns the node where the development container runs
+func (i *DaemonSetApp) Node() string {
+	return i.node
+}
+
+func (i *DaemonSetApp) Replicas() int32 {
+	if i.ds.Annotations[model.DevNodeAnnotation] != "" {
+		return 0
+	}
+	return 1
+}
+
+func (i *DaemonSetApp) SetReplicas(n int32) {
+	if n > 0 {
+		if node := i.ObjectMeta().Annotations[model.DevNodeAnnotation]; node != "" {
+			daemonsets.IncludeNode(i.ds, node)
+			delete(i.ds.Annotations, model.DevNodeAnnotation)
+		}
+		return
+	}
+	if i.node == "" {
+		log.Infof("daemonset '%s' doesn't have a dev node", i.ds.Name)
+		return
+	}
+	if node := i.ObjectMeta().Annotations[model.DevNodeAnnotation]; node != "" && node != i.node {
+		log.Infof("moving the dev node of daemonset '%s' from '%s' to '%s'", i.ds.Name, node, i.node)
+		daemonsets.IncludeNode(i.ds, node)
+	}
+	daemonsets.ExcludeNode(i.ds, i.node)
+	i.ObjectMeta().Annotations[model.DevNodeAnnotation] = i.node
+}
+
+func (i *DaemonSetApp) TemplateObjectMeta() metav1.ObjectMeta {
+	if i.ds.Spec.Template.ObjectMeta.Annotations == nil {
+		i.ds.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+	}
+	if i.ds.Spec.Template.ObjectMeta.Labels == nil {
+		i.ds.Spec.Template.ObjectMeta.Labels = map[string]string{}
+	}
+	return i.ds.Spec.Template.ObjectMeta
+}
+
+func (i *DaemonSetApp) PodSpec() *apiv1.PodSpec {
+	return &i.ds.Spec.Template.Spec
+}
+
+func (i *DaemonSetApp) DevClone() App {
+	clone := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        model.DevCloneName(i.ds.Name),
+			Namespace:   i.ds.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: *i.ds.Spec.DeepCopy(),
+	}
+	clone.Labels[model.DevCloneLabel] = string(i.ds.UID)
+	for k, v := range i.ds.Labels {
+		clone.Labels[k] = v
+	}
+	for k, v := range i.ds.Annotations {
+		clone.Annotations[k] = v
+	}
+	delete(clone.Annotations, model.DevNodeAnnotation)
+	return NewDaemonSetApp(clone, i.node)
+}
+
+// PinNode restricts the pods of the daemonset to its dev node.
+// It must be called after translating the pod spec of the dev clone, as the user affinity replaces the pod affinity
+func (i *DaemonSetApp) PinNode() {
+	if i.node == "" {
+		return
+	}
+	daemonsets.PinNode(i.ds, i.node)
+}
+
+func (i *DaemonSetApp) CheckConditionErrors(dev *model.Dev) error {
+	return daemonsets.CheckConditionErrors(i.ds)
+}
+
+func (i *DaemonSetApp) GetRunningPod(ctx context.Context, c kubernetes.Interface) (*apiv1.Pod, error) {
+	if i.ds.Generation != i.ds.Status.ObservedGeneration {
+		return nil, errors.ErrNotFound
+	}
+	return pods.GetPodByDaemonSet(ctx, i.ds, i.node, c)
+}
+
+func (i *DaemonSetApp) IsRolledOut() (bool, error) {
+	return daemonsets.IsRolledOut(i.ds), nil
+}
+
+func (i *DaemonSetApp) RestoreOriginal() error {
+	return nil
+}
+
+func (i *DaemonSetApp) Refresh(ctx context.Context, c kubernetes.Interface) error {
+	ds, err := daemonsets.Get(ctx, i.ds.Name, i.ds.Namespace, c)
+	if err == nil {
+		i.ds = ds
+		serviceAppsCache.invalidate(ds.UID, ds.ResourceVersion)
+	}
+	return err
+}
+
+func (i *DaemonSetApp) Watch(ctx context.Context, result chan error, c kubernetes.Interface) {
+	optsWatch := metav1.ListOptions{
+		Watch:         true,
+		FieldSelector: fmt.Sprintf("metadata.name=%s", i.ds.Name),
+	}
+
+	watcher, err := c.AppsV1().DaemonSets(i.ds.Namespace).Watch(ctx, optsWatch)
+	if err != nil {
+		result <- err
+		return
+	}
+
+	for {
+		select {
+		case e := <-watcher.ResultChan():
+			ds, ok := e.Object.(*appsv1.DaemonSet)
+			if !ok {
+				watcher, err = c.AppsV1().DaemonSets(i.ds.Namespace).Watch(ctx, optsWatch)
+				if err != nil {
+					result <- err
+					return
+				}
+				continue
+			}
+			if ds.Generation != i.ds.Generation {
+				result <- errors.ErrApplyToApp
+				return
+			}
+		case err := <-ctx.Done():
+			log.Debugf("call to up.applyToApp cancelled: %v", err)
+			return
+		}
+	}
+}
+
+func (i *DaemonSetApp) Deploy(ctx context.Context, c kubernetes.Interface) error {
+	ds, err := daemonsets.Deploy(ctx, i.ds, c)
+	if err == nil {
+		i.ds = ds
+		serviceAppsCache.invalidate(ds.UID, ds.ResourceVersion)
+	}
+	return err
+}
+
+func (i *DaemonSetApp) Destroy(ctx context.Context, c kubernetes.Interface) error {
+	serviceAppsCache.invalidate(i.ds.UID, "")
+	return daemonsets.Destroy(ctx, i.ds.Name, i.ds.Namespace, c)
+}
+
+func (i *DaemonSetApp) Divert(username string) App {
+	return &DaemonSetApp{ds: daemonsets.TranslateDivert(username, i.ds), node: i.node}
+}
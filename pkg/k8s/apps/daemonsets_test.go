@@ -0,0 +1,100 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDaemonSetAppSetReplicas(t *testing.T) {
+	var tests = []struct {
+		name         string
+		current      string
+		node         string
+		replicas     int32
+		expectedNode string
+		excluded     []string
+	}{
+		{
+			name:         "activate",
+			node:         "node-a",
+			replicas:     0,
+			expectedNode: "node-a",
+			excluded:     []string{"node-a"},
+		},
+		{
+			name:         "same-node",
+			current:      "node-a",
+			node:         "node-a",
+			replicas:     0,
+			expectedNode: "node-a",
+			excluded:     []string{"node-a"},
+		},
+		{
+			name:         "move-node",
+			current:      "node-a",
+			node:         "node-b",
+			replicas:     0,
+			expectedNode: "node-b",
+			excluded:     []string{"node-b"},
+		},
+		{
+			name:     "deactivate",
+			current:  "node-a",
+			node:     "node-a",
+			replicas: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "test"}}
+			if tt.current != "" {
+				NewDaemonSetApp(ds, tt.current).SetReplicas(0)
+			}
+
+			app := NewDaemonSetApp(ds, tt.node)
+			app.SetReplicas(tt.replicas)
+
+			if node := ds.Annotations[model.DevNodeAnnotation]; node != tt.expectedNode {
+				t.Errorf("expected dev node '%s', got '%s'", tt.expectedNode, node)
+			}
+			if excluded := getExcludedNodes(ds); !reflect.DeepEqual(excluded, tt.excluded) {
+				t.Errorf("expected excluded nodes %v, got %v", tt.excluded, excluded)
+			}
+		})
+	}
+}
+
+func getExcludedNodes(ds *appsv1.DaemonSet) []string {
+	affinity := ds.Spec.Template.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return nil
+	}
+	var result []string
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, f := range term.MatchFields {
+			if f.Operator == apiv1.NodeSelectorOpNotIn {
+				result = append(result, f.Values...)
+			}
+		}
+	}
+	return result
+}
@@ -104,6 +104,13 @@ func (i *DeploymentApp) DevClone() App {
 	return NewDeploymentApp(clone)
 }
 
+// SetStrategy sets the update strategy of the deployment and returns the previous one
+func (i *DeploymentApp) SetStrategy(strategy appsv1.DeploymentStrategy) appsv1.DeploymentStrategy {
+	previous := i.d.Spec.Strategy
+	i.d.Spec.Strategy = strategy
+	return previous
+}
+
 func (i *DeploymentApp) CheckConditionErrors(dev *model.Dev) error {
 	return deployments.CheckConditionErrors(i.d, dev)
 }
@@ -116,6 +123,10 @@ func (i *DeploymentApp) GetRunningPod(ctx context.Context, c kubernetes.Interfac
 	return pods.GetPodByReplicaSet(ctx, rs, c)
 }
 
+func (i *DeploymentApp) IsRolledOut() (bool, error) {
+	return deployments.IsRolledOut(i.d)
+}
+
 func (i *DeploymentApp) RestoreOriginal() error {
 	manifest := i.d.Annotations[model.DeploymentAnnotation]
 	if manifest == "" {
@@ -134,6 +145,7 @@ func (i *DeploymentApp) Refresh(ctx context.Context, c kubernetes.Interface) err
 	d, err := deployments.Get(ctx, i.d.Name, i.d.Namespace, c)
 	if err == nil {
 		i.d = d
+		serviceAppsCache.invalidate(d.UID, d.ResourceVersion)
 	}
 	return err
 }
@@ -187,11 +199,13 @@ func (i *DeploymentApp) Deploy(ctx context.Context, c kubernetes.Interface) erro
 	d, err := deployments.Deploy(ctx, i.d, c)
 	if err == nil {
 		i.d = d
+		serviceAppsCache.invalidate(d.UID, d.ResourceVersion)
 	}
 	return err
 }
 
 func (i *DeploymentApp) Destroy(ctx context.Context, c kubernetes.Interface) error {
+	serviceAppsCache.invalidate(i.d.UID, "")
 	return deployments.Destroy(ctx, i.d.Name, i.d.Namespace, c)
 }
 
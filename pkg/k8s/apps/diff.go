@@ -0,0 +1,65 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	apiv1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// appSpec represents the fields of an app that are updated by okteto
+type appSpec struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Template    templateSpec      `json:"template"`
+}
+
+type templateSpec struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Spec        *apiv1.PodSpec    `json:"spec"`
+}
+
+// SerializeSpec returns the labels, annotations and pod template of an app in YAML format
+func SerializeSpec(app App) (string, error) {
+	spec := appSpec{
+		Labels:      app.ObjectMeta().Labels,
+		Annotations: app.ObjectMeta().Annotations,
+		Template: templateSpec{
+			Labels:      app.TemplateObjectMeta().Labels,
+			Annotations: app.TemplateObjectMeta().Annotations,
+			Spec:        app.PodSpec(),
+		},
+	}
+	bytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize %s '%s': %w", strings.ToLower(app.TypeMeta().Kind), app.ObjectMeta().Name, err)
+	}
+	return string(bytes), nil
+}
+
+// GetSpecDiff returns the unified diff between two serialized specs of an app, or an empty string if they are equal
+func GetSpecDiff(name, before, after string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(before),
+		B:        difflib.SplitLines(after),
+		FromFile: fmt.Sprintf("%s (current)", name),
+		ToFile:   fmt.Sprintf("%s (pushed)", name),
+		Context:  3,
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_GetSpecDiff(t *testing.T) {
+	app := NewDeploymentApp(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "api",
+			Annotations: map[string]string{"owner": "team"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{{Name: "api", Image: "okteto/api:1"}},
+				},
+			},
+		},
+	})
+
+	before, err := SerializeSpec(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := GetSpecDiff("api", before, before)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("expected no diff, got:\n%s", diff)
+	}
+
+	app.PodSpec().Containers[0].Image = "okteto/api:2"
+	after, err := SerializeSpec(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err = GetSpecDiff("api", before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "-    - image: okteto/api:1\n") || !strings.Contains(diff, "+    - image: okteto/api:2\n") {
+		t.Errorf("the image change is not in the diff:\n%s", diff)
+	}
+	if strings.Contains(diff, "owner: team") {
+		t.Errorf("unchanged annotations far from the change are in the diff:\n%s", diff)
+	}
+}
@@ -105,6 +105,10 @@ func (i *StatefulSetApp) GetRunningPod(ctx context.Context, c kubernetes.Interfa
 	return pods.GetPodByStatefulSet(ctx, i.sfs, c)
 }
 
+func (i *StatefulSetApp) IsRolledOut() (bool, error) {
+	return statefulsets.IsRolledOut(i.sfs), nil
+}
+
 func (i *StatefulSetApp) RestoreOriginal() error {
 	manifest := i.sfs.Annotations[model.StatefulsetAnnotation]
 	if manifest == "" {
@@ -123,6 +127,7 @@ func (i *StatefulSetApp) Refresh(ctx context.Context, c kubernetes.Interface) er
 	sfs, err := statefulsets.Get(ctx, i.sfs.Name, i.sfs.Namespace, c)
 	if err == nil {
 		i.sfs = sfs
+		serviceAppsCache.invalidate(sfs.UID, sfs.ResourceVersion)
 	}
 	return err
 }
@@ -166,11 +171,13 @@ func (i *StatefulSetApp) Deploy(ctx context.Context, c kubernetes.Interface) err
 	sfs, err := statefulsets.Deploy(ctx, i.sfs, c)
 	if err == nil {
 		i.sfs = sfs
+		serviceAppsCache.invalidate(sfs.UID, sfs.ResourceVersion)
 	}
 	return err
 }
 
 func (i *StatefulSetApp) Destroy(ctx context.Context, c kubernetes.Interface) error {
+	serviceAppsCache.invalidate(i.sfs.UID, "")
 	return statefulsets.Destroy(ctx, i.sfs.Name, i.sfs.Namespace, c)
 }
 
@@ -89,6 +89,10 @@ func (tr *Translation) translate() error {
 			TranslateOktetoBinVolume(tr.DevApp.PodSpec())
 		}
 	}
+
+	if ds, ok := tr.DevApp.(*DaemonSetApp); ok {
+		ds.PinNode()
+	}
 	return nil
 }
 
@@ -170,9 +174,11 @@ func TranslatePodSpec(podSpec *apiv1.PodSpec, rule *model.TranslationRule) {
 	TranslateOktetoVolumes(podSpec, rule)
 	TranslatePodSecurityContext(podSpec, rule.SecurityContext)
 	TranslatePodServiceAccount(podSpec, rule.ServiceAccount)
+	TranslateImagePullSecrets(podSpec, rule.ImagePullSecrets)
 
 	TranslateOktetoNodeSelector(podSpec, rule.NodeSelector)
 	TranslateOktetoAffinity(podSpec, rule.Affinity)
+	TranslateOktetoHostNetwork(podSpec, rule.HostNetwork)
 }
 
 //TranslateDinDContainer translates the DinD container
@@ -298,6 +304,20 @@ func TranslateResources(c *apiv1.Container, r model.ResourceRequirements) {
 	if v, ok := r.Limits[model.ResourceNVIDIAGPU]; ok {
 		c.Resources.Limits[model.ResourceNVIDIAGPU] = v
 	}
+
+	// the limits inherited from the original container can't be lower than the requests of the manifest
+	for _, name := range []apiv1.ResourceName{apiv1.ResourceMemory, apiv1.ResourceCPU, model.ResourceAMDGPU, model.ResourceNVIDIAGPU} {
+		request, ok := r.Requests[name]
+		if !ok {
+			continue
+		}
+		if _, ok := r.Limits[name]; ok {
+			continue
+		}
+		if limit, ok := c.Resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			c.Resources.Limits[name] = request
+		}
+	}
 }
 
 //TranslateEnvVars translates the variables attached to a container
@@ -446,6 +466,15 @@ func TranslatePodSecurityContext(spec *apiv1.PodSpec, s *model.SecurityContext)
 	if s.FSGroup != nil {
 		spec.SecurityContext.FSGroup = s.FSGroup
 	}
+
+	if s.RunAsNonRoot == nil && isRootUser(s) && spec.SecurityContext.RunAsNonRoot != nil && *spec.SecurityContext.RunAsNonRoot {
+		// the dev container can run as root even if the original pod requires a non-root user
+		spec.SecurityContext.RunAsNonRoot = nil
+	}
+}
+
+func isRootUser(s *model.SecurityContext) bool {
+	return s.RunAsUser != nil && *s.RunAsUser == 0
 }
 
 //TranslatePodServiceAccount translates the security account the pod uses
@@ -475,6 +504,16 @@ func TranslateContainerSecurityContext(c *apiv1.Container, s *model.SecurityCont
 
 	if s.RunAsNonRoot != nil {
 		c.SecurityContext.RunAsNonRoot = s.RunAsNonRoot
+	} else if isRootUser(s) && c.SecurityContext.RunAsNonRoot != nil && *c.SecurityContext.RunAsNonRoot {
+		// the dev container can run as root even if the original container requires a non-root user
+		c.SecurityContext.RunAsNonRoot = pointer.BoolPtr(false)
+	}
+
+	if s.Privileged != nil {
+		c.SecurityContext.Privileged = s.Privileged
+		if *s.Privileged {
+			c.SecurityContext.AllowPrivilegeEscalation = nil
+		}
 	}
 
 	if s.Capabilities == nil {
@@ -646,10 +685,36 @@ func TranslateOktetoDevSecret(spec *apiv1.PodSpec, secret string, secrets []mode
 	spec.Volumes = append(spec.Volumes, v)
 }
 
+//TranslateImagePullSecrets adds the image pull secrets of the okteto manifest to the ones of the original pod spec
+func TranslateImagePullSecrets(spec *apiv1.PodSpec, names []string) {
+	for _, name := range names {
+		found := false
+		for _, s := range spec.ImagePullSecrets {
+			if s.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			spec.ImagePullSecrets = append(spec.ImagePullSecrets, apiv1.LocalObjectReference{Name: name})
+		}
+	}
+}
+
 func TranslateOktetoNodeSelector(spec *apiv1.PodSpec, nodeSelector map[string]string) {
 	spec.NodeSelector = nodeSelector
 }
 
+//TranslateOktetoHostNetwork runs the development pod in the node network namespace.
+//The DNS policy is adjusted so that cluster services are still resolvable from the pod
+func TranslateOktetoHostNetwork(spec *apiv1.PodSpec, hostNetwork bool) {
+	if !hostNetwork {
+		return
+	}
+	spec.HostNetwork = true
+	spec.DNSPolicy = apiv1.DNSClusterFirstWithHostNet
+}
+
 func TranslateOktetoAffinity(spec *apiv1.PodSpec, affinity *apiv1.Affinity) {
 	if affinity != nil {
 		if affinity.NodeAffinity == nil && affinity.PodAffinity == nil && affinity.PodAntiAffinity == nil {
@@ -26,6 +26,7 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	yaml "gopkg.in/yaml.v2"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	apiv1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -440,7 +441,7 @@ services:
 	ctx := context.Background()
 
 	c := fake.NewSimpleClientset(d2)
-	if err := loadServiceTranslations(ctx, dev1, false, translationRules, c); err != nil {
+	if err := loadServiceTranslations(ctx, dev1, tr1.App, false, translationRules, c); err != nil {
 		t.Fatal(err)
 	}
 	tr2 := translationRules[dev2.Name]
@@ -1060,6 +1061,37 @@ func Test_translateResources(t *testing.T) {
 				apiv1.ResourceCPU:    resource.MustParse("2"),
 			},
 		},
+		{
+			name: "requests-in-yaml-greater-than-limits-in-container",
+			args: args{
+				c: &apiv1.Container{
+					Resources: apiv1.ResourceRequirements{
+						Limits: map[apiv1.ResourceName]resource.Quantity{
+							apiv1.ResourceMemory: resource.MustParse("1Gi"),
+							apiv1.ResourceCPU:    resource.MustParse("2"),
+						},
+						Requests: map[apiv1.ResourceName]resource.Quantity{
+							apiv1.ResourceMemory: resource.MustParse("512Mi"),
+							apiv1.ResourceCPU:    resource.MustParse("1"),
+						},
+					},
+				},
+				r: model.ResourceRequirements{
+					Requests: model.ResourceList{
+						apiv1.ResourceMemory: resource.MustParse("4Gi"),
+						apiv1.ResourceCPU:    resource.MustParse("0.5"),
+					},
+				},
+			},
+			expectedRequests: map[apiv1.ResourceName]resource.Quantity{
+				apiv1.ResourceMemory: resource.MustParse("4Gi"),
+				apiv1.ResourceCPU:    resource.MustParse("0.5"),
+			},
+			expectedLimits: map[apiv1.ResourceName]resource.Quantity{
+				apiv1.ResourceMemory: resource.MustParse("4Gi"),
+				apiv1.ResourceCPU:    resource.MustParse("2"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1185,6 +1217,98 @@ func Test_translateSecurityContext(t *testing.T) {
 	}
 }
 
+func Test_translateSecurityContextRootAndPrivileged(t *testing.T) {
+	var trueB = true
+	var falseB = false
+	var root int64 = 0
+	var user int64 = 1000
+
+	tests := []struct {
+		name                 string
+		c                    *apiv1.Container
+		s                    *model.SecurityContext
+		expectedRunAsNonRoot *bool
+		expectedPrivileged   *bool
+		expectedEscalation   *bool
+	}{
+		{
+			name: "root-overrides-inherited-run-as-non-root",
+			c: &apiv1.Container{
+				SecurityContext: &apiv1.SecurityContext{RunAsNonRoot: &trueB},
+			},
+			s:                    &model.SecurityContext{RunAsUser: &root},
+			expectedRunAsNonRoot: &falseB,
+		},
+		{
+			name:                 "root-without-inherited-run-as-non-root",
+			c:                    &apiv1.Container{},
+			s:                    &model.SecurityContext{RunAsUser: &root},
+			expectedRunAsNonRoot: nil,
+		},
+		{
+			name: "non-root-keeps-inherited-run-as-non-root",
+			c: &apiv1.Container{
+				SecurityContext: &apiv1.SecurityContext{RunAsNonRoot: &trueB},
+			},
+			s:                    &model.SecurityContext{RunAsUser: &user},
+			expectedRunAsNonRoot: &trueB,
+		},
+		{
+			name: "privileged",
+			c: &apiv1.Container{
+				SecurityContext: &apiv1.SecurityContext{AllowPrivilegeEscalation: &falseB},
+			},
+			s:                  &model.SecurityContext{Privileged: &trueB},
+			expectedPrivileged: &trueB,
+		},
+		{
+			name: "not-privileged",
+			c: &apiv1.Container{
+				SecurityContext: &apiv1.SecurityContext{AllowPrivilegeEscalation: &falseB},
+			},
+			s:                  &model.SecurityContext{Privileged: &falseB},
+			expectedPrivileged: &falseB,
+			expectedEscalation: &falseB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			TranslateContainerSecurityContext(tt.c, tt.s)
+			if !reflect.DeepEqual(tt.c.SecurityContext.RunAsNonRoot, tt.expectedRunAsNonRoot) {
+				t.Errorf("RunAsNonRoot: expected %v, got %v", tt.expectedRunAsNonRoot, tt.c.SecurityContext.RunAsNonRoot)
+			}
+			if !reflect.DeepEqual(tt.c.SecurityContext.Privileged, tt.expectedPrivileged) {
+				t.Errorf("Privileged: expected %v, got %v", tt.expectedPrivileged, tt.c.SecurityContext.Privileged)
+			}
+			if !reflect.DeepEqual(tt.c.SecurityContext.AllowPrivilegeEscalation, tt.expectedEscalation) {
+				t.Errorf("AllowPrivilegeEscalation: expected %v, got %v", tt.expectedEscalation, tt.c.SecurityContext.AllowPrivilegeEscalation)
+			}
+		})
+	}
+}
+
+func Test_translatePodSecurityContextRoot(t *testing.T) {
+	var trueB = true
+	var root int64 = 0
+
+	spec := &apiv1.PodSpec{
+		SecurityContext: &apiv1.PodSecurityContext{RunAsNonRoot: &trueB},
+	}
+	TranslatePodSecurityContext(spec, &model.SecurityContext{RunAsUser: &root})
+	if spec.SecurityContext.RunAsNonRoot != nil {
+		t.Errorf("the runAsNonRoot constraint of the pod was not removed")
+	}
+
+	spec = &apiv1.PodSpec{
+		SecurityContext: &apiv1.PodSecurityContext{RunAsNonRoot: &trueB},
+	}
+	TranslatePodSecurityContext(spec, &model.SecurityContext{RunAsUser: &root, RunAsNonRoot: &trueB})
+	if spec.SecurityContext.RunAsNonRoot == nil || !*spec.SecurityContext.RunAsNonRoot {
+		t.Errorf("the runAsNonRoot constraint of the pod was removed")
+	}
+}
+
 func TestTranslateOktetoVolumes(t *testing.T) {
 	var tests = []struct {
 		name     string
@@ -1727,7 +1851,7 @@ services:
 	trMap := make(map[string]*Translation)
 	ctx := context.Background()
 	c := fake.NewSimpleClientset(sfs2)
-	err = loadServiceTranslations(ctx, dev1, false, trMap, c)
+	err = loadServiceTranslations(ctx, dev1, tr1.App, false, trMap, c)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1853,3 +1977,219 @@ services:
 		t.Fatalf("sfs2 is running %d replicas after 'okteto down'", tr2.App.Replicas())
 	}
 }
+
+func TestTranslateOktetoHostNetwork(t *testing.T) {
+	var tests = []struct {
+		name        string
+		hostNetwork bool
+		expected    *apiv1.PodSpec
+	}{
+		{
+			name:        "disabled",
+			hostNetwork: false,
+			expected:    &apiv1.PodSpec{DNSPolicy: apiv1.DNSClusterFirst},
+		},
+		{
+			name:        "enabled",
+			hostNetwork: true,
+			expected:    &apiv1.PodSpec{HostNetwork: true, DNSPolicy: apiv1.DNSClusterFirstWithHostNet},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &apiv1.PodSpec{DNSPolicy: apiv1.DNSClusterFirst}
+			TranslateOktetoHostNetwork(spec, tt.hostNetwork)
+			if !reflect.DeepEqual(tt.expected, spec) {
+				t.Errorf("Expected \n%+v but got \n%+v", tt.expected, spec)
+			}
+		})
+	}
+}
+
+func TestTranslateImagePullSecrets(t *testing.T) {
+	var tests = []struct {
+		name     string
+		original []apiv1.LocalObjectReference
+		names    []string
+		expected []apiv1.LocalObjectReference
+	}{
+		{
+			name:     "none",
+			original: []apiv1.LocalObjectReference{{Name: "registry"}},
+			expected: []apiv1.LocalObjectReference{{Name: "registry"}},
+		},
+		{
+			name:     "added",
+			names:    []string{"artifactory"},
+			expected: []apiv1.LocalObjectReference{{Name: "artifactory"}},
+		},
+		{
+			name:     "merged",
+			original: []apiv1.LocalObjectReference{{Name: "registry"}},
+			names:    []string{"artifactory", "registry"},
+			expected: []apiv1.LocalObjectReference{{Name: "registry"}, {Name: "artifactory"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &apiv1.PodSpec{ImagePullSecrets: tt.original}
+			TranslateImagePullSecrets(spec, tt.names)
+			if !reflect.DeepEqual(tt.expected, spec.ImagePullSecrets) {
+				t.Errorf("Expected \n%+v but got \n%+v", tt.expected, spec.ImagePullSecrets)
+			}
+		})
+	}
+}
+
+func Test_translateDaemonSet(t *testing.T) {
+	original := &apiv1.Affinity{
+		NodeAffinity: &apiv1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &apiv1.NodeSelector{
+				NodeSelectorTerms: []apiv1.NodeSelectorTerm{
+					{
+						MatchExpressions: []apiv1.NodeSelectorRequirement{
+							{Key: "kubernetes.io/os", Operator: apiv1.NodeSelectorOpIn, Values: []string{"linux"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "agent",
+			Namespace: "test",
+			UID:       types.UID("ds1"),
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					Affinity:   original.DeepCopy(),
+					Containers: []apiv1.Container{{Name: "agent", Image: "agent:1.0"}},
+				},
+			},
+		},
+	}
+	dev := &model.Dev{
+		Name:      "agent",
+		Namespace: "test",
+		Image:     &model.BuildInfo{Name: "agent:dev"},
+	}
+	tr := &Translation{
+		MainDev: dev,
+		Dev:     dev,
+		App:     NewDaemonSetApp(ds, "node-a"),
+		Rules:   []*model.TranslationRule{{Container: "agent", Image: "agent:dev"}},
+	}
+	if err := tr.translate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if tr.App.ObjectMeta().Annotations[model.DevNodeAnnotation] != "node-a" {
+		t.Fatalf("expected dev node annotation, got %v", tr.App.ObjectMeta().Annotations)
+	}
+	excluded := tr.App.PodSpec().Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields
+	expected := []apiv1.NodeSelectorRequirement{{Key: "metadata.name", Operator: apiv1.NodeSelectorOpNotIn, Values: []string{"node-a"}}}
+	if !reflect.DeepEqual(excluded, expected) {
+		t.Fatalf("wrong original daemonset node affinity: %+v", excluded)
+	}
+
+	pinned := tr.DevApp.PodSpec().Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields
+	expected = []apiv1.NodeSelectorRequirement{{Key: "metadata.name", Operator: apiv1.NodeSelectorOpIn, Values: []string{"node-a"}}}
+	if !reflect.DeepEqual(pinned, expected) {
+		t.Fatalf("wrong dev clone node affinity: %+v", pinned)
+	}
+	if _, ok := tr.DevApp.ObjectMeta().Annotations[model.DevNodeAnnotation]; ok {
+		t.Fatal("dev clone has the dev node annotation")
+	}
+
+	if err := tr.DevModeOff(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tr.App.ObjectMeta().Annotations[model.DevNodeAnnotation]; ok {
+		t.Fatal("dev node annotation not removed")
+	}
+	if !reflect.DeepEqual(tr.App.PodSpec().Affinity, original) {
+		t.Fatalf("affinity not restored: %+v", tr.App.PodSpec().Affinity)
+	}
+}
+
+func Test_translateCronJob(t *testing.T) {
+	cj := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "report",
+			Namespace: "test",
+			UID:       types.UID("cj1"),
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "0 * * * *",
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: apiv1.PodTemplateSpec{
+						Spec: apiv1.PodSpec{
+							RestartPolicy:         apiv1.RestartPolicyOnFailure,
+							ActiveDeadlineSeconds: pointer.Int64Ptr(600),
+							Containers:            []apiv1.Container{{Name: "report", Image: "report:1.0"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	dev := &model.Dev{
+		Name:      "report",
+		Namespace: "test",
+		Image:     &model.BuildInfo{Name: "report:dev"},
+	}
+	tr := &Translation{
+		MainDev: dev,
+		Dev:     dev,
+		App:     NewCronJobApp(cj),
+		Rules:   []*model.TranslationRule{{Container: "report", Image: "report:dev"}},
+	}
+	if err := tr.translate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if cj.Spec.Suspend == nil || !*cj.Spec.Suspend {
+		t.Fatal("cronjob not suspended")
+	}
+	if tr.App.ObjectMeta().Annotations[model.AppReplicasAnnotation] != "1" {
+		t.Fatalf("wrong replicas annotation: %v", tr.App.ObjectMeta().Annotations)
+	}
+
+	devApp, ok := tr.DevApp.(*DeploymentApp)
+	if !ok {
+		t.Fatalf("expected a deployment dev clone, got %T", tr.DevApp)
+	}
+	if devApp.d.Name != model.DevCloneName("report") {
+		t.Fatalf("wrong dev clone name: %s", devApp.d.Name)
+	}
+	if devApp.Replicas() != 1 {
+		t.Fatalf("wrong dev clone replicas: %d", devApp.Replicas())
+	}
+	spec := devApp.PodSpec()
+	if spec.RestartPolicy != apiv1.RestartPolicyAlways || spec.ActiveDeadlineSeconds != nil {
+		t.Fatalf("job template fields not translated: %+v", spec)
+	}
+	if spec.Containers[0].Image != "report:dev" {
+		t.Fatalf("dev container not translated: %s", spec.Containers[0].Image)
+	}
+	for k, v := range devApp.d.Spec.Selector.MatchLabels {
+		if devApp.d.Spec.Template.Labels[k] != v {
+			t.Fatalf("dev clone selector doesn't match its template labels: %v", devApp.d.Spec.Template.Labels)
+		}
+	}
+	if cj.Spec.JobTemplate.Spec.Template.Spec.RestartPolicy != apiv1.RestartPolicyOnFailure {
+		t.Fatal("cronjob job template modified")
+	}
+
+	if err := tr.DevModeOff(); err != nil {
+		t.Fatal(err)
+	}
+	if cj.Spec.Suspend == nil || *cj.Spec.Suspend {
+		t.Fatal("cronjob schedule not restored")
+	}
+}
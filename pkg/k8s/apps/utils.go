@@ -49,6 +49,22 @@ func getPreviousAppReplicas(app App) int32 {
 	return app.Replicas()
 }
 
+// GetContainer returns the container or the init container of spec with a given name, or nil if it doesn't exist.
+// The first container is returned if containerName is empty
+func GetContainer(spec *apiv1.PodSpec, containerName string) *apiv1.Container {
+	if c := GetDevContainer(spec, containerName); c != nil {
+		return c
+	}
+
+	for i := range spec.InitContainers {
+		if spec.InitContainers[i].Name == containerName {
+			return &spec.InitContainers[i]
+		}
+	}
+
+	return nil
+}
+
 func GetDevContainer(spec *apiv1.PodSpec, containerName string) *apiv1.Container {
 	if containerName == "" {
 		return &spec.Containers[0]
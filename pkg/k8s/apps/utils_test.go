@@ -18,6 +18,7 @@ import (
 
 	"github.com/okteto/okteto/pkg/model"
 	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -131,3 +132,41 @@ func Test_getPreviousAppReplicas(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetContainer(t *testing.T) {
+	spec := &apiv1.PodSpec{
+		InitContainers: []apiv1.Container{{Name: "migrations", Image: "okteto/migrations"}},
+		Containers:     []apiv1.Container{{Name: "api", Image: "okteto/api"}, {Name: "worker", Image: "okteto/worker"}},
+	}
+	var tests = []struct {
+		name      string
+		container string
+		expected  string
+	}{
+		{name: "default", container: "", expected: "okteto/api"},
+		{name: "container", container: "worker", expected: "okteto/worker"},
+		{name: "init-container", container: "migrations", expected: "okteto/migrations"},
+		{name: "not-found", container: "other", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := GetContainer(spec, tt.container)
+			if tt.expected == "" {
+				if c != nil {
+					t.Fatalf("expected no container, got '%s'", c.Name)
+				}
+				return
+			}
+			if c == nil {
+				t.Fatal("container not found")
+			}
+			if c.Image != tt.expected {
+				t.Errorf("expected image '%s', got '%s'", tt.expected, c.Image)
+			}
+		})
+	}
+
+	if GetDevContainer(spec, "migrations") != nil {
+		t.Error("init containers can't be development containers")
+	}
+}
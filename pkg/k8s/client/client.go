@@ -14,6 +14,7 @@
 package client
 
 import (
+	"context"
 	"log"
 	"os"
 
@@ -32,14 +33,22 @@ func InCluster() bool {
 
 // Get returns a kubernetes client for the current okteto context
 func Get(kubeconfigFile string) (*kubernetes.Clientset, *rest.Config, error) {
-	clientConfig := GetClientConfig(kubeconfigFile, "")
+	return GetForContext(kubeconfigFile, "")
+}
+
+//GetForContext returns the kubernetes client of a context of a kubeconfig file, or of its current context if kubeContext is empty
+func GetForContext(kubeconfigFile, kubeContext string) (*kubernetes.Clientset, *rest.Config, error) {
+	clientConfig := GetClientConfig(kubeconfigFile, kubeContext)
 
 	config, err := clientConfig.ClientConfig()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	config.Timeout = getKubernetesTimeout()
+	ConfigureRestConfig(config)
+	if err := checkImpersonation(context.Background(), config); err != nil {
+		return nil, nil, err
+	}
 
 	client, err := kubernetes.NewForConfig(config)
 	if err != nil {
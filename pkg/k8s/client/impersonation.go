@@ -0,0 +1,125 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+var impersonation rest.ImpersonationConfig
+
+// impersonationChecked caches the result of checking the impersonation permissions for each API server
+var impersonationChecked = struct {
+	sync.Mutex
+	hosts map[string]error
+}{hosts: map[string]error{}}
+
+//SetImpersonation makes the kubernetes clients act as the given user and groups, like 'kubectl --as --as-group'
+func SetImpersonation(user string, groups []string) error {
+	if user == "" && len(groups) > 0 {
+		return errors.UserError{
+			E:    fmt.Errorf("'--as-group' requires '--as'"),
+			Hint: "Kubernetes doesn't allow impersonating groups without impersonating a user",
+		}
+	}
+	impersonation = rest.ImpersonationConfig{
+		UserName: user,
+		Groups:   groups,
+	}
+	return nil
+}
+
+// configureImpersonation sets the impersonated identity in a rest config
+func configureImpersonation(cfg *rest.Config) {
+	if impersonation.UserName == "" {
+		return
+	}
+	cfg.Impersonate = rest.ImpersonationConfig{
+		UserName: impersonation.UserName,
+		Groups:   append([]string{}, impersonation.Groups...),
+	}
+}
+
+// checkImpersonation verifies that the credentials of a rest config are allowed to impersonate the configured identity.
+// The check is done with the credentials of the user, before impersonating anybody
+func checkImpersonation(ctx context.Context, cfg *rest.Config) error {
+	if cfg.Impersonate.UserName == "" {
+		return nil
+	}
+
+	impersonationChecked.Lock()
+	defer impersonationChecked.Unlock()
+	if err, ok := impersonationChecked.hosts[cfg.Host]; ok {
+		return err
+	}
+
+	baseCfg := rest.CopyConfig(cfg)
+	baseCfg.Impersonate = rest.ImpersonationConfig{}
+	c, err := kubernetes.NewForConfig(baseCfg)
+	if err != nil {
+		return err
+	}
+	err = checkImpersonationAccess(ctx, cfg.Impersonate, c)
+	impersonationChecked.hosts[cfg.Host] = err
+	return err
+}
+
+func checkImpersonationAccess(ctx context.Context, impersonate rest.ImpersonationConfig, c kubernetes.Interface) error {
+	resources := map[string][]string{"users": {impersonate.UserName}}
+	if len(impersonate.Groups) > 0 {
+		resources["groups"] = impersonate.Groups
+	}
+
+	denied := []string{}
+	for _, resource := range []string{"users", "groups"} {
+		for _, name := range resources[resource] {
+			review := &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Verb:     "impersonate",
+						Resource: resource,
+						Name:     name,
+					},
+				},
+			}
+			result, err := c.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+			if err != nil {
+				// the API server will reject the requests if impersonation isn't allowed
+				log.Infof("failed to check the permission to impersonate %s '%s': %s", resource, name, err)
+				continue
+			}
+			if !result.Status.Allowed {
+				denied = append(denied, fmt.Sprintf("%s '%s'", strings.TrimSuffix(resource, "s"), name))
+			}
+		}
+	}
+
+	if len(denied) == 0 {
+		return nil
+	}
+	return errors.UserError{
+		E:    fmt.Errorf("your Kubernetes credentials are not allowed to impersonate %s", strings.Join(denied, ", ")),
+		Hint: "Ask your cluster administrator for the 'impersonate' permission on the 'users' and 'groups' resources, or run the command without '--as' and '--as-group'",
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestSetImpersonation(t *testing.T) {
+	defer func() { impersonation = rest.ImpersonationConfig{} }()
+
+	if err := SetImpersonation("", []string{"admins"}); err == nil {
+		t.Fatal("expected an error when impersonating groups without a user")
+	}
+
+	if err := SetImpersonation("jane", []string{"admins"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &rest.Config{}
+	ConfigureRestConfig(cfg)
+	if cfg.Impersonate.UserName != "jane" || len(cfg.Impersonate.Groups) != 1 || cfg.Impersonate.Groups[0] != "admins" {
+		t.Errorf("wrong impersonation config: %+v", cfg.Impersonate)
+	}
+
+	if err := SetImpersonation("", nil); err != nil {
+		t.Fatal(err)
+	}
+	cfg = &rest.Config{}
+	ConfigureRestConfig(cfg)
+	if cfg.Impersonate.UserName != "" {
+		t.Errorf("unexpected impersonation config: %+v", cfg.Impersonate)
+	}
+}
+
+func Test_checkImpersonationAccess(t *testing.T) {
+	tests := []struct {
+		name        string
+		impersonate rest.ImpersonationConfig
+		allowed     map[string]bool
+		wantErr     string
+	}{
+		{
+			name:        "allowed",
+			impersonate: rest.ImpersonationConfig{UserName: "jane", Groups: []string{"admins"}},
+			allowed:     map[string]bool{"users/jane": true, "groups/admins": true},
+		},
+		{
+			name:        "user-denied",
+			impersonate: rest.ImpersonationConfig{UserName: "jane"},
+			allowed:     map[string]bool{},
+			wantErr:     "user 'jane'",
+		},
+		{
+			name:        "group-denied",
+			impersonate: rest.ImpersonationConfig{UserName: "jane", Groups: []string{"admins", "devs"}},
+			allowed:     map[string]bool{"users/jane": true, "groups/devs": true},
+			wantErr:     "group 'admins'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset()
+			c.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				attrs := review.Spec.ResourceAttributes
+				if attrs.Verb != "impersonate" {
+					t.Errorf("unexpected verb %s", attrs.Verb)
+				}
+				review.Status.Allowed = tt.allowed[attrs.Resource+"/"+attrs.Name]
+				return true, review, nil
+			})
+
+			err := checkImpersonationAccess(context.Background(), tt.impersonate, c)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
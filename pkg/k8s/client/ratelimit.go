@@ -0,0 +1,81 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/log"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	qpsEnvVar   = "OKTETO_KUBERNETES_QPS"
+	burstEnvVar = "OKTETO_KUBERNETES_BURST"
+)
+
+var qps float32
+var burst int
+var rOnce sync.Once
+
+//ConfigureRestConfig sets the timeout, the client-side rate limits, the user agent and the impersonated identity of the okteto CLI in a rest config.
+//Rate limits default to the ones of client-go and can be tuned with OKTETO_KUBERNETES_QPS and OKTETO_KUBERNETES_BURST
+func ConfigureRestConfig(cfg *rest.Config) {
+	cfg.Timeout = getKubernetesTimeout()
+	cfg.QPS, cfg.Burst = getRateLimits()
+	cfg.UserAgent = getUserAgent()
+	configureImpersonation(cfg)
+}
+
+func getRateLimits() (float32, int) {
+	rOnce.Do(func() {
+		qps = rest.DefaultQPS
+		burst = rest.DefaultBurst
+
+		if v, ok := os.LookupEnv(qpsEnvVar); ok {
+			parsed, err := strconv.ParseFloat(v, 32)
+			if err != nil || parsed <= 0 {
+				log.Infof("'%s' is not a valid value for %s, ignoring", v, qpsEnvVar)
+			} else {
+				log.Infof("%s applied: '%s'", qpsEnvVar, v)
+				qps = float32(parsed)
+			}
+		}
+
+		if v, ok := os.LookupEnv(burstEnvVar); ok {
+			parsed, err := strconv.Atoi(v)
+			if err != nil || parsed <= 0 {
+				log.Infof("'%s' is not a valid value for %s, ignoring", v, burstEnvVar)
+			} else {
+				log.Infof("%s applied: '%s'", burstEnvVar, v)
+				burst = parsed
+			}
+		}
+	})
+
+	return qps, burst
+}
+
+func getUserAgent() string {
+	version := config.VersionString
+	if version == "" {
+		version = "dev"
+	}
+	return fmt.Sprintf("okteto/%s (%s/%s)", version, runtime.GOOS, runtime.GOARCH)
+}
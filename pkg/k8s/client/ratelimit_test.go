@@ -0,0 +1,115 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func Test_getRateLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		qps       string
+		burst     string
+		wantQPS   float32
+		wantBurst int
+	}{
+		{
+			name:      "defaults",
+			wantQPS:   rest.DefaultQPS,
+			wantBurst: rest.DefaultBurst,
+		},
+		{
+			name:      "from-env",
+			qps:       "50.5",
+			burst:     "100",
+			wantQPS:   50.5,
+			wantBurst: 100,
+		},
+		{
+			name:      "invalid",
+			qps:       "fast",
+			burst:     "-1",
+			wantQPS:   rest.DefaultQPS,
+			wantBurst: rest.DefaultBurst,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rOnce = sync.Once{}
+			if tt.qps != "" {
+				t.Setenv(qpsEnvVar, tt.qps)
+			}
+			if tt.burst != "" {
+				t.Setenv(burstEnvVar, tt.burst)
+			}
+			gotQPS, gotBurst := getRateLimits()
+			if gotQPS != tt.wantQPS {
+				t.Errorf("got QPS %v, want %v", gotQPS, tt.wantQPS)
+			}
+			if gotBurst != tt.wantBurst {
+				t.Errorf("got burst %d, want %d", gotBurst, tt.wantBurst)
+			}
+		})
+	}
+}
+
+func Test_GetConfiguresRestConfig(t *testing.T) {
+	rOnce = sync.Once{}
+	t.Setenv(qpsEnvVar, "42")
+	t.Setenv(burstEnvVar, "84")
+	defer func() { rOnce = sync.Once{} }()
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	content := []byte(`apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- cluster:
+    server: https://dev.example.com
+  name: dev
+contexts:
+- context:
+    cluster: dev
+    user: dev
+  name: dev
+users:
+- name: dev
+  user:
+    token: dev-token
+`)
+	if err := os.WriteFile(kubeconfig, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, cfg, err := Get(kubeconfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.QPS != 42 {
+		t.Errorf("got QPS %v, want 42", cfg.QPS)
+	}
+	if cfg.Burst != 84 {
+		t.Errorf("got burst %d, want 84", cfg.Burst)
+	}
+	if !strings.HasPrefix(cfg.UserAgent, "okteto/") {
+		t.Errorf("got user agent '%s', want it to identify the okteto CLI", cfg.UserAgent)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cronjobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetByDev(t *testing.T) {
+	cj := func(name string, labels map[string]string) *batchv1.CronJob {
+		return &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+				Labels:    labels,
+			},
+		}
+	}
+
+	var tests = []struct {
+		name        string
+		dev         *model.Dev
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "by-name",
+			dev:      &model.Dev{Name: "report"},
+			expected: "report",
+		},
+		{
+			name:     "by-labels",
+			dev:      &model.Dev{Name: "dev", Labels: model.Labels{"app": "cleanup"}},
+			expected: "cleanup",
+		},
+		{
+			name:        "multiple-matches",
+			dev:         &model.Dev{Name: "dev", Labels: model.Labels{"tier": "batch"}},
+			expectError: true,
+		},
+		{
+			name:        "not-found",
+			dev:         &model.Dev{Name: "dev", Labels: model.Labels{"app": "unknown"}},
+			expectError: true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset(
+				cj("report", map[string]string{"app": "report", "tier": "batch"}),
+				cj("cleanup", map[string]string{"app": "cleanup", "tier": "batch"}),
+			)
+			result, err := GetByDev(ctx, tt.dev, "test", c)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Name != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, result.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemonsets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+//Deploy creates or updates a daemonset
+func Deploy(ctx context.Context, ds *appsv1.DaemonSet, c kubernetes.Interface) (*appsv1.DaemonSet, error) {
+	ds.ResourceVersion = ""
+	result, err := c.AppsV1().DaemonSets(ds.Namespace).Update(ctx, ds, metav1.UpdateOptions{})
+	if err == nil {
+		return result, nil
+	}
+
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	return c.AppsV1().DaemonSets(ds.Namespace).Create(ctx, ds, metav1.CreateOptions{})
+}
+
+//List returns the list of daemonsets
+func List(ctx context.Context, namespace, labels string, c kubernetes.Interface) ([]appsv1.DaemonSet, error) {
+	dsList, err := c.AppsV1().DaemonSets(namespace).List(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector: labels,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return dsList.Items, nil
+}
+
+//Get returns a daemonset object by name
+func Get(ctx context.Context, name, namespace string, c kubernetes.Interface) (*appsv1.DaemonSet, error) {
+	return c.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+//GetByDev returns a daemonset object given a dev struct (by name or by labels)
+func GetByDev(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Interface) (*appsv1.DaemonSet, error) {
+	if len(dev.Labels) == 0 {
+		return Get(ctx, dev.Name, namespace, c)
+	}
+
+	dsList, err := List(ctx, namespace, dev.LabelsSelector(), c)
+	if err != nil {
+		return nil, err
+	}
+	validDaemonsets := []*appsv1.DaemonSet{}
+	for i := range dsList {
+		if dsList[i].Labels[model.DevCloneLabel] == "" {
+			validDaemonsets = append(validDaemonsets, &dsList[i])
+		}
+	}
+	if len(validDaemonsets) == 0 {
+		return nil, errors.ErrNotFound
+	}
+	if len(validDaemonsets) > 1 {
+		return nil, fmt.Errorf("found '%d' daemonsets for labels '%s' instead of 1", len(validDaemonsets), dev.LabelsSelector())
+	}
+	return validDaemonsets[0], nil
+}
+
+//Destroy removes a daemonset object given its name and namespace
+func Destroy(ctx context.Context, name, namespace string, c kubernetes.Interface) error {
+	if err := c.AppsV1().DaemonSets(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("error deleting kubernetes daemonset: %s", err)
+	}
+	log.Infof("daemonset '%s' deleted", name)
+	return nil
+}
+
+//IsRolledOut returns if the latest revision of a daemonset is ready, following the same rules as "kubectl rollout status"
+func IsRolledOut(ds *appsv1.DaemonSet) bool {
+	if ds.Generation > ds.Status.ObservedGeneration {
+		return false
+	}
+	if ds.Status.UpdatedNumberScheduled < ds.Status.DesiredNumberScheduled {
+		return false
+	}
+	return ds.Status.NumberAvailable >= ds.Status.DesiredNumberScheduled
+}
+
+//CheckConditionErrors checks errors in conditions
+func CheckConditionErrors(ds *appsv1.DaemonSet) error {
+	for _, c := range ds.Status.Conditions {
+		if c.Status != apiv1.ConditionTrue || c.Reason != "FailedCreate" {
+			continue
+		}
+		if strings.Contains(c.Message, "exceeded quota") {
+			log.Infof("%s: %s", errors.ErrQuota, c.Message)
+			return errors.ErrQuota
+		}
+		return fmt.Errorf(c.Message)
+	}
+	return nil
+}
+
+//TranslateDivert translates a daemonset to be diverted by a given user
+func TranslateDivert(username string, ds *appsv1.DaemonSet) *appsv1.DaemonSet {
+	name := model.DivertName(ds.Name, username)
+	result := ds.DeepCopy()
+	result.UID = ""
+	result.Name = name
+	result.Labels = map[string]string{model.OktetoDivertLabel: username}
+	if ds.Labels != nil && ds.Labels[model.DeployedByLabel] != "" {
+		result.Labels[model.DeployedByLabel] = ds.Labels[model.DeployedByLabel]
+	}
+	result.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			model.OktetoDivertLabel: username,
+		},
+	}
+	result.Spec.Template.Labels = map[string]string{
+		model.OktetoDivertLabel: username,
+	}
+
+	result.ResourceVersion = ""
+	return result
+}
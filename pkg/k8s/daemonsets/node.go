@@ -0,0 +1,182 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemonsets
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// nodeNameField is the node field used by the daemonset controller to schedule pods on a given node
+const nodeNameField = "metadata.name"
+
+//GetDevNode returns the node where the development container of a daemonset runs.
+//It defaults to the node of a previous 'okteto up' or to the first node running a pod of the daemonset
+func GetDevNode(ctx context.Context, ds *appsv1.DaemonSet, node string, c kubernetes.Interface) (string, error) {
+	current := ds.Annotations[model.DevNodeAnnotation]
+	if node == "" && current != "" {
+		return current, nil
+	}
+	if node != "" && node == current {
+		return node, nil
+	}
+
+	nodes, err := getNodes(ctx, ds, c)
+	if err != nil {
+		return "", err
+	}
+	if node == "" {
+		if len(nodes) == 0 {
+			return "", fmt.Errorf("daemonset '%s' doesn't have running pods", ds.Name)
+		}
+		return nodes[0], nil
+	}
+	for _, n := range nodes {
+		if n == node {
+			return node, nil
+		}
+	}
+	return "", errors.UserError{
+		E:    fmt.Errorf("daemonset '%s' doesn't have running pods on node '%s'", ds.Name, node),
+		Hint: fmt.Sprintf("Use the '--node' flag to select one of these nodes: %s", strings.Join(nodes, ", ")),
+	}
+}
+
+// getNodes returns the sorted list of nodes running a pod of a daemonset
+func getNodes(ctx context.Context, ds *appsv1.DaemonSet, c kubernetes.Interface) ([]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	podList, err := c.CoreV1().Pods(ds.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	nodes := []string{}
+	for i := range podList.Items {
+		p := &podList.Items[i]
+		if p.DeletionTimestamp != nil || p.Spec.NodeName == "" {
+			continue
+		}
+		for _, or := range p.OwnerReferences {
+			if or.UID == ds.UID {
+				nodes = append(nodes, p.Spec.NodeName)
+				break
+			}
+		}
+	}
+	sort.Strings(nodes)
+	return nodes, nil
+}
+
+//ExcludeNode prevents the pods of a daemonset from running on a given node
+func ExcludeNode(ds *appsv1.DaemonSet, node string) {
+	addNodeRequirement(&ds.Spec.Template.Spec, nodeRequirement(node, apiv1.NodeSelectorOpNotIn))
+}
+
+//IncludeNode reverts the changes of ExcludeNode
+func IncludeNode(ds *appsv1.DaemonSet, node string) {
+	removeNodeRequirement(&ds.Spec.Template.Spec, nodeRequirement(node, apiv1.NodeSelectorOpNotIn))
+}
+
+//PinNode restricts the pods of a daemonset to a given node
+func PinNode(ds *appsv1.DaemonSet, node string) {
+	addNodeRequirement(&ds.Spec.Template.Spec, nodeRequirement(node, apiv1.NodeSelectorOpIn))
+}
+
+func nodeRequirement(node string, op apiv1.NodeSelectorOperator) apiv1.NodeSelectorRequirement {
+	return apiv1.NodeSelectorRequirement{
+		Key:      nodeNameField,
+		Operator: op,
+		Values:   []string{node},
+	}
+}
+
+// addNodeRequirement adds a requirement to every term of the required node affinity, as terms are ORed
+func addNodeRequirement(spec *apiv1.PodSpec, r apiv1.NodeSelectorRequirement) {
+	if spec.Affinity == nil {
+		spec.Affinity = &apiv1.Affinity{}
+	}
+	if spec.Affinity.NodeAffinity == nil {
+		spec.Affinity.NodeAffinity = &apiv1.NodeAffinity{}
+	}
+	if spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &apiv1.NodeSelector{}
+	}
+	selector := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = []apiv1.NodeSelectorTerm{{}}
+	}
+	for i := range selector.NodeSelectorTerms {
+		if !hasRequirement(selector.NodeSelectorTerms[i].MatchFields, r) {
+			selector.NodeSelectorTerms[i].MatchFields = append(selector.NodeSelectorTerms[i].MatchFields, r)
+		}
+	}
+}
+
+// removeNodeRequirement removes a requirement added by addNodeRequirement, cleaning up the empty affinity fields
+func removeNodeRequirement(spec *apiv1.PodSpec, r apiv1.NodeSelectorRequirement) {
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil || spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return
+	}
+	selector := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	terms := []apiv1.NodeSelectorTerm{}
+	for _, t := range selector.NodeSelectorTerms {
+		fields := []apiv1.NodeSelectorRequirement{}
+		for _, f := range t.MatchFields {
+			if !reflect.DeepEqual(f, r) {
+				fields = append(fields, f)
+			}
+		}
+		if len(fields) == 0 {
+			fields = nil
+		}
+		t.MatchFields = fields
+		if len(t.MatchExpressions) == 0 && len(t.MatchFields) == 0 {
+			continue
+		}
+		terms = append(terms, t)
+	}
+
+	if len(terms) > 0 {
+		selector.NodeSelectorTerms = terms
+		return
+	}
+	spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = nil
+	if len(spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution) == 0 {
+		spec.Affinity.NodeAffinity = nil
+	}
+	if spec.Affinity.NodeAffinity == nil && spec.Affinity.PodAffinity == nil && spec.Affinity.PodAntiAffinity == nil {
+		spec.Affinity = nil
+	}
+}
+
+func hasRequirement(requirements []apiv1.NodeSelectorRequirement, r apiv1.NodeSelectorRequirement) bool {
+	for _, req := range requirements {
+		if reflect.DeepEqual(req, r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemonsets
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestExcludeNode(t *testing.T) {
+	var tests = []struct {
+		name     string
+		affinity *apiv1.Affinity
+	}{
+		{
+			name: "no-affinity",
+		},
+		{
+			name: "pod-affinity",
+			affinity: &apiv1.Affinity{
+				PodAffinity: &apiv1.PodAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []apiv1.PodAffinityTerm{
+						{TopologyKey: "kubernetes.io/hostname"},
+					},
+				},
+			},
+		},
+		{
+			name: "multiple-terms",
+			affinity: &apiv1.Affinity{
+				NodeAffinity: &apiv1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &apiv1.NodeSelector{
+						NodeSelectorTerms: []apiv1.NodeSelectorTerm{
+							{
+								MatchExpressions: []apiv1.NodeSelectorRequirement{
+									{Key: "kubernetes.io/os", Operator: apiv1.NodeSelectorOpIn, Values: []string{"linux"}},
+								},
+							},
+							{
+								MatchFields: []apiv1.NodeSelectorRequirement{
+									{Key: "metadata.name", Operator: apiv1.NodeSelectorOpNotIn, Values: []string{"node-z"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	excluded := apiv1.NodeSelectorRequirement{Key: "metadata.name", Operator: apiv1.NodeSelectorOpNotIn, Values: []string{"node-a"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := &appsv1.DaemonSet{}
+			ds.Spec.Template.Spec.Affinity = tt.affinity.DeepCopy()
+
+			ExcludeNode(ds, "node-a")
+			ExcludeNode(ds, "node-a")
+			terms := ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+			if len(terms) == 0 {
+				t.Fatal("node affinity terms not added")
+			}
+			for _, term := range terms {
+				found := 0
+				for _, f := range term.MatchFields {
+					if reflect.DeepEqual(f, excluded) {
+						found++
+					}
+				}
+				if found != 1 {
+					t.Fatalf("expected the node to be excluded once in every term, got %+v", term)
+				}
+			}
+
+			IncludeNode(ds, "node-a")
+			if !reflect.DeepEqual(ds.Spec.Template.Spec.Affinity, tt.affinity) {
+				t.Fatalf("expected %+v, got %+v", tt.affinity, ds.Spec.Template.Spec.Affinity)
+			}
+		})
+	}
+}
+
+func TestPinNode(t *testing.T) {
+	ds := &appsv1.DaemonSet{}
+	PinNode(ds, "node-a")
+	expected := &apiv1.NodeSelector{
+		NodeSelectorTerms: []apiv1.NodeSelectorTerm{
+			{
+				MatchFields: []apiv1.NodeSelectorRequirement{
+					{Key: "metadata.name", Operator: apiv1.NodeSelectorOpIn, Values: []string{"node-a"}},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution, expected) {
+		t.Fatalf("wrong node affinity: %+v", ds.Spec.Template.Spec.Affinity.NodeAffinity)
+	}
+}
@@ -222,6 +222,29 @@ func IsRunning(ctx context.Context, namespace, svcName string, c kubernetes.Inte
 	return d.Status.ReadyReplicas > 0
 }
 
+//IsRolledOut returns if the latest revision of a deployment is available, following the same rules as "kubectl rollout status"
+func IsRolledOut(d *appsv1.Deployment) (bool, error) {
+	if d.Generation > d.Status.ObservedGeneration {
+		return false, nil
+	}
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			return false, fmt.Errorf("deployment '%s' exceeded its progress deadline", d.Name)
+		}
+	}
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	if d.Status.UpdatedReplicas < replicas {
+		return false, nil
+	}
+	if d.Status.Replicas > d.Status.UpdatedReplicas {
+		return false, nil
+	}
+	return d.Status.AvailableReplicas >= d.Status.UpdatedReplicas, nil
+}
+
 func TranslateDivert(username string, d *appsv1.Deployment) *appsv1.Deployment {
 	name := model.DivertName(d.Name, username)
 	result := d.DeepCopy()
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
 )
 
 func TestGet(t *testing.T) {
@@ -253,3 +254,92 @@ func Test_translateDivertDeployment(t *testing.T) {
 		t.Fatalf("Wrong translation.\nActual %+v, \nExpected %+v", string(marshalled), string(marshalledExpected))
 	}
 }
+
+func TestIsRolledOut(t *testing.T) {
+	tests := []struct {
+		name        string
+		deployment  *appsv1.Deployment
+		expected    bool
+		expectedErr bool
+	}{
+		{
+			name: "rolled-out",
+			deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32Ptr(2)},
+				Status: appsv1.DeploymentStatus{
+					ObservedGeneration: 2,
+					Replicas:           2,
+					UpdatedReplicas:    2,
+					AvailableReplicas:  2,
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "generation-not-observed",
+			deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32Ptr(1)},
+				Status: appsv1.DeploymentStatus{
+					ObservedGeneration: 2,
+					Replicas:           1,
+					UpdatedReplicas:    1,
+					AvailableReplicas:  1,
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "old-replicas-pending-termination",
+			deployment: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: pointer.Int32Ptr(1)},
+				Status: appsv1.DeploymentStatus{
+					Replicas:          2,
+					UpdatedReplicas:   1,
+					AvailableReplicas: 1,
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "updated-replicas-not-available",
+			deployment: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: pointer.Int32Ptr(1)},
+				Status: appsv1.DeploymentStatus{
+					Replicas:          1,
+					UpdatedReplicas:   1,
+					AvailableReplicas: 0,
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "progress-deadline-exceeded",
+			deployment: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: pointer.Int32Ptr(1)},
+				Status: appsv1.DeploymentStatus{
+					Conditions: []appsv1.DeploymentCondition{
+						{
+							Type:   appsv1.DeploymentProgressing,
+							Status: apiv1.ConditionFalse,
+							Reason: "ProgressDeadlineExceeded",
+						},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := IsRolledOut(tt.deployment)
+			if tt.expectedErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, result)
+			}
+		})
+	}
+}
@@ -16,8 +16,8 @@ package diverts
 import (
 	"fmt"
 
-	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/k8s/client"
+	"github.com/okteto/okteto/pkg/okteto"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8sScheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -53,13 +53,17 @@ func NewForConfig(cfg *rest.Config) (*DivertV1Client, error) {
 }
 
 func GetClient(thisContext string) (*DivertV1Client, error) {
-	kubeconfigFile := config.GetOktetoContextKubeconfigPath()
-	clientConfig := client.GetClientConfig(kubeconfigFile, "")
+	kubeconfigFile, kubeContext, err := okteto.GetK8sKubeconfig()
+	if err != nil {
+		return nil, err
+	}
+	clientConfig := client.GetClientConfig(kubeconfigFile, kubeContext)
 
 	config, err := clientConfig.ClientConfig()
 	if err != nil {
 		return nil, err
 	}
+	client.ConfigureRestConfig(config)
 
 	c, err := NewForConfig(config)
 	if err != nil {
@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/k8s/apps"
@@ -29,110 +30,188 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
 )
 
-func Create(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
+//Create diverts the service and ingress of a development container.
+//In dry-run mode, it prints the divert resources instead of applying them
+func Create(ctx context.Context, dev *model.Dev, dryRun bool, c kubernetes.Interface) error {
 	if !okteto.IsOktetoContext() {
 		return errors.ErrDivertNotSupported
 	}
 
-	username := okteto.GetSanitizedUsername()
+	username := okteto.GetDivertKey(dev)
+	timeout := getCallTimeout(dev)
 
-	app, err := divertApp(ctx, dev, username, c)
-	if err != nil {
-		return err
+	if dClient, err := GetClient(dev.Context); err != nil {
+		log.Infof("error creating divert CRD client: %s", err.Error())
+	} else if err := pruneOrphans(ctx, dClient.Diverts(dev.Namespace), dev.Namespace, username, model.DivertName(dev.Divert.Service, username), dryRun, timeout, c); err != nil {
+		log.Infof("failed to prune orphaned divert resources: %s", err.Error())
 	}
 
-	s, err := divertService(ctx, dev, app, username, c)
+	app, err := divertApp(ctx, dev, username, timeout, c)
 	if err != nil {
 		return err
 	}
 
-	i, err := divertIngress(ctx, dev, username, c)
+	s, err := divertService(ctx, dev, app, username, dryRun, timeout, c)
 	if err != nil {
 		return err
 	}
 
-	if err := createDivertCRD(ctx, dev, username, i, s); err != nil {
+	ingresses := []*networkingv1.Ingress{}
+	for _, name := range dev.Divert.Ingress {
+		i, err := divertIngress(ctx, dev, name, username, dryRun, timeout, c)
+		if err != nil {
+			return err
+		}
+		ingresses = append(ingresses, i)
+	}
+
+	if err := createDivertCRD(ctx, dev, username, ingresses, s, dryRun, timeout); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func divertApp(ctx context.Context, dev *model.Dev, username string, c kubernetes.Interface) (apps.App, error) {
-	app, err := apps.Get(ctx, dev, dev.Namespace, c)
+func divertApp(ctx context.Context, dev *model.Dev, username string, timeout time.Duration, c kubernetes.Interface) (apps.App, error) {
+	var app apps.App
+	err := withTimeout(ctx, timeout, fmt.Sprintf("getting the application '%s'", dev.Name), func(ctx context.Context) error {
+		var err error
+		app, err = apps.Get(ctx, dev, dev.Namespace, c)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 	return app.Divert(username), nil
 }
 
-func divertService(ctx context.Context, dev *model.Dev, app apps.App, username string, c kubernetes.Interface) (*apiv1.Service, error) {
-	s, err := services.Get(ctx, dev.Divert.Service, dev.Namespace, c)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			return nil, fmt.Errorf("the divert service '%s' doesn't exist", dev.Divert.Service)
+func divertService(ctx context.Context, dev *model.Dev, app apps.App, username string, dryRun bool, timeout time.Duration, c kubernetes.Interface) (*apiv1.Service, error) {
+	var s *apiv1.Service
+	err := withTimeout(ctx, timeout, fmt.Sprintf("getting divert service '%s'", dev.Divert.Service), func(ctx context.Context) error {
+		var err error
+		s, err = services.Get(ctx, dev.Divert.Service, dev.Namespace, c)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return fmt.Errorf("the divert service '%s' doesn't exist", dev.Divert.Service)
+			}
+			return fmt.Errorf("error getting divert service '%s': %s", dev.Divert.Service, err.Error())
 		}
-		return nil, fmt.Errorf("error getting divert service '%s': %s", dev.Divert.Service, err.Error())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	divertService, err := translateService(username, app, s)
 	if err != nil {
 		return nil, err
 	}
-	if err := services.Deploy(ctx, divertService, c); err != nil {
-		return nil, fmt.Errorf("error creating divert service '%s': %s", divertService.Name, err.Error())
+	if dryRun {
+		return divertService, printDryRun("Service", divertService.Name, divertService)
+	}
+	err = withTimeout(ctx, timeout, fmt.Sprintf("creating divert service '%s'", divertService.Name), func(ctx context.Context) error {
+		if err := services.Deploy(ctx, divertService, c); err != nil {
+			return fmt.Errorf("error creating divert service '%s': %s", divertService.Name, err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return divertService, nil
 }
 
-func divertIngress(ctx context.Context, dev *model.Dev, username string, c kubernetes.Interface) (*networkingv1.Ingress, error) {
-	i, err := ingressesv1.Get(ctx, dev.Divert.Ingress, dev.Namespace, c)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			return nil, fmt.Errorf("the divert ingress '%s' doesn't exist", dev.Divert.Ingress)
+func divertIngress(ctx context.Context, dev *model.Dev, name, username string, dryRun bool, timeout time.Duration, c kubernetes.Interface) (*networkingv1.Ingress, error) {
+	var i *networkingv1.Ingress
+	err := withTimeout(ctx, timeout, fmt.Sprintf("getting divert ingress '%s'", name), func(ctx context.Context) error {
+		var err error
+		i, err = ingressesv1.Get(ctx, name, dev.Namespace, c)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return fmt.Errorf("the divert ingress '%s' doesn't exist", name)
+			}
+			return fmt.Errorf("error getting divert ingress '%s': %s", name, err.Error())
 		}
-		return nil, fmt.Errorf("error getting divert ingress '%s': %s", dev.Divert.Ingress, err.Error())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	divertIngress := translateIngress(username, i)
-	if err := ingressesv1.Deploy(ctx, divertIngress, c); err != nil {
-		return nil, fmt.Errorf("error creating divert ingress '%s': %s", divertIngress.Name, err.Error())
+	if dryRun {
+		return divertIngress, printDryRun("Ingress", divertIngress.Name, divertIngress)
+	}
+	err = withTimeout(ctx, timeout, fmt.Sprintf("creating divert ingress '%s'", divertIngress.Name), func(ctx context.Context) error {
+		if err := ingressesv1.Deploy(ctx, divertIngress, c); err != nil {
+			return fmt.Errorf("error creating divert ingress '%s': %s", divertIngress.Name, err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return divertIngress, nil
 }
 
-func createDivertCRD(ctx context.Context, dev *model.Dev, username string, i *networkingv1.Ingress, s *apiv1.Service) error {
+func createDivertCRD(ctx context.Context, dev *model.Dev, username string, ingresses []*networkingv1.Ingress, s *apiv1.Service, dryRun bool, timeout time.Duration) error {
+	divertCRD := translateDivertCRD(username, dev, s, ingresses)
+	if dryRun {
+		return printDryRun("Divert", divertCRD.Name, divertCRD)
+	}
+
 	dClient, err := GetClient(dev.Context)
 	if err != nil {
 		return fmt.Errorf("error creating divert CRD client: %s", err.Error())
 	}
+	return applyDivertCRD(ctx, dClient.Diverts(divertCRD.Namespace), divertCRD, timeout)
+}
 
-	divertCRD := translateDivertCRD(username, dev, s, i)
-
-	old, err := dClient.Diverts(divertCRD.Namespace).Get(ctx, divertCRD.Name, metav1.GetOptions{})
-	if err != nil && !errors.IsNotFound(err) {
-		return fmt.Errorf("error getting divert CRD '%s'': %s", divertCRD.Name, err)
+//applyDivertCRD creates or updates a divert CRD, preserving the annotations and labels set by other controllers
+func applyDivertCRD(ctx context.Context, dClient DivertInterface, divertCRD *Divert, timeout time.Duration) error {
+	var old *Divert
+	err := withTimeout(ctx, timeout, fmt.Sprintf("getting divert CRD '%s'", divertCRD.Name), func(ctx context.Context) error {
+		var err error
+		old, err = dClient.Get(ctx, divertCRD.Name, metav1.GetOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("error getting divert CRD '%s'': %s", divertCRD.Name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	if old.Name == "" {
+	if old == nil || old.Name == "" {
 		log.Infof("creating  divert CRD '%s'", divertCRD.Name)
-		_, err = dClient.Diverts(divertCRD.Namespace).Create(ctx, divertCRD)
+		err = withTimeout(ctx, timeout, fmt.Sprintf("creating divert CRD '%s'", divertCRD.Name), func(ctx context.Context) error {
+			if _, err := dClient.Create(ctx, divertCRD); err != nil {
+				return fmt.Errorf("error creating divert CRD '%s': %s", divertCRD.Name, err)
+			}
+			return nil
+		})
 		if err != nil {
-			return fmt.Errorf("error creating divert CRD '%s': %s", divertCRD.Name, err)
+			return err
 		}
 		log.Infof("created divert CRD '%s'", divertCRD.Name)
 	} else {
 		log.Infof("updating divert CRD '%s'", divertCRD.Name)
 		old.TypeMeta = divertCRD.TypeMeta
-		old.Annotations = divertCRD.Annotations
-		old.Labels = divertCRD.Labels
+		old.Annotations = mergeMetadata(old.Annotations, divertCRD.Annotations)
+		old.Labels = mergeMetadata(old.Labels, divertCRD.Labels)
 		old.Spec = divertCRD.Spec
 		old.Status = DivertStatus{}
-		_, err = dClient.Diverts(divertCRD.Namespace).Update(ctx, old)
+		err = withTimeout(ctx, timeout, fmt.Sprintf("updating divert CRD '%s'", divertCRD.Name), func(ctx context.Context) error {
+			if _, err := dClient.Update(ctx, old); err != nil {
+				return fmt.Errorf("error updating divert CRD '%s': %s", divertCRD.Name, err)
+			}
+			return nil
+		})
 		if err != nil {
-			return fmt.Errorf("error updating divert CRD '%s': %s", divertCRD.Name, err)
+			return err
 		}
 		log.Infof("updated divert CRD '%s'.", divertCRD.Name)
 	}
@@ -140,32 +219,84 @@ func createDivertCRD(ctx context.Context, dev *model.Dev, username string, i *ne
 	return nil
 }
 
-func Delete(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
-	username := okteto.GetSanitizedUsername()
+//printDryRun prints the manifest of a divert resource that would be created or updated
+func printDryRun(kind, name string, obj interface{}) error {
+	bytes, err := yaml.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("error marshalling %s '%s': %s", strings.ToLower(kind), name, err.Error())
+	}
+	log.Information("Dry run: %s '%s' would be created or updated", strings.ToLower(kind), name)
+	fmt.Printf("---\n%s", string(bytes))
+	return nil
+}
+
+//mergeMetadata returns the current annotations or labels of a resource overridden by the ones managed by okteto
+func mergeMetadata(current, managed map[string]string) map[string]string {
+	if len(current) == 0 && len(managed) == 0 {
+		return managed
+	}
+	result := map[string]string{}
+	for k, v := range current {
+		result[k] = v
+	}
+	for k, v := range managed {
+		result[k] = v
+	}
+	return result
+}
+
+//Delete deletes the divert resources of a development container.
+//In dry-run mode, it prints the divert resources that would be deleted instead of deleting them
+func Delete(ctx context.Context, dev *model.Dev, dryRun bool, c kubernetes.Interface) error {
+	username := okteto.GetDivertKey(dev)
+	if dryRun {
+		log.Information("Dry run: divert CRD '%s' would be deleted", model.DivertName(dev.Divert.Service, username))
+		for _, name := range dev.Divert.Ingress {
+			log.Information("Dry run: ingress '%s' would be deleted", model.DivertName(name, username))
+		}
+		log.Information("Dry run: service '%s' would be deleted", model.DivertName(dev.Divert.Service, username))
+		return nil
+	}
 
 	dClient, err := GetClient(dev.Context)
 	if err != nil {
 		return fmt.Errorf("error creating divert CRD client: %s", err.Error())
 	}
+	timeout := getCallTimeout(dev)
 	divertCRDName := model.DivertName(dev.Divert.Service, username)
-	if err := dClient.Diverts(dev.Namespace).Delete(ctx, divertCRDName, metav1.DeleteOptions{}); err != nil {
-		if strings.Contains(err.Error(), "the server could not find the requested resource") {
-			return errors.ErrDivertNotSupported
-		}
-		if !errors.IsNotFound(err) {
-			return fmt.Errorf("error deleting divert CRD '%s': %s", divertCRDName, err.Error())
+	err = withTimeout(ctx, timeout, fmt.Sprintf("deleting divert CRD '%s'", divertCRDName), func(ctx context.Context) error {
+		if err := dClient.Diverts(dev.Namespace).Delete(ctx, divertCRDName, metav1.DeleteOptions{}); err != nil {
+			if strings.Contains(err.Error(), "the server could not find the requested resource") {
+				return errors.ErrDivertNotSupported
+			}
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error deleting divert CRD '%s': %s", divertCRDName, err.Error())
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	iName := model.DivertName(dev.Divert.Ingress, username)
-	if err := ingressesv1.Destroy(ctx, iName, dev.Namespace, c); err != nil {
-		return fmt.Errorf("error deleting divert ingress '%s': %s", iName, err.Error())
+	for _, name := range dev.Divert.Ingress {
+		iName := model.DivertName(name, username)
+		err := withTimeout(ctx, timeout, fmt.Sprintf("deleting divert ingress '%s'", iName), func(ctx context.Context) error {
+			if err := ingressesv1.Destroy(ctx, iName, dev.Namespace, c); err != nil {
+				return fmt.Errorf("error deleting divert ingress '%s': %s", iName, err.Error())
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
 	}
 
 	sName := model.DivertName(dev.Divert.Service, username)
-	if err := services.Destroy(ctx, sName, dev.Namespace, c); err != nil {
-		return fmt.Errorf("error deleting divert service '%s': %s", sName, err.Error())
-	}
-
-	return nil
+	return withTimeout(ctx, timeout, fmt.Sprintf("deleting divert service '%s'", sName), func(ctx context.Context) error {
+		if err := services.Destroy(ctx, sName, dev.Namespace, c); err != nil {
+			return fmt.Errorf("error deleting divert service '%s': %s", sName, err.Error())
+		}
+		return nil
+	})
 }
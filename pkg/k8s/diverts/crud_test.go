@@ -0,0 +1,80 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diverts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_applyDivertCRDPreservesForeignMetadata(t *testing.T) {
+	ctx := context.Background()
+	old := newTestDivert("api-cindy", "api", "cindy")
+	old.Annotations = map[string]string{
+		"cert-manager.io/cluster-issuer": "letsencrypt",
+		model.DeployedByLabel:            "old",
+	}
+	old.Labels = map[string]string{"mesh.io/injected": "true"}
+	dClient := &fakeDivertClient{diverts: map[string]*Divert{old.Name: old}}
+
+	divertCRD := newTestDivert("api-cindy", "api", "cindy")
+	divertCRD.Annotations = map[string]string{model.DeployedByLabel: "new"}
+	divertCRD.Labels = map[string]string{model.DeployedByLabel: "app"}
+	divertCRD.Spec.ToService.Port = 8080
+
+	if err := applyDivertCRD(ctx, dClient, divertCRD, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	updated := dClient.diverts["api-cindy"]
+	if updated.Annotations["cert-manager.io/cluster-issuer"] != "letsencrypt" {
+		t.Errorf("foreign annotation was not preserved: %v", updated.Annotations)
+	}
+	if updated.Annotations[model.DeployedByLabel] != "new" {
+		t.Errorf("okteto annotation was not updated: %v", updated.Annotations)
+	}
+	if updated.Labels["mesh.io/injected"] != "true" || updated.Labels[model.DeployedByLabel] != "app" {
+		t.Errorf("labels were not merged: %v", updated.Labels)
+	}
+	if updated.Spec.ToService.Port != 8080 {
+		t.Errorf("spec was not updated: %+v", updated.Spec)
+	}
+}
+
+func Test_divertServiceDryRun(t *testing.T) {
+	ctx := context.Background()
+	svc := &apiv1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"}}
+	c := fake.NewSimpleClientset(svc)
+	dev := &model.Dev{Name: "api", Namespace: "test", Divert: &model.Divert{Service: "api", Port: 8080}}
+	app := apps.NewDeploymentApp(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api-cindy", Namespace: "test"}})
+
+	s, err := divertService(ctx, dev, app, "cindy", true, time.Minute, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Name != "api-cindy" {
+		t.Errorf("expected translated service 'api-cindy', got '%s'", s.Name)
+	}
+	if _, err := c.CoreV1().Services("test").Get(ctx, "api-cindy", metav1.GetOptions{}); err == nil {
+		t.Errorf("service 'api-cindy' was created in dry-run mode")
+	}
+}
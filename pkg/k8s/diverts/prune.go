@@ -0,0 +1,134 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diverts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/ingressesv1"
+	"github.com/okteto/okteto/pkg/k8s/services"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+//pruneOrphans deletes the divert resources of a divert key whose development container doesn't exist anymore.
+//The divert CRD named 'current' is skipped, as it is about to be recreated
+func pruneOrphans(ctx context.Context, dClient DivertInterface, namespace, username, current string, dryRun bool, timeout time.Duration, c kubernetes.Interface) error {
+	var dList *DivertList
+	err := withTimeout(ctx, timeout, "listing divert CRDs", func(ctx context.Context) error {
+		var err error
+		dList, err = dClient.List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("error listing divert CRDs: %s", err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range dList.Items {
+		d := &dList.Items[i]
+		if d.Name == current || d.Spec.Ingress.Value != username {
+			continue
+		}
+
+		var orphan bool
+		err := withTimeout(ctx, timeout, fmt.Sprintf("getting the diverted application of divert CRD '%s'", d.Name), func(ctx context.Context) error {
+			var err error
+			orphan, err = isOrphan(ctx, d, namespace, c)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if !orphan {
+			continue
+		}
+
+		if dryRun {
+			log.Information("Dry run: orphaned divert CRD '%s' would be deleted", d.Name)
+			continue
+		}
+
+		log.Infof("deleting orphaned divert CRD '%s'", d.Name)
+		err = withTimeout(ctx, timeout, fmt.Sprintf("deleting orphaned divert CRD '%s'", d.Name), func(ctx context.Context) error {
+			return deleteDivertResources(ctx, dClient, d, namespace, c)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//isOrphan returns if the diverted app of a divert CRD doesn't exist or it isn't in dev mode
+func isOrphan(ctx context.Context, d *Divert, namespace string, c kubernetes.Interface) (bool, error) {
+	appName := model.DivertName(d.Spec.Deployment.Name, d.Spec.Ingress.Value)
+
+	deployment, err := deployments.Get(ctx, appName, namespace, c)
+	if err == nil {
+		return deployment.Labels[model.DevLabel] != "true", nil
+	}
+	if !errors.IsNotFound(err) {
+		return false, fmt.Errorf("error getting diverted deployment '%s': %s", appName, err.Error())
+	}
+
+	sfs, err := statefulsets.Get(ctx, appName, namespace, c)
+	if err == nil {
+		return sfs.Labels[model.DevLabel] != "true", nil
+	}
+	if !errors.IsNotFound(err) {
+		return false, fmt.Errorf("error getting diverted statefulset '%s': %s", appName, err.Error())
+	}
+	return true, nil
+}
+
+func deleteDivertResources(ctx context.Context, dClient DivertInterface, d *Divert, namespace string, c kubernetes.Interface) error {
+	if err := dClient.Delete(ctx, d.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("error deleting divert CRD '%s': %s", d.Name, err.Error())
+	}
+
+	for _, name := range divertIngressNames(d) {
+		if err := ingressesv1.Destroy(ctx, name, namespace, c); err != nil {
+			return fmt.Errorf("error deleting divert ingress '%s': %s", name, err.Error())
+		}
+	}
+
+	if err := services.Destroy(ctx, d.Spec.ToService.Name, namespace, c); err != nil {
+		return fmt.Errorf("error deleting divert service '%s': %s", d.Spec.ToService.Name, err.Error())
+	}
+	return nil
+}
+
+//divertIngressNames returns the names of the ingresses of a divert CRD, including the ones created by versions that only set 'spec.ingress'
+func divertIngressNames(d *Divert) []string {
+	result := []string{}
+	if d.Spec.Ingress.Name != "" {
+		result = append(result, d.Spec.Ingress.Name)
+	}
+	for _, i := range d.Spec.Ingresses {
+		if i.Name != "" && i.Name != d.Spec.Ingress.Name {
+			result = append(result, i.Name)
+		}
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diverts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeDivertClient struct {
+	diverts map[string]*Divert
+}
+
+func (f *fakeDivertClient) List(_ context.Context, _ metav1.ListOptions) (*DivertList, error) {
+	result := &DivertList{}
+	for _, d := range f.diverts {
+		result.Items = append(result.Items, *d)
+	}
+	return result, nil
+}
+
+func (f *fakeDivertClient) Get(_ context.Context, name string, _ metav1.GetOptions) (*Divert, error) {
+	d, ok := f.diverts[name]
+	if !ok {
+		return &Divert{}, errors.ErrNotFound
+	}
+	return d, nil
+}
+
+func (f *fakeDivertClient) Create(_ context.Context, d *Divert) (*Divert, error) {
+	f.diverts[d.Name] = d
+	return d, nil
+}
+
+func (f *fakeDivertClient) Update(_ context.Context, d *Divert) (*Divert, error) {
+	f.diverts[d.Name] = d
+	return d, nil
+}
+
+func (f *fakeDivertClient) Delete(_ context.Context, name string, _ metav1.DeleteOptions) error {
+	delete(f.diverts, name)
+	return nil
+}
+
+func newTestDivert(name, deployment, username string) *Divert {
+	return &Divert{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+		Spec: DivertSpec{
+			Ingress:    IngressDivertSpec{Value: username},
+			ToService:  ServiceDivertSpec{Name: name},
+			Deployment: DeploymentDivertSpec{Name: deployment},
+		},
+	}
+}
+
+func Test_pruneOrphans(t *testing.T) {
+	ctx := context.Background()
+	alive := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      model.DivertName("api", "cindy"),
+			Namespace: "test",
+			Labels:    map[string]string{model.DevLabel: "true"},
+		},
+	}
+	stopped := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      model.DivertName("web", "cindy"),
+			Namespace: "test",
+		},
+	}
+	orphanService := &apiv1.Service{ObjectMeta: metav1.ObjectMeta{Name: "worker-cindy", Namespace: "test"}}
+	c := fake.NewSimpleClientset(alive, stopped, orphanService)
+
+	dClient := &fakeDivertClient{
+		diverts: map[string]*Divert{
+			"api-cindy":    newTestDivert("api-cindy", "api", "cindy"),
+			"web-cindy":    newTestDivert("web-cindy", "web", "cindy"),
+			"worker-cindy": newTestDivert("worker-cindy", "worker", "cindy"),
+			"worker-bob":   newTestDivert("worker-bob", "worker", "bob"),
+			"db-cindy":     newTestDivert("db-cindy", "db", "cindy"),
+		},
+	}
+
+	if err := pruneOrphans(ctx, dClient, "test", "cindy", "db-cindy", false, time.Minute, c); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"api-cindy", "worker-bob", "db-cindy"}
+	if len(dClient.diverts) != len(expected) {
+		t.Errorf("expected %d diverts, got %d", len(expected), len(dClient.diverts))
+	}
+	for _, name := range expected {
+		if _, ok := dClient.diverts[name]; !ok {
+			t.Errorf("divert '%s' was pruned", name)
+		}
+	}
+
+	if _, err := c.CoreV1().Services("test").Get(ctx, "worker-cindy", metav1.GetOptions{}); err == nil {
+		t.Errorf("orphaned service 'worker-cindy' was not deleted")
+	}
+}
+
+func Test_divertIngressNames(t *testing.T) {
+	d := &Divert{
+		Spec: DivertSpec{
+			Ingress:   IngressDivertSpec{Name: "api-cindy"},
+			Ingresses: []IngressDivertSpec{{Name: "api-cindy"}, {Name: "web-cindy"}},
+		},
+	}
+	names := divertIngressNames(d)
+	if len(names) != 2 || names[0] != "api-cindy" || names[1] != "web-cindy" {
+		t.Errorf("wrong ingress names: %v", names)
+	}
+
+	if names := divertIngressNames(&Divert{}); len(names) != 0 {
+		t.Errorf("expected no ingress names, got %v", names)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diverts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+//defaultCallTimeout is the deadline of each call to the Kubernetes API when the development container doesn't define 'timeout.default'
+const defaultCallTimeout = 60 * time.Second
+
+//getCallTimeout returns the deadline of each call to the Kubernetes API done to divert a development container
+func getCallTimeout(dev *model.Dev) time.Duration {
+	if dev.Timeout.Default > 0 {
+		return dev.Timeout.Default
+	}
+	return defaultCallTimeout
+}
+
+//withTimeout runs a call to the Kubernetes API bounded by timeout.
+//If the deadline is exceeded, it returns an error describing the call that timed out
+func withTimeout(ctx context.Context, timeout time.Duration, description string, call func(ctx context.Context) error) error {
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := call(callCtx)
+	if err != nil && ctx.Err() == nil && callCtx.Err() == context.DeadlineExceeded {
+		return errors.UserError{
+			E:    fmt.Errorf("timeout %s after %s", description, timeout),
+			Hint: "Check that the Kubernetes API server is responsive or increase 'timeout.default' in your okteto manifest",
+		}
+	}
+	return err
+}
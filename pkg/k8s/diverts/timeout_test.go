@@ -0,0 +1,68 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diverts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+func Test_withTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	err := withTimeout(ctx, 10*time.Millisecond, "getting divert CRD 'api-cindy'", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	uErr, ok := err.(errors.UserError)
+	if !ok {
+		t.Fatalf("expected a user error, got %v", err)
+	}
+	if !strings.Contains(uErr.E.Error(), "timeout getting divert CRD 'api-cindy'") {
+		t.Errorf("wrong timeout error: %s", uErr.E)
+	}
+
+	expected := fmt.Errorf("error getting divert CRD 'api-cindy': forbidden")
+	err = withTimeout(ctx, time.Minute, "getting divert CRD 'api-cindy'", func(ctx context.Context) error {
+		return expected
+	})
+	if err != expected {
+		t.Errorf("expected the error of the call, got %v", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = withTimeout(canceled, time.Minute, "getting divert CRD 'api-cindy'", func(ctx context.Context) error {
+		return ctx.Err()
+	})
+	if err != context.Canceled {
+		t.Errorf("expected the cancellation error, got %v", err)
+	}
+}
+
+func Test_getCallTimeout(t *testing.T) {
+	if timeout := getCallTimeout(&model.Dev{}); timeout != defaultCallTimeout {
+		t.Errorf("expected default timeout, got %s", timeout)
+	}
+	dev := &model.Dev{Timeout: model.Timeout{Default: 5 * time.Second}}
+	if timeout := getCallTimeout(dev); timeout != 5*time.Second {
+		t.Errorf("expected 5s timeout, got %s", timeout)
+	}
+}
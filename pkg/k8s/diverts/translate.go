@@ -118,7 +118,13 @@ func translateIngress(username string, i *networkingv1.Ingress) *networkingv1.In
 	return result
 }
 
-func translateDivertCRD(username string, dev *model.Dev, s *apiv1.Service, i *networkingv1.Ingress) *Divert {
+//translateDivertCRD returns the divert CRD. The ingresses are empty when only the service is diverted.
+//'spec.ingress' keeps the first ingress for controllers that don't support 'spec.ingresses'
+func translateDivertCRD(username string, dev *model.Dev, s *apiv1.Service, ingresses []*networkingv1.Ingress) *Divert {
+	ingressName := ""
+	if len(ingresses) > 0 {
+		ingressName = ingresses[0].Name
+	}
 	result := &Divert{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Divert",
@@ -130,7 +136,7 @@ func translateDivertCRD(username string, dev *model.Dev, s *apiv1.Service, i *ne
 		},
 		Spec: DivertSpec{
 			Ingress: IngressDivertSpec{
-				Name:      i.Name,
+				Name:      ingressName,
 				Namespace: dev.Namespace,
 				Value:     username,
 			},
@@ -150,6 +156,16 @@ func translateDivertCRD(username string, dev *model.Dev, s *apiv1.Service, i *ne
 			},
 		},
 	}
+	for _, i := range ingresses {
+		result.Spec.Ingresses = append(
+			result.Spec.Ingresses,
+			IngressDivertSpec{
+				Name:      i.Name,
+				Namespace: dev.Namespace,
+				Value:     username,
+			},
+		)
+	}
 	if s.Labels != nil && s.Labels[model.DeployedByLabel] != "" {
 		result.Labels = map[string]string{model.DeployedByLabel: s.Labels[model.DeployedByLabel]}
 	}
@@ -249,3 +249,60 @@ func Test_translateIngressCustomGenerateHost(t *testing.T) {
 		t.Fatalf("Wrong translation.\nActual %+v, \nExpected %+v", string(marshalled), string(marshalledExpected))
 	}
 }
+
+func Test_translateDivertCRDWithoutIngress(t *testing.T) {
+	dev := &model.Dev{
+		Name:      "grpc",
+		Namespace: "namespace",
+		Divert:    &model.Divert{Service: "grpc", Port: 9090},
+	}
+	s := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "grpc-cindy",
+			Namespace: "namespace",
+		},
+	}
+	translated := translateDivertCRD("cindy", dev, s, nil)
+	if translated.Name != "grpc-cindy" {
+		t.Errorf("wrong divert name: %s", translated.Name)
+	}
+	if translated.Spec.Ingress.Name != "" {
+		t.Errorf("ingress should be empty, got '%s'", translated.Spec.Ingress.Name)
+	}
+	if translated.Spec.Ingress.Value != "cindy" {
+		t.Errorf("wrong divert header value: %s", translated.Spec.Ingress.Value)
+	}
+	if translated.Spec.FromService.Name != "grpc" || translated.Spec.ToService.Name != "grpc-cindy" {
+		t.Errorf("wrong divert services: %+v, %+v", translated.Spec.FromService, translated.Spec.ToService)
+	}
+}
+
+func Test_translateDivertCRDWithIngresses(t *testing.T) {
+	dev := &model.Dev{
+		Name:      "web",
+		Namespace: "namespace",
+		Divert:    &model.Divert{Service: "web", Ingress: model.DivertIngresses{"api", "web"}, Port: 8080},
+	}
+	s := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-cindy",
+			Namespace: "namespace",
+		},
+	}
+	ingresses := []*networkingv1.Ingress{
+		{ObjectMeta: metav1.ObjectMeta{Name: "api-cindy"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-cindy"}},
+	}
+	translated := translateDivertCRD("cindy", dev, s, ingresses)
+	if translated.Spec.Ingress.Name != "api-cindy" {
+		t.Errorf("wrong first ingress: %s", translated.Spec.Ingress.Name)
+	}
+	if len(translated.Spec.Ingresses) != 2 {
+		t.Fatalf("expected 2 ingresses, got %d", len(translated.Spec.Ingresses))
+	}
+	for i, name := range []string{"api-cindy", "web-cindy"} {
+		if translated.Spec.Ingresses[i].Name != name || translated.Spec.Ingresses[i].Value != "cindy" {
+			t.Errorf("wrong ingress %d: %+v", i, translated.Spec.Ingresses[i])
+		}
+	}
+}
@@ -37,6 +37,7 @@ type DeploymentDivertSpec struct {
 
 type DivertSpec struct {
 	Ingress     IngressDivertSpec    `json:"ingress"`
+	Ingresses   []IngressDivertSpec  `json:"ingresses,omitempty"`
 	FromService ServiceDivertSpec    `json:"fromService"`
 	ToService   ServiceDivertSpec    `json:"toService"`
 	Deployment  DeploymentDivertSpec `json:"deployment"`
@@ -70,7 +71,7 @@ func (in *Divert) DeepCopyInto(out *Divert) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
@@ -127,6 +128,10 @@ func (in *DivertList) DeepCopyObject() runtime.Object {
 // DeepCopyInto a deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DivertSpec) DeepCopyInto(out *DivertSpec) {
 	*out = *in
+	if in.Ingresses != nil {
+		out.Ingresses = make([]IngressDivertSpec, len(in.Ingresses))
+		copy(out.Ingresses, in.Ingresses)
+	}
 }
 
 // DeepCopy a deepcopy function, copying the receiver, creating a new DivertSpec.
@@ -0,0 +1,70 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diverts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/ingressesv1"
+	"github.com/okteto/okteto/pkg/k8s/services"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+)
+
+//Validate checks that the service and ingress referred by the divert section of a development container exist
+func Validate(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
+	if dev.Divert == nil {
+		return nil
+	}
+
+	timeout := getCallTimeout(dev)
+	err := withTimeout(ctx, timeout, fmt.Sprintf("getting divert service '%s'", dev.Divert.Service), func(ctx context.Context) error {
+		if _, err := services.Get(ctx, dev.Divert.Service, dev.Namespace, c); err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error getting divert service '%s': %s", dev.Divert.Service, err.Error())
+			}
+			return errors.UserError{
+				E: fmt.Errorf("the divert service '%s' doesn't exist in namespace '%s'", dev.Divert.Service, dev.Namespace),
+				Hint: `Verify the value of 'divert.service' in your okteto manifest and that your application has been deployed
+    More information is available here: https://okteto.com/docs/reference/manifest/#divert`,
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range dev.Divert.Ingress {
+		err := withTimeout(ctx, timeout, fmt.Sprintf("getting divert ingress '%s'", name), func(ctx context.Context) error {
+			if _, err := ingressesv1.Get(ctx, name, dev.Namespace, c); err != nil {
+				if !errors.IsNotFound(err) {
+					return fmt.Errorf("error getting divert ingress '%s': %s", name, err.Error())
+				}
+				return errors.UserError{
+					E: fmt.Errorf("the divert ingress '%s' doesn't exist in namespace '%s'", name, dev.Namespace),
+					Hint: `Verify the value of 'divert.ingress' in your okteto manifest and that your application has been deployed
+    More information is available here: https://okteto.com/docs/reference/manifest/#divert`,
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diverts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestValidate(t *testing.T) {
+	svc := &apiv1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"}}
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test"}}
+	c := fake.NewSimpleClientset(svc, ingress)
+
+	var tests = []struct {
+		name      string
+		divert    *model.Divert
+		userError bool
+	}{
+		{name: "no-divert", divert: nil},
+		{name: "service", divert: &model.Divert{Service: "api"}},
+		{name: "service-and-ingress", divert: &model.Divert{Service: "api", Ingress: model.DivertIngresses{"web"}}},
+		{name: "missing-service", divert: &model.Divert{Service: "worker"}, userError: true},
+		{name: "missing-ingress", divert: &model.Divert{Service: "api", Ingress: model.DivertIngresses{"web", "admin"}}, userError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &model.Dev{Name: "api", Namespace: "test", Divert: tt.divert}
+			err := Validate(context.Background(), dev, c)
+			if !tt.userError {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if _, ok := err.(errors.UserError); !ok {
+				t.Fatalf("expected a user error, got %v", err)
+			}
+		})
+	}
+}
@@ -20,8 +20,11 @@ import (
 	"io"
 	"net/http"
 	"runtime"
+	"sync"
 	"time"
 
+	"github.com/okteto/okteto/pkg/errors"
+
 	"github.com/okteto/okteto/pkg/k8s/labels"
 	"github.com/okteto/okteto/pkg/k8s/pods"
 	"github.com/okteto/okteto/pkg/k8s/services"
@@ -35,13 +38,25 @@ import (
 	"k8s.io/client-go/transport/spdy"
 )
 
+var (
+	// maxForwardRetries is the number of consecutive attempts to re-establish a dead forward to the development container
+	// before the connection is reported as lost
+	maxForwardRetries = 3
+
+	// forwardRetryInterval is the time between the attempts to re-establish a dead forward to the development container
+	forwardRetryInterval = 2 * time.Second
+)
+
 // PortForwardManager keeps a list of all the active port forwards
 type PortForwardManager struct {
+	mu             sync.Mutex
 	stopped        bool
+	disconnect     chan error
 	iface          string
 	ports          map[int]model.Forward
+	sockets        map[string]*socketProxy
 	services       map[string]struct{}
-	activeDev      *active
+	activeDev      map[string]*active
 	activeServices map[string]*active
 	ctx            context.Context
 	restConfig     *rest.Config
@@ -70,6 +85,19 @@ func (a *active) closeReady() {
 	}
 }
 
+// isReady returns true if the port forwarder was established
+func (a *active) isReady() bool {
+	if a == nil || a.readyChan == nil {
+		return false
+	}
+	select {
+	case <-a.readyChan:
+		return true
+	default:
+		return false
+	}
+}
+
 func (a *active) error() error {
 	if a != nil {
 		return a.err
@@ -84,6 +112,7 @@ func NewPortForwardManager(ctx context.Context, iface string, restConfig *rest.C
 		ctx:        ctx,
 		iface:      iface,
 		ports:      make(map[int]model.Forward),
+		sockets:    make(map[string]*socketProxy),
 		services:   make(map[string]struct{}),
 		restConfig: restConfig,
 		client:     c,
@@ -91,13 +120,23 @@ func NewPortForwardManager(ctx context.Context, iface string, restConfig *rest.C
 	}
 }
 
+// SetDisconnect sets the channel where the manager reports that a forward to the development container
+// couldn't be re-established after maxForwardRetries attempts
+func (p *PortForwardManager) SetDisconnect(disconnect chan error) {
+	p.disconnect = disconnect
+}
+
 // Add initializes a port forward
 func (p *PortForwardManager) Add(f model.Forward) error {
+	if f.IsSocket() {
+		return p.addSocket(f)
+	}
+
 	if _, ok := p.ports[f.Local]; ok {
 		return fmt.Errorf("port %d is listed multiple times, please check your configuration", f.Local)
 	}
 
-	if !model.IsPortAvailable(p.iface, f.Local) {
+	if !model.IsPortAvailable(p.getBindAddress(f), f.Local) {
 		if f.Local <= 1024 {
 			os := runtime.GOOS
 			switch os {
@@ -118,38 +157,93 @@ func (p *PortForwardManager) Add(f model.Forward) error {
 	return nil
 }
 
+// addSocket initializes a port forward from a local UNIX socket.
+// The socket proxies its connections to a port forward on a random local port
+func (p *PortForwardManager) addSocket(f model.Forward) error {
+	if _, ok := p.sockets[f.Socket]; ok {
+		return fmt.Errorf("socket %s is listed multiple times, please check your configuration", f.Socket)
+	}
+
+	if err := model.CheckSocketAvailable(f.Socket); err != nil {
+		return err
+	}
+
+	port, err := model.GetAvailablePort(model.Localhost)
+	if err != nil {
+		return fmt.Errorf("failed to get a local port for socket %s: %w", f.Socket, err)
+	}
+
+	f.Local = port
+	f.BindAddress = model.Localhost
+	p.ports[port] = f
+	p.sockets[f.Socket] = &socketProxy{
+		path:   f.Socket,
+		target: fmt.Sprintf("%s:%d", model.Localhost, port),
+	}
+	if f.Service {
+		p.services[f.ServiceName] = struct{}{}
+	}
+
+	return nil
+}
+
 // AddReverse is not implemented
 func (p *PortForwardManager) AddReverse(_ model.Reverse) error {
 	return fmt.Errorf("not implemented")
 }
 
-// Start starts all the port forwarders to the development container
+// Start starts all the port forwarders to the development container.
+// A port forwarder is started for each of the local bind addresses of the forwards
 func (p *PortForwardManager) Start(devPod, namespace string) error {
+	p.mu.Lock()
 	p.stopped = false
-	a, devPF, err := p.buildForwarderToDevPod(namespace, devPod)
-	if err != nil {
-		return fmt.Errorf("failed to k8s forward to development container: %w", err)
-	}
-
-	p.activeDev = a
-	go func() {
-		err := devPF.ForwardPorts()
+	p.activeDev = map[string]*active{}
+	p.mu.Unlock()
+	forwardsByAddress := p.getForwardsByAddress()
+	for address, forwards := range forwardsByAddress {
+		if len(getDevPodPorts(forwards)) == 0 {
+			continue
+		}
+		a, devPF, err := p.buildForwarderToDevPod(namespace, devPod, address, forwards)
 		if err != nil {
-			log.Infof("k8s forwarding to dev pod finished with errors: %s", err)
-			p.activeDev.closeReady()
-			p.activeDev.err = err
+			return fmt.Errorf("failed to k8s forward to development container: %w", err)
 		}
-	}()
+
+		p.mu.Lock()
+		p.activeDev[address] = a
+		p.mu.Unlock()
+		go p.forwardDevPod(namespace, devPod, address, forwards, a, devPF)
+	}
 
 	p.activeServices = map[string]*active{}
-	for svc := range p.services {
-		go p.forwardService(p.ctx, namespace, svc)
+	for address, forwards := range forwardsByAddress {
+		for svc := range p.services {
+			if len(getServicePorts(svc, forwards)) > 0 {
+				go p.forwardService(p.ctx, namespace, svc, address)
+			}
+		}
 	}
 
-	<-p.activeDev.readyChan
+	p.mu.Lock()
+	activeDev := make([]*active, 0, len(p.activeDev))
+	for _, a := range p.activeDev {
+		activeDev = append(activeDev, a)
+	}
+	p.mu.Unlock()
+	for _, a := range activeDev {
+		<-a.readyChan
 
-	if err := p.activeDev.error(); err != nil {
-		return err
+		if err := a.error(); err != nil {
+			return err
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, s := range p.sockets {
+		if err := s.start(); err != nil {
+			return fmt.Errorf("failed to listen on socket %s: %w", s.path, err)
+		}
 	}
 
 	log.Infof("all k8s port-forwards are connected")
@@ -158,18 +252,111 @@ func (p *PortForwardManager) Start(devPod, namespace string) error {
 
 // Stop stops all the port forwarders
 func (p *PortForwardManager) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.stopped = true
-	p.activeDev.stop()
+	for _, a := range p.activeDev {
+		a.stop()
+	}
 
 	for _, a := range p.activeServices {
 		a.stop()
 	}
 
+	for _, s := range p.sockets {
+		s.stop()
+	}
+
 	p.activeServices = nil
 	p.activeDev = nil
 	log.Infof("stopped k8s forwarder")
 }
 
+// forwardDevPod runs a port forwarder to the development container.
+// A forwarder that dies is re-established against the same pod, and the connection is reported as lost
+// after maxForwardRetries consecutive failures
+func (p *PortForwardManager) forwardDevPod(namespace, devPod, address string, forwards map[int]model.Forward, a *active, pf *portforward.PortForwarder) {
+	established := false
+	failures := 0
+	for {
+		var err error
+		if pf != nil {
+			err = pf.ForwardPorts()
+			if !p.isActive(address, a) {
+				return
+			}
+			if err == nil {
+				err = fmt.Errorf("lost connection to pod")
+			}
+			log.Infof("k8s forwarding to dev pod on %s finished with errors: %s", address, err)
+
+			if !established && !a.isReady() {
+				// the first forwarder is reported by Start
+				a.err = err
+				a.closeReady()
+				return
+			}
+			if a.isReady() {
+				established = true
+				failures = 0
+				log.Yellow("Port forward to your development container on %s was lost, reconnecting...", address)
+			}
+		}
+
+		failures++
+		if failures > maxForwardRetries {
+			log.Infof("failed to re-establish the k8s forwarding to dev pod on %s: %s", address, err)
+			p.reportDisconnect()
+			return
+		}
+
+		select {
+		case <-time.After(forwardRetryInterval):
+		case <-p.ctx.Done():
+			return
+		}
+
+		newActive, newPF, err := p.buildForwarderToDevPod(namespace, devPod, address, forwards)
+		if err != nil {
+			log.Infof("failed to k8s forward to dev pod on %s: %s", address, err)
+			pf = nil
+			continue
+		}
+		if !p.replaceActive(address, a, newActive) {
+			return
+		}
+		a, pf = newActive, newPF
+	}
+}
+
+// isActive returns true if a is the running forwarder on address
+func (p *PortForwardManager) isActive(address string, a *active) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return !p.stopped && p.activeDev[address] == a
+}
+
+// replaceActive replaces the forwarder on address, unless the manager was stopped
+func (p *PortForwardManager) replaceActive(address string, old, next *active) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped || p.activeDev[address] != old {
+		return false
+	}
+	p.activeDev[address] = next
+	return true
+}
+
+func (p *PortForwardManager) reportDisconnect() {
+	if p.disconnect == nil {
+		return
+	}
+	select {
+	case p.disconnect <- errors.ErrLostPortForward:
+	default:
+	}
+}
+
 func (fm *PortForwardManager) TransformLabelsToServiceName(f model.Forward) (model.Forward, error) {
 	serviceName, err := fm.GetServiceNameByLabel(fm.namespace, f.Labels)
 	if err != nil {
@@ -179,18 +366,42 @@ func (fm *PortForwardManager) TransformLabelsToServiceName(f model.Forward) (mod
 	return f, nil
 }
 
-func (p *PortForwardManager) buildForwarderToDevPod(namespace, pod string) (*active, *portforward.PortForwarder, error) {
+// getBindAddress returns the local address where a forward listens, the interface of the manager by default
+func (p *PortForwardManager) getBindAddress(f model.Forward) string {
+	if f.BindAddress != "" {
+		return f.BindAddress
+	}
+	return p.iface
+}
+
+// getForwardsByAddress groups the forwards by their local bind address
+func (p *PortForwardManager) getForwardsByAddress() map[string]map[int]model.Forward {
+	result := map[string]map[int]model.Forward{}
+	for local, f := range p.ports {
+		address := p.getBindAddress(f)
+		if _, ok := result[address]; !ok {
+			result[address] = map[int]model.Forward{}
+		}
+		result[address][local] = f
+	}
+	return result
+}
+
+func (p *PortForwardManager) buildForwarderToDevPod(namespace, pod, address string, forwards map[int]model.Forward) (*active, *portforward.PortForwarder, error) {
+	return p.buildForwarder(namespace, pod, address, getDevPodPorts(forwards))
+}
+
+func getDevPodPorts(forwards map[int]model.Forward) []string {
 	ports := []string{}
-	for _, f := range p.ports {
+	for _, f := range forwards {
 		if !f.Service {
 			ports = append(ports, fmt.Sprintf("%d:%d", f.Local, f.Remote))
 		}
 	}
-
-	return p.buildForwarder(namespace, pod, ports)
+	return ports
 }
 
-func (p *PortForwardManager) buildForwarder(namespace, pod string, ports []string) (*active, *portforward.PortForwarder, error) {
+func (p *PortForwardManager) buildForwarder(namespace, pod, address string, ports []string) (*active, *portforward.PortForwarder, error) {
 	dialer, err := p.buildDialer(namespace, pod)
 	if err != nil {
 		return nil, nil, err
@@ -204,7 +415,7 @@ func (p *PortForwardManager) buildForwarder(namespace, pod string, ports []strin
 
 	pf, err := portforward.NewOnAddresses(
 		dialer,
-		[]string{p.iface},
+		[]string{address},
 		ports,
 		a.stopChan,
 		a.readyChan,
@@ -218,7 +429,7 @@ func (p *PortForwardManager) buildForwarder(namespace, pod string, ports []strin
 	return a, pf, nil
 }
 
-func (p *PortForwardManager) buildForwarderToService(ctx context.Context, namespace, service string) (*active, *portforward.PortForwarder, error) {
+func (p *PortForwardManager) buildForwarderToService(ctx context.Context, namespace, service, address string) (*active, *portforward.PortForwarder, error) {
 	svc, err := services.Get(ctx, service, namespace, p.client)
 	if err != nil {
 		return nil, nil, err
@@ -233,8 +444,8 @@ func (p *PortForwardManager) buildForwarderToService(ctx context.Context, namesp
 		return nil, nil, fmt.Errorf("failed to get pod mapped to service/%s: %w", svc.GetName(), err)
 	}
 
-	ports := getServicePorts(svc.GetName(), p.ports)
-	return p.buildForwarder(pod.GetNamespace(), pod.GetName(), ports)
+	ports := getServicePorts(svc.GetName(), p.getForwardsByAddress()[address])
+	return p.buildForwarder(pod.GetNamespace(), pod.GetName(), address, ports)
 }
 
 func getServicePorts(service string, forwards map[int]model.Forward) []string {
@@ -250,16 +461,16 @@ func getServicePorts(service string, forwards map[int]model.Forward) []string {
 }
 
 func (p *PortForwardManager) buildDialer(namespace, pod string) (httpstream.Dialer, error) {
+	if p.restConfig == nil {
+		return nil, fmt.Errorf("restConfig is nil")
+	}
+
 	url := p.client.CoreV1().RESTClient().Post().
 		Resource("pods").
 		Namespace(namespace).
 		Name(pod).
 		SubResource("portforward").URL()
 
-	if p.restConfig == nil {
-		return nil, fmt.Errorf("restConfig is nil")
-	}
-
 	transport, upgrader, err := spdy.RoundTripperFor(p.restConfig)
 	if err != nil {
 		return nil, err
@@ -268,7 +479,7 @@ func (p *PortForwardManager) buildDialer(namespace, pod string) (httpstream.Dial
 	return spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url), nil
 }
 
-func (p *PortForwardManager) forwardService(ctx context.Context, namespace, service string) {
+func (p *PortForwardManager) forwardService(ctx context.Context, namespace, service, address string) {
 	t := time.NewTicker(3 * time.Second)
 
 	for {
@@ -277,7 +488,7 @@ func (p *PortForwardManager) forwardService(ctx context.Context, namespace, serv
 		}
 
 		log.Infof("k8s forwarding ports for service/%s", service)
-		a, pf, err := p.buildForwarderToService(ctx, namespace, service)
+		a, pf, err := p.buildForwarderToService(ctx, namespace, service, address)
 		if err != nil {
 			log.Infof("failed to k8s forward ports to service/%s: %s", service, err)
 			<-t.C
@@ -15,10 +15,17 @@ package forward
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
+	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model"
 )
 
@@ -52,9 +59,11 @@ func TestAdd(t *testing.T) {
 
 func TestStop(t *testing.T) {
 	pf := NewPortForwardManager(context.Background(), model.Localhost, nil, nil, "")
-	pf.activeDev = &active{
-		readyChan: make(chan struct{}, 1),
-		stopChan:  make(chan struct{}, 1),
+	pf.activeDev = map[string]*active{
+		model.Localhost: {
+			readyChan: make(chan struct{}, 1),
+			stopChan:  make(chan struct{}, 1),
+		},
 	}
 
 	pf.activeServices = map[string]*active{
@@ -183,3 +192,145 @@ func Test_getServicePorts(t *testing.T) {
 		})
 	}
 }
+
+func Test_getForwardsByAddress(t *testing.T) {
+	pf := NewPortForwardManager(context.Background(), model.Localhost, nil, nil, "")
+	pf.ports = map[int]model.Forward{
+		8080:  {Local: 8080, Remote: 8080},
+		9090:  {Local: 9090, Remote: 9090, BindAddress: "0.0.0.0"},
+		22000: {Local: 22000, Remote: 22000},
+		5432:  {Local: 5432, Remote: 5432, ServiceName: "db", Service: true, BindAddress: "0.0.0.0"},
+	}
+
+	result := pf.getForwardsByAddress()
+	if len(result) != 2 {
+		t.Fatalf("expected 2 addresses, got %+v", result)
+	}
+
+	ports := getDevPodPorts(result[model.Localhost])
+	sort.Strings(ports)
+	if !reflect.DeepEqual(ports, []string{"22000:22000", "8080:8080"}) {
+		t.Errorf("unexpected ports on localhost: %+v", ports)
+	}
+
+	if ports := getDevPodPorts(result["0.0.0.0"]); !reflect.DeepEqual(ports, []string{"9090:9090"}) {
+		t.Errorf("unexpected ports on 0.0.0.0: %+v", ports)
+	}
+
+	if ports := getServicePorts("db", result["0.0.0.0"]); !reflect.DeepEqual(ports, []string{"5432:5432"}) {
+		t.Errorf("unexpected service ports on 0.0.0.0: %+v", ports)
+	}
+}
+
+func Test_forwardDevPodReportsDisconnect(t *testing.T) {
+	forwardRetryInterval = time.Millisecond
+	defer func() { forwardRetryInterval = 2 * time.Second }()
+
+	disconnect := make(chan error, 1)
+	pf := NewPortForwardManager(context.Background(), model.Localhost, nil, nil, "")
+	pf.SetDisconnect(disconnect)
+	a := &active{readyChan: make(chan struct{}, 1), stopChan: make(chan struct{}, 1)}
+	pf.activeDev = map[string]*active{model.Localhost: a}
+
+	// the forwarder can't be re-established without a rest config
+	pf.forwardDevPod("namespace", "pod", model.Localhost, map[int]model.Forward{8080: {Local: 8080, Remote: 8080}}, a, nil)
+
+	select {
+	case err := <-disconnect:
+		if err != errors.ErrLostPortForward {
+			t.Errorf("expected '%s', got '%s'", errors.ErrLostPortForward, err)
+		}
+	default:
+		t.Fatal("the lost forward wasn't reported")
+	}
+}
+
+func Test_replaceActive(t *testing.T) {
+	pf := NewPortForwardManager(context.Background(), model.Localhost, nil, nil, "")
+	a := &active{}
+	b := &active{}
+	pf.activeDev = map[string]*active{model.Localhost: a}
+
+	if !pf.replaceActive(model.Localhost, a, b) {
+		t.Fatal("the forwarder wasn't replaced")
+	}
+	if !pf.isActive(model.Localhost, b) || pf.isActive(model.Localhost, a) {
+		t.Fatal("the new forwarder isn't the active one")
+	}
+	if pf.replaceActive(model.Localhost, a, &active{}) {
+		t.Fatal("replaced a forwarder that wasn't active")
+	}
+
+	pf.Stop()
+	if pf.isActive(model.Localhost, b) {
+		t.Fatal("forwarder active after stopping the manager")
+	}
+}
+
+func TestAddSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+	pf := NewPortForwardManager(context.Background(), model.Localhost, nil, nil, "")
+	if err := pf.Add(model.Forward{Socket: path, Remote: 5432}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pf.Add(model.Forward{Socket: path, Remote: 5433}); err == nil {
+		t.Fatal("duplicated socket didn't return an error")
+	}
+
+	if len(pf.ports) != 1 {
+		t.Fatalf("expected 1 port but got %d", len(pf.ports))
+	}
+
+	for port, f := range pf.ports {
+		if f.Local != port || f.Remote != 5432 || f.BindAddress != model.Localhost {
+			t.Errorf("wrong port forward for socket: %+v", f)
+		}
+		if pf.sockets[path].target != fmt.Sprintf("%s:%d", model.Localhost, port) {
+			t.Errorf("socket proxies to '%s' instead of port %d", pf.sockets[path].target, port)
+		}
+	}
+}
+
+func Test_socketProxy(t *testing.T) {
+	l, err := net.Listen("tcp", fmt.Sprintf("%s:0", model.Localhost))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = io.Copy(conn, conn)
+	}()
+
+	path := filepath.Join(t.TempDir(), "app.sock")
+	s := &socketProxy{path: path, target: l.Addr().String()}
+	if err := s.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]byte, 4)
+	if _, err := io.ReadFull(conn, got); err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "ping" {
+		t.Errorf("got '%s' through the socket, expected 'ping'", got)
+	}
+
+	s.stop()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("socket %s wasn't removed: %v", path, err)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log"
+)
+
+// socketProxy proxies the connections to a local UNIX socket to the local port of a port forward
+type socketProxy struct {
+	path     string
+	target   string
+	listener net.Listener
+}
+
+// start listens on the local UNIX socket, replacing any leftover socket file
+func (s *socketProxy) start() error {
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	l, err := net.Listen("unix", s.path)
+	if err != nil {
+		return err
+	}
+
+	s.listener = l
+	go s.serve(l)
+	return nil
+}
+
+// stop closes the listener, which also removes the socket file
+func (s *socketProxy) stop() {
+	if s.listener == nil {
+		return
+	}
+
+	if err := s.listener.Close(); err != nil {
+		log.Infof("failed to close socket %s: %s", s.path, err)
+	}
+	s.listener = nil
+}
+
+func (s *socketProxy) serve(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.IsClosedNetwork(err) {
+				return
+			}
+			log.Infof("socket %s failed to accept connection: %s", s.path, err)
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *socketProxy) handle(local net.Conn) {
+	defer local.Close()
+
+	remote, err := net.Dial("tcp", s.target)
+	if err != nil {
+		log.Infof("socket %s failed to dial %s: %s", s.path, s.target, err)
+		return
+	}
+	defer remote.Close()
+
+	done := make(chan struct{}, 2)
+	go transfer(remote, local, done)
+	go transfer(local, remote, done)
+	<-done
+}
+
+func transfer(to io.Writer, from io.Reader, done chan struct{}) {
+	if _, err := io.Copy(to, from); err != nil && !errors.IsClosedNetwork(err) {
+		log.Infof("socket data transfer failed: %s", err)
+	}
+	done <- struct{}{}
+}
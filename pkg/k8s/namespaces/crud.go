@@ -0,0 +1,56 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Get returns a namespace object by name
+func Get(ctx context.Context, name string, c kubernetes.Interface) (*apiv1.Namespace, error) {
+	return c.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+}
+
+// List returns the names of the namespaces of the cluster
+func List(ctx context.Context, c kubernetes.Interface) ([]string, error) {
+	nsList, err := c.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(nsList.Items))
+	for _, ns := range nsList.Items {
+		result = append(result, ns.Name)
+	}
+	return result, nil
+}
+
+// IsDeleted returns true if the namespace doesn't exist anymore or is being deleted.
+// Errors other than not found (e.g. forbidden) are not considered a deleted namespace
+func IsDeleted(ctx context.Context, name string, c kubernetes.Interface) bool {
+	ns, err := Get(ctx, name, c)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return true
+		}
+		log.Infof("failed to get namespace '%s': %s", name, err)
+		return false
+	}
+	return ns.DeletionTimestamp != nil || ns.Status.Phase == apiv1.NamespaceTerminating
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestIsDeleted(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		&apiv1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "active"},
+			Status:     apiv1.NamespaceStatus{Phase: apiv1.NamespaceActive},
+		},
+		&apiv1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "terminating"},
+			Status:     apiv1.NamespaceStatus{Phase: apiv1.NamespaceTerminating},
+		},
+	)
+
+	var tests = []struct {
+		name      string
+		namespace string
+		expected  bool
+	}{
+		{name: "active", namespace: "active", expected: false},
+		{name: "terminating", namespace: "terminating", expected: true},
+		{name: "not-found", namespace: "missing", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsDeleted(ctx, tt.namespace, clientset); result != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev"}},
+		&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod"}},
+	)
+
+	result, err := List(context.Background(), clientset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 2 || result[0] != "dev" || result[1] != "prod" {
+		t.Errorf("expected [dev prod], got %v", result)
+	}
+}
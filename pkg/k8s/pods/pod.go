@@ -31,6 +31,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/utils/pointer"
@@ -101,27 +102,78 @@ func GetPodByReplicaSet(ctx context.Context, rs *appsv1.ReplicaSet, c kubernetes
 	return nil, errors.ErrNotFound
 }
 
-//GetPodByReplicaSet returns a pod of a given replicaset
+//GetPodByStatefulSet returns the pod of a given statefulset with the lowest ordinal, skipping pods of old revisions
 func GetPodByStatefulSet(ctx context.Context, sfs *appsv1.StatefulSet, c kubernetes.Interface) (*apiv1.Pod, error) {
 	podList, err := c.CoreV1().Pods(sfs.Namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
+	var result *apiv1.Pod
+	resultOrdinal := -1
 	for i := range podList.Items {
 		if podList.Items[i].DeletionTimestamp != nil {
 			continue
 		}
-		if sfs.Status.UpdateRevision == podList.Items[i].Labels[appsv1.StatefulSetRevisionLabel] {
-			for _, or := range podList.Items[i].OwnerReferences {
-				if or.UID == sfs.UID {
-					return &podList.Items[i], nil
-				}
-			}
+		if sfs.Status.UpdateRevision != podList.Items[i].Labels[appsv1.StatefulSetRevisionLabel] {
+			continue
+		}
+		if !isOwnedBy(&podList.Items[i], sfs.UID) {
+			continue
+		}
+		ordinal := getStatefulSetPodOrdinal(sfs.Name, podList.Items[i].Name)
+		if result == nil || (ordinal >= 0 && (resultOrdinal < 0 || ordinal < resultOrdinal)) {
+			result = &podList.Items[i]
+			resultOrdinal = ordinal
+		}
+	}
+	if result == nil {
+		return nil, errors.ErrNotFound
+	}
+	return result, nil
+}
+
+//GetPodByDaemonSet returns the pod of a given daemonset running on a node, or on any node if node is empty
+func GetPodByDaemonSet(ctx context.Context, ds *appsv1.DaemonSet, node string, c kubernetes.Interface) (*apiv1.Pod, error) {
+	podList, err := c.CoreV1().Pods(ds.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range podList.Items {
+		if podList.Items[i].DeletionTimestamp != nil {
+			continue
+		}
+		if node != "" && podList.Items[i].Spec.NodeName != node {
+			continue
+		}
+		if isOwnedBy(&podList.Items[i], ds.UID) {
+			return &podList.Items[i], nil
 		}
 	}
 	return nil, errors.ErrNotFound
 }
 
+func isOwnedBy(p *apiv1.Pod, uid types.UID) bool {
+	for _, or := range p.OwnerReferences {
+		if or.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// getStatefulSetPodOrdinal returns the ordinal of a statefulset pod named '<statefulset>-<ordinal>', or -1 if the name doesn't follow that pattern
+func getStatefulSetPodOrdinal(sfsName, podName string) int {
+	prefix := fmt.Sprintf("%s-", sfsName)
+	if !strings.HasPrefix(podName, prefix) {
+		return -1
+	}
+	ordinal, err := strconv.Atoi(strings.TrimPrefix(podName, prefix))
+	if err != nil || ordinal < 0 {
+		return -1
+	}
+	return ordinal
+}
+
 //GetUserByPod returns the current user of a running pod
 func GetUserByPod(ctx context.Context, p *apiv1.Pod, container string, config *rest.Config, c *kubernetes.Clientset) (int64, error) {
 	cmd := []string{"sh", "-c", "id -u"}
@@ -274,6 +326,19 @@ func ContainerLogs(ctx context.Context, containerName, podName, namespace string
 	return buf.String(), nil
 }
 
+//StreamLogs writes the logs of a container in a pod to w until the stream ends or ctx is cancelled
+func StreamLogs(ctx context.Context, pod *apiv1.Pod, opts *apiv1.PodLogOptions, c kubernetes.Interface, w io.Writer) error {
+	req := c.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts)
+	logsStream, err := req.Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer logsStream.Close()
+
+	_, err = io.Copy(w, logsStream)
+	return err
+}
+
 // Restart restarts the pods of a deployment
 func Restart(ctx context.Context, dev *model.Dev, c *kubernetes.Clientset, sn string) error {
 	pods, err := c.CoreV1().Pods(dev.Namespace).List(
@@ -391,6 +456,16 @@ func isRunning(p *apiv1.Pod) bool {
 	return false
 }
 
+//CheckCrashLoopBackOff returns an error if any of the containers of a pod is in CrashLoopBackOff
+func CheckCrashLoopBackOff(p *apiv1.Pod) error {
+	for _, cs := range p.Status.ContainerStatuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
+			return fmt.Errorf("container '%s' of pod '%s' is crashing: %s", cs.Name, p.Name, cs.State.Waiting.Message)
+		}
+	}
+	return nil
+}
+
 func GetHealthcheckFailure(ctx context.Context, namespace, svcName, stackName string, c kubernetes.Interface) string {
 	selector := fmt.Sprintf("%s=%s,%s=%s", model.StackNameLabel, stackName, model.StackServiceNameLabel, svcName)
 	pods, err := c.CoreV1().Pods(namespace).List(
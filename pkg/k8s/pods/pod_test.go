@@ -14,11 +14,15 @@
 package pods
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
+	"github.com/okteto/okteto/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 )
 
@@ -179,3 +183,125 @@ func Test_parseUserID(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPodByStatefulSet(t *testing.T) {
+	sfs := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "test", UID: "sfs-uid"},
+		Status:     appsv1.StatefulSetStatus{UpdateRevision: "db-2"},
+	}
+	newPod := func(name, revision string, uid types.UID) *apiv1.Pod {
+		return &apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "test",
+				Labels:          map[string]string{appsv1.StatefulSetRevisionLabel: revision},
+				OwnerReferences: []metav1.OwnerReference{{UID: uid}},
+			},
+		}
+	}
+
+	var tests = []struct {
+		name     string
+		pods     []*apiv1.Pod
+		expected string
+	}{
+		{
+			name:     "single-pod",
+			pods:     []*apiv1.Pod{newPod("db-0", "db-2", "sfs-uid")},
+			expected: "db-0",
+		},
+		{
+			name: "lowest-ordinal",
+			pods: []*apiv1.Pod{
+				newPod("db-10", "db-2", "sfs-uid"),
+				newPod("db-2", "db-2", "sfs-uid"),
+				newPod("db-1", "db-2", "sfs-uid"),
+			},
+			expected: "db-1",
+		},
+		{
+			name: "skip-old-revision",
+			pods: []*apiv1.Pod{
+				newPod("db-0", "db-1", "sfs-uid"),
+				newPod("db-1", "db-2", "sfs-uid"),
+			},
+			expected: "db-1",
+		},
+		{
+			name: "skip-other-owner",
+			pods: []*apiv1.Pod{
+				newPod("db-0", "db-2", "other-uid"),
+				newPod("db-3", "db-2", "sfs-uid"),
+			},
+			expected: "db-3",
+		},
+		{
+			name:     "not-found",
+			pods:     []*apiv1.Pod{newPod("db-0", "db-1", "sfs-uid")},
+			expected: "",
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset(ns)
+			for _, p := range tt.pods {
+				if err := c.Tracker().Add(p); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			p, err := GetPodByStatefulSet(ctx, sfs, c)
+			if tt.expected == "" {
+				if !errors.IsNotFound(err) {
+					t.Fatalf("expected not found error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Name != tt.expected {
+				t.Fatalf("expected %s but got %s", tt.expected, p.Name)
+			}
+		})
+	}
+}
+
+func Test_getStatefulSetPodOrdinal(t *testing.T) {
+	var tests = []struct {
+		podName  string
+		expected int
+	}{
+		{podName: "db-0", expected: 0},
+		{podName: "db-12", expected: 12},
+		{podName: "db-okteto-0", expected: -1},
+		{podName: "db", expected: -1},
+		{podName: "api-0", expected: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.podName, func(t *testing.T) {
+			if got := getStatefulSetPodOrdinal("db", tt.podName); got != tt.expected {
+				t.Fatalf("expected %d but got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dev",
+			Namespace: "test",
+		},
+	}
+	c := fake.NewSimpleClientset(pod)
+	buf := &bytes.Buffer{}
+	if err := StreamLogs(context.Background(), pod, &apiv1.PodLogOptions{Container: "dev"}, c, buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "fake logs" {
+		t.Fatalf("expected 'fake logs', got '%s'", buf.String())
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+func Test_getConfigXMLMaxConflicts(t *testing.T) {
+	s := &syncthing.Syncthing{
+		MaxConflicts: "-1",
+		Folders:      []*syncthing.Folder{{Name: "1", RemotePath: "/app"}},
+	}
+	b, err := getConfigXML(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// conflict copies are collected from the local folder only, the remote folder must not keep them
+	if !strings.Contains(string(b), "<maxConflicts>0</maxConflicts>") {
+		t.Errorf("remote config doesn't disable conflict copies:\n%s", string(b))
+	}
+}
@@ -19,10 +19,12 @@ import (
 	"os"
 	"strings"
 
+	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/syncthing"
 	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -107,6 +109,29 @@ func Destroy(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error
 	return nil
 }
 
+// CheckImagePullSecrets returns an error if an image pull secret of the development containers doesn't exist.
+// The check is skipped if the user is not allowed to read the secrets of the namespace
+func CheckImagePullSecrets(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
+	devs := append([]*model.Dev{dev}, dev.Services...)
+	for _, d := range devs {
+		for _, name := range d.ImagePullSecrets {
+			_, err := c.CoreV1().Secrets(dev.Namespace).Get(ctx, name, metav1.GetOptions{})
+			if err == nil {
+				continue
+			}
+			if !k8sErrors.IsNotFound(err) {
+				log.Infof("failed to check the image pull secret '%s': %s", name, err)
+				continue
+			}
+			return errors.UserError{
+				E:    fmt.Errorf("the image pull secret '%s' of the development container '%s' doesn't exist in the namespace '%s'", name, d.Name, dev.Namespace),
+				Hint: fmt.Sprintf("Create it with 'kubectl create secret docker-registry %s --docker-server=<registry> --docker-username=<user> --docker-password=<password> -n %s' or update the field 'imagePullSecrets' of your okteto manifest", name, dev.Namespace),
+			}
+		}
+	}
+	return nil
+}
+
 // GetSecretName returns the okteto secret name for a given development container
 func GetSecretName(dev *model.Dev) string {
 	return fmt.Sprintf(oktetoSecretTemplate, dev.Name)
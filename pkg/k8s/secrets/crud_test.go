@@ -0,0 +1,84 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_CheckImagePullSecrets(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "artifactory", Namespace: "test"}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "other"}},
+	)
+
+	var tests = []struct {
+		name      string
+		dev       *model.Dev
+		expectErr bool
+	}{
+		{
+			name: "no-secrets",
+			dev:  &model.Dev{Name: "api", Namespace: "test"},
+		},
+		{
+			name: "existing-secret",
+			dev:  &model.Dev{Name: "api", Namespace: "test", ImagePullSecrets: []string{"artifactory"}},
+		},
+		{
+			name:      "missing-secret",
+			dev:       &model.Dev{Name: "api", Namespace: "test", ImagePullSecrets: []string{"artifactory", "missing"}},
+			expectErr: true,
+		},
+		{
+			name:      "secret-in-other-namespace",
+			dev:       &model.Dev{Name: "api", Namespace: "test", ImagePullSecrets: []string{"other-namespace"}},
+			expectErr: true,
+		},
+		{
+			name: "missing-secret-in-service",
+			dev: &model.Dev{
+				Name:      "api",
+				Namespace: "test",
+				Services:  []*model.Dev{{Name: "worker", ImagePullSecrets: []string{"missing"}}},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckImagePullSecrets(context.Background(), tt.dev, c)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't get the expected error")
+				}
+				if _, ok := err.(errors.UserError); !ok {
+					t.Fatalf("expected a user error, got %T", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -160,6 +160,24 @@ func IsRunning(ctx context.Context, namespace, svcName string, c kubernetes.Inte
 	return sfs.Status.ReadyReplicas > 0
 }
 
+//IsRolledOut returns if the latest revision of a statefulset is ready, following the same rules as "kubectl rollout status"
+func IsRolledOut(sfs *appsv1.StatefulSet) bool {
+	if sfs.Generation > sfs.Status.ObservedGeneration {
+		return false
+	}
+	replicas := int32(1)
+	if sfs.Spec.Replicas != nil {
+		replicas = *sfs.Spec.Replicas
+	}
+	if sfs.Status.ReadyReplicas < replicas {
+		return false
+	}
+	if sfs.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType && sfs.Status.UpdateRevision != sfs.Status.CurrentRevision {
+		return false
+	}
+	return true
+}
+
 //IsDevModeOn returns if a statefulset is in devmode
 func IsDevModeOn(s *appsv1.StatefulSet) bool {
 	labels := s.GetObjectMeta().GetLabels()
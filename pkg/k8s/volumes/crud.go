@@ -26,6 +26,7 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 
 	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -47,7 +48,7 @@ func List(ctx context.Context, namespace, labels string, c kubernetes.Interface)
 }
 
 // CreateForDev deploys the volume claim for a given development container
-func CreateForDev(ctx context.Context, dev *model.Dev, c *kubernetes.Clientset, devPath string) error {
+func CreateForDev(ctx context.Context, dev *model.Dev, c kubernetes.Interface, devPath string) error {
 	vClient := c.CoreV1().PersistentVolumeClaims(dev.Namespace)
 	pvc := translate(dev)
 	k8Volume, err := vClient.Get(ctx, pvc.Name, metav1.GetOptions{})
@@ -55,6 +56,9 @@ func CreateForDev(ctx context.Context, dev *model.Dev, c *kubernetes.Clientset,
 		return fmt.Errorf("error getting kubernetes volume claim: %s", err)
 	}
 	if k8Volume.Name == "" {
+		if err := checkStorageClass(ctx, dev.PersistentVolumeStorageClass(), c); err != nil {
+			return err
+		}
 		log.Infof("creating volume claim '%s'", pvc.Name)
 		_, err = vClient.Create(ctx, pvc, metav1.CreateOptions{})
 		if err != nil {
@@ -77,6 +81,39 @@ func CreateForDev(ctx context.Context, dev *model.Dev, c *kubernetes.Clientset,
 	return nil
 }
 
+// checkStorageClass returns an error if the storage class of the persistent volume doesn't exist.
+// The check is skipped if the user is not allowed to read the storage classes of the cluster
+func checkStorageClass(ctx context.Context, storageClass string, c kubernetes.Interface) error {
+	if storageClass == "" {
+		return nil
+	}
+	_, err := c.StorageV1().StorageClasses().Get(ctx, storageClass, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !k8sErrors.IsNotFound(err) {
+		log.Infof("failed to check the storage class '%s': %s", storageClass, err)
+		return nil
+	}
+
+	hint := "Update the field 'persistentVolume.storageClass' of your okteto manifest"
+	if classes, err := c.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{}); err == nil && len(classes.Items) > 0 {
+		names := make([]string, 0, len(classes.Items))
+		for _, class := range classes.Items {
+			name := class.Name
+			if class.Annotations[model.DefaultStorageClassAnnotation] == "true" {
+				name = fmt.Sprintf("%s (default)", name)
+			}
+			names = append(names, name)
+		}
+		hint = fmt.Sprintf("%s. The available storage classes are: %s", hint, strings.Join(names, ", "))
+	}
+	return errors.UserError{
+		E:    fmt.Errorf("the storage class '%s' of your persistent volume doesn't exist", storageClass),
+		Hint: hint,
+	}
+}
+
 func Create(ctx context.Context, pvc *apiv1.PersistentVolumeClaim, c kubernetes.Interface) error {
 	_, err := c.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(ctx, pvc, metav1.CreateOptions{})
 	if err != nil {
@@ -14,11 +14,17 @@
 package volumes
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model"
 	apiv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func Test_checkPVCValues(t *testing.T) {
@@ -160,3 +166,37 @@ func Test_checkPVCValues(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkStorageClass(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(
+		&storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "standard",
+				Annotations: map[string]string{model.DefaultStorageClassAnnotation: "true"},
+			},
+		},
+		&storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "ssd"},
+		},
+	)
+
+	if err := checkStorageClass(ctx, "", c); err != nil {
+		t.Errorf("unexpected error without storage class: %s", err)
+	}
+	if err := checkStorageClass(ctx, "ssd", c); err != nil {
+		t.Errorf("unexpected error for an existing storage class: %s", err)
+	}
+
+	err := checkStorageClass(ctx, "fast", c)
+	if err == nil {
+		t.Fatal("expected an error for a missing storage class")
+	}
+	userErr, ok := err.(errors.UserError)
+	if !ok {
+		t.Fatalf("expected a user error, got %T", err)
+	}
+	if !strings.Contains(userErr.Hint, "ssd, standard (default)") {
+		t.Errorf("expected the available storage classes in the hint, got '%s'", userErr.Hint)
+	}
+}
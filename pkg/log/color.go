@@ -0,0 +1,52 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+)
+
+const (
+	// ColorAuto colors the output only if stdout is a terminal and NO_COLOR is not set
+	ColorAuto = "auto"
+
+	// ColorAlways always colors the output
+	ColorAlways = "always"
+
+	// ColorNever never colors the output
+	ColorNever = "never"
+)
+
+// colorByDefault is false if stdout is not a terminal or NO_COLOR is set
+var colorByDefault = !color.NoColor
+
+// ConfigureColor sets when the output of the command is colored
+func ConfigureColor(mode string) error {
+	switch mode {
+	case ColorAuto:
+		color.NoColor = !colorByDefault
+	case ColorAlways:
+		color.NoColor = false
+	case ColorNever:
+		color.NoColor = true
+	default:
+		return fmt.Errorf("invalid color mode '%s': accepted values are '%s', '%s' and '%s'", mode, ColorAuto, ColorAlways, ColorNever)
+	}
+	initSymbols()
+	textFormatter.DisableColors = color.NoColor
+	textFormatter.ForceColors = !color.NoColor
+	return nil
+}
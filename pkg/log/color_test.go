@@ -0,0 +1,66 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestConfigureColor(t *testing.T) {
+	defer func() {
+		if err := ConfigureColor(ColorAuto); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	var tests = []struct {
+		name      string
+		mode      string
+		expectErr bool
+		noColor   bool
+	}{
+		{name: "always", mode: ColorAlways, noColor: false},
+		{name: "never", mode: ColorNever, noColor: true},
+		{name: "auto", mode: ColorAuto, noColor: !colorByDefault},
+		{name: "invalid", mode: "sometimes", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ConfigureColor(tt.mode)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if color.NoColor != tt.noColor {
+				t.Errorf("expected NoColor %t, got %t", tt.noColor, color.NoColor)
+			}
+			if textFormatter.DisableColors != tt.noColor {
+				t.Errorf("expected DisableColors %t, got %t", tt.noColor, textFormatter.DisableColors)
+			}
+			hasEscape := strings.Contains(errorSymbol, "\x1b[")
+			if hasEscape == tt.noColor {
+				t.Errorf("unexpected error symbol %q", errorSymbol)
+			}
+		})
+	}
+}
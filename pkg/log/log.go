@@ -35,28 +35,46 @@ var (
 
 	blueString = color.New(color.FgHiBlue).SprintfFunc()
 
-	errorSymbol = color.New(color.BgHiRed, color.FgBlack).Sprint(" x ")
+	errorSymbol string
 
-	successSymbol = color.New(color.BgGreen, color.FgBlack).Sprint(" ✓ ")
+	successSymbol string
 
-	informationSymbol = color.New(color.BgHiBlue, color.FgBlack).Sprint(" i ")
+	informationSymbol string
 
-	warningSymbol = color.New(color.BgHiYellow, color.FgBlack).Sprint(" ! ")
+	warningSymbol string
 )
 
 type logger struct {
-	out  *logrus.Logger
-	file *logrus.Entry
+	out     *logrus.Logger
+	file    *logrus.Entry
+	logFile *os.File
+
+	// outputLevel is the threshold of the messages printed by Information, Success, Hint, Warning and the color helpers
+	outputLevel logrus.Level
 }
 
 var log = &logger{
-	out: logrus.New(),
+	out:         logrus.New(),
+	outputLevel: logrus.InfoLevel,
 }
 
+var textFormatter = &logrus.TextFormatter{}
+
+var actionID = uuid.New().String()
+
 func init() {
+	log.out.SetFormatter(&redactFormatter{formatter: textFormatter})
+	initSymbols()
+}
+
+func initSymbols() {
+	errorSymbol = color.New(color.BgHiRed, color.FgBlack).Sprint(" x ")
+	successSymbol = color.New(color.BgGreen, color.FgBlack).Sprint(" ✓ ")
 	if runtime.GOOS == "windows" {
 		successSymbol = color.New(color.BgGreen, color.FgBlack).Sprint(" + ")
 	}
+	informationSymbol = color.New(color.BgHiBlue, color.FgBlack).Sprint(" i ")
+	warningSymbol = color.New(color.BgHiYellow, color.FgBlack).Sprint(" ! ")
 }
 
 // Init configures the logger for the package to use.
@@ -66,19 +84,53 @@ func Init(level logrus.Level) {
 }
 
 func ConfigureFileLogger(dir, version string) {
+	logPath := filepath.Join(dir, "okteto.log")
+	var output io.Writer = getRollingLog(logPath)
+	if log.logFile != nil {
+		output = io.MultiWriter(output, log.logFile)
+	}
+	log.file = newFileLogger(output, version)
+}
+
+// ConfigureLogFile writes the debug-level logs of the command to the given file, truncating it first.
+// The output on the terminal is not affected
+func ConfigureLogFile(path, version string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to open the log file '%s': %w", path, err)
+	}
+	log.logFile = f
+	log.file = newFileLogger(f, version)
+	return nil
+}
+
+// CloseLogFile flushes and closes the file configured by ConfigureLogFile, if any
+func CloseLogFile() {
+	if log.logFile == nil {
+		return
+	}
+	f := log.logFile
+	log.logFile = nil
+	log.file = nil
+	if err := f.Sync(); err != nil {
+		Infof("failed to flush the log file '%s': %s", f.Name(), err)
+	}
+	if err := f.Close(); err != nil {
+		Infof("failed to close the log file '%s': %s", f.Name(), err)
+	}
+}
+
+func newFileLogger(output io.Writer, version string) *logrus.Entry {
 	fileLogger := logrus.New()
-	fileLogger.SetFormatter(&logrus.TextFormatter{
-		DisableColors: true,
-		FullTimestamp: true,
+	fileLogger.SetFormatter(&redactFormatter{
+		formatter: &logrus.TextFormatter{
+			DisableColors: true,
+			FullTimestamp: true,
+		},
 	})
-
-	logPath := filepath.Join(dir, "okteto.log")
-	rolling := getRollingLog(logPath)
-	fileLogger.SetOutput(rolling)
+	fileLogger.SetOutput(output)
 	fileLogger.SetLevel(logrus.DebugLevel)
-
-	actionID := uuid.New().String()
-	log.file = fileLogger.WithFields(logrus.Fields{"action": actionID, "version": version})
+	return fileLogger.WithFields(logrus.Fields{"action": actionID, "version": version})
 }
 
 func getRollingLog(path string) io.Writer {
@@ -99,6 +151,19 @@ func SetLevel(level string) {
 	}
 }
 
+// SetOutputLevel sets the threshold of the messages printed to the terminal.
+// Information, Success, Hint and Green are printed at info or more verbose levels, and Warning and Yellow at warn or more verbose levels
+func SetOutputLevel(level string) {
+	l, err := logrus.ParseLevel(level)
+	if err == nil {
+		log.outputLevel = l
+	}
+}
+
+func isOutputEnabled(level logrus.Level) bool {
+	return log.outputLevel >= level
+}
+
 // IsDebug checks if the level of the main logger is DEBUG or TRACE
 func IsDebug() bool {
 	return log.out.GetLevel() >= logrus.DebugLevel
@@ -164,15 +229,27 @@ func Fatalf(format string, args ...interface{}) {
 // Yellow writes a line in yellow
 func Yellow(format string, args ...interface{}) {
 	log.out.Infof(format, args...)
+	if !isOutputEnabled(logrus.WarnLevel) {
+		return
+	}
 	fmt.Fprintln(color.Output, yellowString(format, args...))
 }
 
 // Green writes a line in green
 func Green(format string, args ...interface{}) {
 	log.out.Infof(format, args...)
+	if !isOutputEnabled(logrus.InfoLevel) {
+		return
+	}
 	fmt.Fprintln(color.Output, greenString(format, args...))
 }
 
+// Red writes a line in red
+func Red(format string, args ...interface{}) {
+	log.out.Infof(format, args...)
+	fmt.Fprintln(color.Output, redString(format, args...))
+}
+
 // BlueString returns a string in blue
 func BlueString(format string, args ...interface{}) string {
 	return blueString(format, args...)
@@ -181,24 +258,36 @@ func BlueString(format string, args ...interface{}) string {
 // Success prints a message with the success symbol first, and the text in green
 func Success(format string, args ...interface{}) {
 	log.out.Infof(format, args...)
+	if !isOutputEnabled(logrus.InfoLevel) {
+		return
+	}
 	fmt.Fprintf(color.Output, "%s %s\n", successSymbol, greenString(format, args...))
 }
 
 // Information prints a message with the information symbol first, and the text in blue
 func Information(format string, args ...interface{}) {
 	log.out.Infof(format, args...)
+	if !isOutputEnabled(logrus.InfoLevel) {
+		return
+	}
 	fmt.Fprintf(color.Output, "%s %s\n", informationSymbol, blueString(format, args...))
 }
 
 // Warning prints a message with the warning symbol first, and the text in yellow
 func Warning(format string, args ...interface{}) {
 	log.out.Infof(format, args...)
+	if !isOutputEnabled(logrus.WarnLevel) {
+		return
+	}
 	fmt.Fprintf(color.Output, "%s %s\n", warningSymbol, yellowString(format, args...))
 }
 
 // Hint prints a message with the text in blue
 func Hint(format string, args ...interface{}) {
 	log.out.Infof(format, args...)
+	if !isOutputEnabled(logrus.InfoLevel) {
+		return
+	}
 	fmt.Fprintf(color.Output, "%s\n", blueString(format, args...))
 }
 
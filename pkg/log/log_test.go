@@ -0,0 +1,87 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestCloseLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "okteto.log")
+	if err := ConfigureLogFile(path, "test"); err != nil {
+		t.Fatal(err)
+	}
+	Infof("last line before exiting")
+	CloseLogFile()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "last line before exiting") {
+		t.Fatalf("log file doesn't contain the last line: %s", string(content))
+	}
+
+	Infof("line after closing")
+	CloseLogFile()
+}
+
+func TestSetOutputLevel(t *testing.T) {
+	output := color.Output
+	defer func() {
+		color.Output = output
+		SetOutputLevel("info")
+	}()
+
+	var tests = []struct {
+		level    string
+		expected []string
+	}{
+		{level: "debug", expected: []string{"information", "success", "hint", "warning"}},
+		{level: "info", expected: []string{"information", "success", "hint", "warning"}},
+		{level: "warn", expected: []string{"warning"}},
+		{level: "error", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			color.Output = buf
+			SetOutputLevel(tt.level)
+
+			Information("information")
+			Success("success")
+			Hint("hint")
+			Warning("warning")
+			Fail("fail")
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			expected := append(tt.expected, "fail")
+			if len(lines) != len(expected) {
+				t.Fatalf("expected %d lines, got %d: %q", len(expected), len(lines), buf.String())
+			}
+			for i, line := range lines {
+				if !strings.Contains(line, expected[i]) {
+					t.Errorf("expected line %d to contain '%s', got '%s'", i, expected[i], line)
+				}
+			}
+		})
+	}
+}
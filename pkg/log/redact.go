@@ -0,0 +1,86 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	redactedValue = "****"
+
+	// minMaskedWordLength avoids masking short values that would make the logs unreadable
+	minMaskedWordLength = 4
+)
+
+var (
+	maskedWords   = map[string]struct{}{}
+	maskedWordsMu sync.RWMutex
+
+	// authorizationRegex matches the credentials of Authorization headers, e.g. 'Authorization: Bearer <token>' or 'Authorization:[Basic <credentials>]'
+	authorizationRegex = regexp.MustCompile(`(?i)(authorization"?\s*[:=]\s*\[?\s*(?:bearer\s+|basic\s+|token\s+)?)[^\s"'\],]+`)
+
+	// urlPasswordRegex matches the password of URLs with user information, e.g. 'https://user:<password>@host'
+	urlPasswordRegex = regexp.MustCompile(`(://[^:/@\s]+:)[^@/\s]+@`)
+)
+
+// AddMaskedWord registers a sensitive value, like a token or a password, to be replaced by **** in the logs
+func AddMaskedWord(word string) {
+	word = strings.TrimSpace(word)
+	if len(word) < minMaskedWordLength {
+		return
+	}
+	maskedWordsMu.Lock()
+	defer maskedWordsMu.Unlock()
+	maskedWords[word] = struct{}{}
+}
+
+// Redact replaces the sensitive values of a message by ****
+func Redact(message string) string {
+	maskedWordsMu.RLock()
+	words := make([]string, 0, len(maskedWords))
+	for word := range maskedWords {
+		words = append(words, word)
+	}
+	maskedWordsMu.RUnlock()
+
+	// longer words first, in case a masked word contains another one
+	sort.Slice(words, func(i, j int) bool {
+		return len(words[i]) > len(words[j])
+	})
+	for _, word := range words {
+		message = strings.ReplaceAll(message, word, redactedValue)
+	}
+
+	message = authorizationRegex.ReplaceAllString(message, "${1}"+redactedValue)
+	return urlPasswordRegex.ReplaceAllString(message, "${1}"+redactedValue+"@")
+}
+
+// redactFormatter redacts the sensitive values of the entries formatted by another formatter
+type redactFormatter struct {
+	formatter logrus.Formatter
+}
+
+func (f *redactFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	b, err := f.formatter.Format(entry)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(Redact(string(b))), nil
+}
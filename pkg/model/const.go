@@ -46,9 +46,15 @@ const (
 	// StatefulsetAnnotation indicates the original statefulset manifest  when the development container was activated
 	StatefulsetAnnotation = "dev.okteto.com/statefulset"
 
+	// DevNodeAnnotation indicates the node of a daemonset where the development container was activated
+	DevNodeAnnotation = "dev.okteto.com/node"
+
 	// LastBuiltAnnotation indicates the timestamp of an operation
 	LastBuiltAnnotation = "dev.okteto.com/last-built"
 
+	// LastBuiltDigestAnnotation indicates the digest of the image pushed by the last operation
+	LastBuiltDigestAnnotation = "dev.okteto.com/last-built-digest"
+
 	// TranslationAnnotation sets the translation rules
 	TranslationAnnotation = "dev.okteto.com/translation"
 
@@ -101,6 +107,8 @@ const (
 	Deployment = "Deployment"
 	//StatefulSet k8s statefulset kind
 	StatefulSet = "StatefulSet"
+	//DaemonSet k8s daemonset kind
+	DaemonSet = "DaemonSet"
 
 	//Localhost localhost
 	Localhost                   = "localhost"
@@ -173,3 +181,21 @@ const (
 	//OktetoExtension identifies the okteto extension in kubeconfig files
 	OktetoExtension = "okteto"
 )
+
+// DefaultSyncIgnores are the file patterns never synchronized into the development container unless 'sync.defaultIgnores' is set to false:
+// version control folders, build caches and temporary files created by editors and operating systems
+var DefaultSyncIgnores = []string{
+	".git",
+	".hg",
+	".svn",
+	"__pycache__",
+	"*.pyc",
+	".pytest_cache",
+	".gradle",
+	"*.swp",
+	"*.swo",
+	"*~",
+	".#*",
+	".DS_Store",
+	"Thumbs.db",
+}
@@ -29,9 +29,11 @@ import (
 	"github.com/a8m/envsubst"
 	"github.com/google/uuid"
 	"github.com/okteto/okteto/pkg/log"
+	"github.com/subosito/gotenv"
 	yaml "gopkg.in/yaml.v2"
 	apiv1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/pointer"
 )
 
@@ -45,6 +47,9 @@ var (
 	ValidKubeNameRegex = regexp.MustCompile(`[^a-z0-9\-]+`)
 
 	once sync.Once
+
+	// strictEnv makes the expansion of an undefined environment variable fail
+	strictEnv bool
 )
 
 // Dev represents a development container
@@ -52,6 +57,7 @@ type Dev struct {
 	Name                 string                `json:"name" yaml:"name"`
 	Username             string                `json:"-" yaml:"-"`
 	RegistryURL          string                `json:"-" yaml:"-"`
+	Node                 string                `json:"-" yaml:"-"`
 	Autocreate           bool                  `json:"autocreate,omitempty" yaml:"autocreate,omitempty"`
 	Labels               Labels                `json:"labels,omitempty" yaml:"labels,omitempty"`
 	Annotations          Annotations           `json:"annotations,omitempty" yaml:"annotations,omitempty"`
@@ -60,10 +66,18 @@ type Dev struct {
 	Namespace            string                `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 	Container            string                `json:"container,omitempty" yaml:"container,omitempty"`
 	EmptyImage           bool                  `json:"-" yaml:"-"`
+	EmptyCommand         bool                  `json:"-" yaml:"-"`
 	Image                *BuildInfo            `json:"image,omitempty" yaml:"image,omitempty"`
 	Push                 *BuildInfo            `json:"-" yaml:"push,omitempty"`
+	Build                map[string]*BuildInfo `json:"-" yaml:"build,omitempty"`
+	Deploy               *DeployInfo           `json:"-" yaml:"deploy,omitempty"`
+	Scan                 *ScanInfo             `json:"-" yaml:"scan,omitempty"`
 	ImagePullPolicy      apiv1.PullPolicy      `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
+	ImagePullSecrets     []string              `json:"imagePullSecrets,omitempty" yaml:"imagePullSecrets,omitempty"`
 	Environment          Environment           `json:"environment,omitempty" yaml:"environment,omitempty"`
+	EnvFiles             EnvFiles              `json:"-" yaml:"envFile,omitempty"`
+	manifestEnvironment  Environment           `json:"-" yaml:"-"`
+	envOverrides         Environment           `json:"-" yaml:"-"`
 	Secrets              []Secret              `json:"secrets,omitempty" yaml:"secrets,omitempty"`
 	Command              Command               `json:"command,omitempty" yaml:"command,omitempty"`
 	Healthchecks         bool                  `json:"healthchecks,omitempty" yaml:"healthchecks,omitempty"`
@@ -91,6 +105,8 @@ type Dev struct {
 	Divert               *Divert               `json:"divert,omitempty" yaml:"divert,omitempty"`
 	NodeSelector         map[string]string     `json:"nodeSelector,omitempty" yaml:"nodeSelector,omitempty"`
 	Affinity             *Affinity             `json:"affinity,omitempty" yaml:"affinity,omitempty"`
+	HostNetwork          bool                  `json:"hostNetwork,omitempty" yaml:"hostNetwork,omitempty"`
+	rootRequested        bool                  `json:"-" yaml:"-"`
 }
 
 type Affinity apiv1.Affinity
@@ -120,6 +136,19 @@ type BuildInfo struct {
 	Args       Environment `yaml:"args,omitempty"`
 }
 
+// DeployInfo represents the commands and manifests applied before activating the development container
+type DeployInfo struct {
+	Commands  []string `yaml:"commands,omitempty"`
+	Manifests []string `yaml:"manifests,omitempty"`
+}
+
+// ScanInfo represents the vulnerability scan run by okteto push before redeploying the app
+type ScanInfo struct {
+	Command  string `yaml:"command,omitempty"`
+	Severity string `yaml:"severity,omitempty"`
+	Hook     string `yaml:"hook,omitempty"`
+}
+
 // Volume represents a volume in the development container
 type Volume struct {
 	LocalPath  string
@@ -131,6 +160,8 @@ type Sync struct {
 	Compression    bool         `json:"compression" yaml:"compression"`
 	Verbose        bool         `json:"verbose" yaml:"verbose"`
 	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
+	ConflictsDir   string       `json:"conflictsDir,omitempty" yaml:"conflictsDir,omitempty"`
+	DefaultIgnores *bool        `json:"defaultIgnores,omitempty" yaml:"defaultIgnores,omitempty"`
 	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
 	LocalPath      string
 	RemotePath     string
@@ -185,6 +216,7 @@ type SecurityContext struct {
 	FSGroup      *int64        `json:"fsGroup,omitempty" yaml:"fsGroup,omitempty"`
 	Capabilities *Capabilities `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
 	RunAsNonRoot *bool         `json:"runAsNonRoot,omitempty" yaml:"runAsNonRoot,omitempty"`
+	Privileged   *bool         `json:"privileged,omitempty" yaml:"privileged,omitempty"`
 }
 
 // Capabilities sets the linux capabilities of a container
@@ -206,14 +238,24 @@ type Secret struct {
 	Mode       int32
 }
 
-// Reverse represents a remote forward port
+// Reverse represents a remote forward port.
+// If WaitForLocal is true, the dev command is not launched until the local port accepts connections.
+// The wait is bounded by 'timeout.default': once it expires, a warning is shown and the command is launched anyway.
 type Reverse struct {
-	Remote int
-	Local  int
+	Remote       int
+	Local        int
+	WaitForLocal bool
 }
 
 // ResourceRequirements describes the compute resource requirements.
 type ResourceRequirements struct {
+	Limits   ResourceList               `json:"limits,omitempty" yaml:"limits,omitempty"`
+	Requests ResourceList               `json:"requests,omitempty" yaml:"requests,omitempty"`
+	Profiles map[string]ResourceProfile `json:"-" yaml:"profiles,omitempty"`
+}
+
+// ResourceProfile is a named set of resource requirements selectable with 'okteto up --profile'
+type ResourceProfile struct {
 	Limits   ResourceList `json:"limits,omitempty" yaml:"limits,omitempty"`
 	Requests ResourceList `json:"requests,omitempty" yaml:"requests,omitempty"`
 }
@@ -233,11 +275,15 @@ type Lifecycle struct {
 
 // Divert defines how to divert a given service
 type Divert struct {
-	Ingress string `yaml:"ingress,omitempty"`
-	Service string `yaml:"service,omitempty"`
-	Port    int    `yaml:"port,omitempty"`
+	Ingress DivertIngresses `yaml:"ingress,omitempty"`
+	Service string          `yaml:"service,omitempty"`
+	Port    int             `yaml:"port,omitempty"`
+	Value   string          `yaml:"value,omitempty"`
 }
 
+// DivertIngresses represents the names of the ingresses diverted by a development container
+type DivertIngresses []string
+
 // ResourceList is a set of (resource name, quantity) pairs.
 type ResourceList map[apiv1.ResourceName]resource.Quantity
 
@@ -255,7 +301,7 @@ type EnvFiles []string
 
 // Get returns a Dev object from a given file
 func Get(devPath string) (*Dev, error) {
-	b, err := os.ReadFile(devPath)
+	b, err := readManifest(devPath)
 	if err != nil {
 		return nil, err
 	}
@@ -273,6 +319,15 @@ func Get(devPath string) (*Dev, error) {
 		return nil, err
 	}
 
+	if err := dev.LoadEnvFiles(); err != nil {
+		return nil, err
+	}
+	for _, s := range dev.Services {
+		if err := s.LoadEnvFiles(); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := dev.validate(); err != nil {
 		return nil, err
 	}
@@ -282,6 +337,34 @@ func Get(devPath string) (*Dev, error) {
 	return dev, nil
 }
 
+// unmarshalManifest strictly decodes the bytes of an okteto manifest into dev.
+// The unknown top level fields in ignoredFields are not reported
+func unmarshalManifest(bytes []byte, dev *Dev, ignoredFields ...string) error {
+	err := yaml.UnmarshalStrict(bytes, dev)
+	if err == nil {
+		return nil
+	}
+	if strings.HasPrefix(err.Error(), "yaml: unmarshal errors:") {
+		schemaErr := &SchemaError{}
+		l := strings.Split(err.Error(), "\n")
+		for i := 1; i < len(l); i++ {
+			e := strings.TrimSpace(strings.TrimSuffix(l[i], "in type model.Dev"))
+			if isIgnoredField(e, ignoredFields) {
+				continue
+			}
+			schemaErr.Errors = append(schemaErr.Errors, formatUnmarshalError(e))
+		}
+		if len(schemaErr.Errors) == 0 {
+			return nil
+		}
+		return schemaErr
+	}
+
+	msg := strings.Replace(err.Error(), "yaml: unmarshal errors:", "invalid manifest:", 1)
+	msg = strings.TrimSuffix(msg, "in type model.Dev")
+	return errors.New(msg)
+}
+
 // Read reads an okteto manifests
 func Read(bytes []byte) (*Dev, error) {
 	dev := &Dev{
@@ -302,24 +385,8 @@ func Read(bytes []byte) (*Dev, error) {
 	}
 
 	if bytes != nil {
-		if err := yaml.UnmarshalStrict(bytes, dev); err != nil {
-			if strings.HasPrefix(err.Error(), "yaml: unmarshal errors:") {
-				var sb strings.Builder
-				_, _ = sb.WriteString("Invalid manifest:\n")
-				l := strings.Split(err.Error(), "\n")
-				for i := 1; i < len(l); i++ {
-					e := strings.TrimSuffix(l[i], "in type model.Dev")
-					e = strings.TrimSpace(e)
-					_, _ = sb.WriteString(fmt.Sprintf("    - %s\n", e))
-				}
-
-				_, _ = sb.WriteString("    See https://okteto.com/docs/reference/manifest/ for details")
-				return nil, errors.New(sb.String())
-			}
-
-			msg := strings.Replace(err.Error(), "yaml: unmarshal errors:", "invalid manifest:", 1)
-			msg = strings.TrimSuffix(msg, "in type model.Dev")
-			return nil, errors.New(msg)
+		if err := unmarshalManifest(bytes, dev); err != nil {
+			return nil, err
 		}
 	}
 
@@ -332,10 +399,25 @@ func Read(bytes []byte) (*Dev, error) {
 		}
 	}
 
+	if err := dev.loadBuildReferences(); err != nil {
+		return nil, err
+	}
+
+	dev.rootRequested = dev.isRootUser()
+	for _, s := range dev.Services {
+		s.rootRequested = s.isRootUser()
+	}
+
 	if err := dev.setDefaults(); err != nil {
 		return nil, err
 	}
 
+	forwards, err := expandForwardRanges(dev.Forward)
+	if err != nil {
+		return nil, err
+	}
+	dev.Forward = forwards
+
 	sort.SliceStable(dev.Forward, func(i, j int) bool {
 		return dev.Forward[i].less(&dev.Forward[j])
 	})
@@ -353,18 +435,35 @@ func (dev *Dev) loadAbsPaths(devPath string) error {
 		return err
 	}
 
-	if uri, err := url.ParseRequestURI(dev.Image.Context); err != nil || (uri != nil && (uri.Scheme == "" || uri.Host == "")) {
-		dev.Image.Context = loadAbsPath(devDir, dev.Image.Context)
-		dev.Image.Dockerfile = loadAbsPath(devDir, dev.Image.Dockerfile)
+	loadBuildAbsPaths(devDir, dev.Image)
+	loadBuildAbsPaths(devDir, dev.Push)
+	for _, b := range dev.Build {
+		loadBuildAbsPaths(devDir, b)
+	}
+
+	if dev.Sync.ConflictsDir != "" {
+		dev.Sync.ConflictsDir = loadAbsPath(devDir, dev.Sync.ConflictsDir)
+	}
+
+	for i := range dev.EnvFiles {
+		dev.EnvFiles[i] = loadAbsPath(devDir, dev.EnvFiles[i])
 	}
-	if uri, err := url.ParseRequestURI(dev.Push.Context); err != nil || (uri != nil && (uri.Scheme == "" || uri.Host == "")) {
-		dev.Push.Context = loadAbsPath(devDir, dev.Push.Context)
-		dev.Push.Dockerfile = loadAbsPath(devDir, dev.Push.Dockerfile)
+
+	if dev.Deploy != nil {
+		for i := range dev.Deploy.Manifests {
+			dev.Deploy.Manifests[i] = loadAbsPath(devDir, dev.Deploy.Manifests[i])
+		}
 	}
 
 	dev.loadVolumeAbsPaths(devDir)
 	for _, s := range dev.Services {
+		if s.Image != nil && s.Image.Context != "" {
+			loadBuildAbsPaths(devDir, s.Image)
+		}
 		s.loadVolumeAbsPaths(devDir)
+		for i := range s.EnvFiles {
+			s.EnvFiles[i] = loadAbsPath(devDir, s.EnvFiles[i])
+		}
 	}
 	return nil
 }
@@ -381,6 +480,13 @@ func (dev *Dev) loadVolumeAbsPaths(folder string) {
 	}
 }
 
+func loadBuildAbsPaths(folder string, build *BuildInfo) {
+	if uri, err := url.ParseRequestURI(build.Context); err != nil || (uri != nil && (uri.Scheme == "" || uri.Host == "")) {
+		build.Context = loadAbsPath(folder, build.Context)
+		build.Dockerfile = loadAbsPath(folder, build.Dockerfile)
+	}
+}
+
 func loadAbsPath(folder, path string) string {
 	if filepath.IsAbs(path) {
 		return path
@@ -388,6 +494,67 @@ func loadAbsPath(folder, path string) string {
 	return filepath.Join(folder, path)
 }
 
+// LoadEnvFiles sets the environment of the development container from its 'environment' and 'envFile' fields.
+// Variables defined in 'environment' take precedence, and later env files override the previous ones.
+// The variables set with OverrideEnvironment take precedence over both.
+// It can be called again to reload the env files
+func (dev *Dev) LoadEnvFiles() error {
+	if dev.manifestEnvironment == nil {
+		dev.manifestEnvironment = append(Environment{}, dev.Environment...)
+	}
+
+	fromFiles := map[string]string{}
+	for _, path := range dev.EnvFiles {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("error reading env file '%s': %s", path, err.Error())
+		}
+		envMap, err := gotenv.StrictParse(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("error parsing env file '%s': %s", path, err.Error())
+		}
+		for name, value := range envMap {
+			fromFiles[name] = value
+		}
+	}
+
+	env := append(Environment{}, dev.manifestEnvironment...)
+	for _, e := range env {
+		delete(fromFiles, e.Name)
+	}
+	names := make([]string, 0, len(fromFiles))
+	for name := range fromFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env = append(env, EnvVar{Name: name, Value: fromFiles[name]})
+	}
+	dev.Environment = mergeEnvironment(env, dev.envOverrides)
+	return nil
+}
+
+// OverrideEnvironment sets environment variables of the development container that take precedence
+// over the 'environment' and 'envFile' fields, and are kept when the env files are reloaded
+func (dev *Dev) OverrideEnvironment(overrides Environment) {
+	dev.envOverrides = overrides
+	dev.Environment = mergeEnvironment(dev.Environment, overrides)
+}
+
+// mergeEnvironment returns env with the values of overrides, replacing the variables with the same name
+func mergeEnvironment(env, overrides Environment) Environment {
+	result := append(Environment{}, env...)
+	for _, o := range overrides {
+		if idx := getEnvVarIdx(result, o); idx != -1 {
+			result[idx] = o
+			continue
+		}
+		result = append(result, o)
+	}
+	return result
+}
+
 func (dev *Dev) expandEnvVars() error {
 	if err := dev.loadName(); err != nil {
 		return err
@@ -401,6 +568,26 @@ func (dev *Dev) expandEnvVars() error {
 	if err := dev.loadLabels(); err != nil {
 		return err
 	}
+	if err := dev.loadAnnotations(); err != nil {
+		return err
+	}
+	if err := dev.loadContainer(); err != nil {
+		return err
+	}
+	if err := dev.loadServiceAccount(); err != nil {
+		return err
+	}
+	if err := dev.loadImagePullSecrets(); err != nil {
+		return err
+	}
+	if err := dev.loadDivertValue(); err != nil {
+		return err
+	}
+	if dev.Push != nil {
+		if err := dev.Push.expandEnvVars(); err != nil {
+			return err
+		}
+	}
 
 	return dev.loadImage()
 }
@@ -438,6 +625,17 @@ func (dev *Dev) loadContext() error {
 	return nil
 }
 
+func (dev *Dev) loadDivertValue() error {
+	var err error
+	if dev.Divert != nil && len(dev.Divert.Value) > 0 {
+		dev.Divert.Value, err = ExpandEnv(dev.Divert.Value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (dev *Dev) loadLabels() error {
 	var err error
 	for i := range dev.Labels {
@@ -449,16 +647,56 @@ func (dev *Dev) loadLabels() error {
 	return nil
 }
 
-func (dev *Dev) loadImage() error {
+func (dev *Dev) loadAnnotations() error {
 	var err error
-	if dev.Image == nil {
-		dev.Image = &BuildInfo{}
+	for i := range dev.Annotations {
+		dev.Annotations[i], err = ExpandEnv(dev.Annotations[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (dev *Dev) loadContainer() error {
+	var err error
+	if len(dev.Container) > 0 {
+		dev.Container, err = ExpandEnv(dev.Container)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (dev *Dev) loadServiceAccount() error {
+	var err error
+	if len(dev.ServiceAccount) > 0 {
+		dev.ServiceAccount, err = ExpandEnv(dev.ServiceAccount)
+		if err != nil {
+			return err
+		}
 	}
-	if len(dev.Image.Name) > 0 {
-		dev.Image.Name, err = ExpandEnv(dev.Image.Name)
+	return nil
+}
+
+func (dev *Dev) loadImagePullSecrets() error {
+	for i := range dev.ImagePullSecrets {
+		name, err := ExpandEnv(dev.ImagePullSecrets[i])
 		if err != nil {
 			return err
 		}
+		dev.ImagePullSecrets[i] = name
+	}
+	return nil
+}
+
+func (dev *Dev) loadImage() error {
+	if dev.Image == nil {
+		dev.Image = &BuildInfo{}
+	}
+	if err := dev.Image.expandEnvVars(); err != nil {
+		return err
 	}
 	if dev.Image.Name == "" {
 		dev.EmptyImage = true
@@ -466,12 +704,103 @@ func (dev *Dev) loadImage() error {
 	return nil
 }
 
+// loadBuildReferences replaces the 'image' and 'push' fields referencing an image of the 'build' section by its build info.
+// 'push' defaults to the image of the 'build' section with the name of the development container
+func (dev *Dev) loadBuildReferences() error {
+	for name, b := range dev.Build {
+		if b == nil {
+			b = &BuildInfo{}
+			dev.Build[name] = b
+		}
+		if err := b.expandEnvVars(); err != nil {
+			return err
+		}
+	}
+	if len(dev.Build) == 0 {
+		return nil
+	}
+
+	if b := dev.getBuildReference(dev.Image); b != nil {
+		dev.Image = b
+		dev.EmptyImage = b.Name == ""
+	}
+	for _, s := range dev.Services {
+		if b := dev.getBuildReference(s.Image); b != nil {
+			s.Image = b
+			s.EmptyImage = b.Name == ""
+		}
+	}
+
+	if b := dev.getBuildReference(dev.Push); b != nil {
+		dev.Push = b
+	} else if b, ok := dev.Build[dev.Name]; ok && (dev.Push == nil || dev.Push.isEmpty()) {
+		dev.Push = b.copy()
+	}
+	return nil
+}
+
+// getBuildReference returns a copy of the image of the 'build' section referenced by build, or nil if build is not a reference
+func (dev *Dev) getBuildReference(build *BuildInfo) *BuildInfo {
+	if build == nil || build.Name == "" {
+		return nil
+	}
+	if build.Context != "" || build.Dockerfile != "" || build.Target != "" || len(build.CacheFrom) > 0 || len(build.Args) > 0 {
+		return nil
+	}
+	b, ok := dev.Build[build.Name]
+	if !ok {
+		return nil
+	}
+	return b.copy()
+}
+
+// expandEnvVars expands the environment variables of the fields of a build info.
+// The values of 'args' are expanded when they are unmarshalled
+func (build *BuildInfo) expandEnvVars() error {
+	var err error
+	for _, value := range []*string{&build.Name, &build.Context, &build.Dockerfile, &build.Target} {
+		if len(*value) == 0 {
+			continue
+		}
+		*value, err = ExpandEnv(*value)
+		if err != nil {
+			return err
+		}
+	}
+	for i := range build.CacheFrom {
+		build.CacheFrom[i], err = ExpandEnv(build.CacheFrom[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (build *BuildInfo) isEmpty() bool {
+	return build.Name == "" && build.Context == "" && build.Dockerfile == "" && build.Target == "" && len(build.CacheFrom) == 0 && len(build.Args) == 0
+}
+
+func (build *BuildInfo) copy() *BuildInfo {
+	result := *build
+	if build.CacheFrom != nil {
+		result.CacheFrom = append([]string{}, build.CacheFrom...)
+	}
+	if build.Args != nil {
+		result.Args = append(Environment{}, build.Args...)
+	}
+	return &result
+}
+
 func (dev *Dev) setDefaults() error {
 	if dev.Command.Values == nil {
 		dev.Command.Values = []string{"sh"}
+		dev.EmptyCommand = true
 	}
 	setBuildDefaults(dev.Image)
 	setBuildDefaults(dev.Push)
+	for _, b := range dev.Build {
+		setBuildDefaults(b)
+	}
 
 	if err := dev.setTimeout(); err != nil {
 		return err
@@ -617,6 +946,10 @@ func (dev *Dev) validate() error {
 		return err
 	}
 
+	if err := validateImagePullSecrets("", dev.ImagePullSecrets); err != nil {
+		return err
+	}
+
 	if err := validateSecrets(dev.Secrets); err != nil {
 		return err
 	}
@@ -635,6 +968,50 @@ func (dev *Dev) validate() error {
 		return err
 	}
 
+	if err := dev.validateSyncConflictsDir(); err != nil {
+		return err
+	}
+
+	if dev.Scan != nil && dev.Scan.Hook != "" && dev.Scan.Command != "" {
+		return fmt.Errorf("'scan.hook' and 'scan.command' can't be used together")
+	}
+
+	if dev.Sync.RescanInterval < 0 {
+		return fmt.Errorf("'sync.rescanInterval' must be a positive number of seconds, or 0 to disable the periodic rescans")
+	}
+
+	if err := dev.validateDivert(); err != nil {
+		return err
+	}
+
+	for _, f := range dev.Forward {
+		if f.IsSocket() {
+			if err := ValidateSocket(f.Socket); err != nil {
+				return fmt.Errorf("invalid forward of local socket %s: %w", f.Socket, err)
+			}
+			if f.BindAddress != "" {
+				return fmt.Errorf("invalid forward of local socket %s: 'bindAddress' can't be used with a local socket", f.Socket)
+			}
+			continue
+		}
+		if err := ValidateBindAddress(f.BindAddress); err != nil {
+			return fmt.Errorf("invalid forward of local port %d: %w", f.Local, err)
+		}
+	}
+
+	if err := validateResources("resources", dev.Resources.Requests, dev.Resources.Limits); err != nil {
+		return err
+	}
+	for name, profile := range dev.Resources.Profiles {
+		if err := validateResources(fmt.Sprintf("resources.profiles.%s", name), profile.Requests, profile.Limits); err != nil {
+			return err
+		}
+	}
+
+	if err := validateScheduling("", dev); err != nil {
+		return err
+	}
+
 	if _, err := resource.ParseQuantity(dev.PersistentVolumeSize()); err != nil {
 		return fmt.Errorf("'persistentVolume.size' is not valid. A sample value would be '10Gi'")
 	}
@@ -643,10 +1020,28 @@ func (dev *Dev) validate() error {
 		return fmt.Errorf("'sshServerPort' must be > 0")
 	}
 
+	for name := range dev.Build {
+		if ValidKubeNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid name '%s' in the 'build' section: must consist of lower case alphanumeric characters or '-'", name)
+		}
+	}
+
 	for _, s := range dev.Services {
+		if len(s.Build) > 0 {
+			return fmt.Errorf("'build' is not supported in 'services'. Define your images in the 'build' section of the main development container")
+		}
 		if err := validatePullPolicy(s.ImagePullPolicy); err != nil {
 			return err
 		}
+		if err := validateImagePullSecrets(fmt.Sprintf("services.%s.", s.Name), s.ImagePullSecrets); err != nil {
+			return err
+		}
+		if err := validateResources(fmt.Sprintf("services.%s.resources", s.Name), s.Resources.Requests, s.Resources.Limits); err != nil {
+			return err
+		}
+		if err := validateScheduling(fmt.Sprintf("services.%s.", s.Name), s); err != nil {
+			return err
+		}
 		if err := s.validateVolumes(dev); err != nil {
 			return err
 		}
@@ -660,6 +1055,182 @@ func (dev *Dev) validate() error {
 	return nil
 }
 
+// validateResources returns an error if the requests or limits of field use a resource not supported by okteto,
+// or a request is greater than its limit
+func validateResources(field string, requests, limits ResourceList) error {
+	supported := map[apiv1.ResourceName]bool{
+		apiv1.ResourceCPU:    true,
+		apiv1.ResourceMemory: true,
+		ResourceAMDGPU:       true,
+		ResourceNVIDIAGPU:    true,
+	}
+	for _, list := range []struct {
+		name      string
+		resources ResourceList
+	}{{name: "requests", resources: requests}, {name: "limits", resources: limits}} {
+		for name := range list.resources {
+			if !supported[name] {
+				return fmt.Errorf("'%s.%s.%s' is not supported. Supported resources are '%s', '%s', '%s' and '%s'", field, list.name, name, apiv1.ResourceCPU, apiv1.ResourceMemory, ResourceAMDGPU, ResourceNVIDIAGPU)
+			}
+		}
+	}
+
+	for name, request := range requests {
+		limit, ok := limits[name]
+		if ok && request.Cmp(limit) > 0 {
+			return fmt.Errorf("'%s.requests.%s' (%s) cannot be greater than '%s.limits.%s' (%s)", field, name, request.String(), field, name, limit.String())
+		}
+	}
+	return nil
+}
+
+// validateScheduling returns an error if the nodeSelector, tolerations or node affinity of d have invalid keys, values, operators or effects
+func validateScheduling(prefix string, d *Dev) error {
+	for k, v := range d.NodeSelector {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("invalid key '%s' in '%snodeSelector': %s", k, prefix, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+			return fmt.Errorf("invalid value '%s' for '%snodeSelector.%s': %s", v, prefix, k, strings.Join(errs, ", "))
+		}
+	}
+
+	for i, t := range d.Tolerations {
+		field := fmt.Sprintf("%stolerations[%d]", prefix, i)
+		if t.Key != "" {
+			if errs := validation.IsQualifiedName(t.Key); len(errs) > 0 {
+				return fmt.Errorf("invalid key '%s' in '%s': %s", t.Key, field, strings.Join(errs, ", "))
+			}
+		}
+		switch t.Operator {
+		case apiv1.TolerationOpEqual, "":
+			if t.Key == "" {
+				return fmt.Errorf("'%s.key' is required when 'operator' is '%s'", field, apiv1.TolerationOpEqual)
+			}
+			if errs := validation.IsValidLabelValue(t.Value); len(errs) > 0 {
+				return fmt.Errorf("invalid value '%s' in '%s': %s", t.Value, field, strings.Join(errs, ", "))
+			}
+		case apiv1.TolerationOpExists:
+			if t.Value != "" {
+				return fmt.Errorf("'%s.value' must be empty when 'operator' is '%s'", field, apiv1.TolerationOpExists)
+			}
+		default:
+			return fmt.Errorf("'%s.operator' must be '%s' or '%s'", field, apiv1.TolerationOpEqual, apiv1.TolerationOpExists)
+		}
+		switch t.Effect {
+		case "", apiv1.TaintEffectNoSchedule, apiv1.TaintEffectPreferNoSchedule, apiv1.TaintEffectNoExecute:
+		default:
+			return fmt.Errorf("'%s.effect' must be '%s', '%s' or '%s'", field, apiv1.TaintEffectNoSchedule, apiv1.TaintEffectPreferNoSchedule, apiv1.TaintEffectNoExecute)
+		}
+	}
+
+	if d.Affinity == nil || d.Affinity.NodeAffinity == nil {
+		return nil
+	}
+	terms := []apiv1.NodeSelectorTerm{}
+	if required := d.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+		terms = append(terms, required.NodeSelectorTerms...)
+	}
+	for _, preferred := range d.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		terms = append(terms, preferred.Preference)
+	}
+	for _, term := range terms {
+		for _, r := range term.MatchExpressions {
+			if errs := validation.IsQualifiedName(r.Key); len(errs) > 0 {
+				return fmt.Errorf("invalid key '%s' in '%saffinity.nodeAffinity': %s", r.Key, prefix, strings.Join(errs, ", "))
+			}
+			switch r.Operator {
+			case apiv1.NodeSelectorOpIn, apiv1.NodeSelectorOpNotIn, apiv1.NodeSelectorOpExists, apiv1.NodeSelectorOpDoesNotExist, apiv1.NodeSelectorOpGt, apiv1.NodeSelectorOpLt:
+			default:
+				return fmt.Errorf("invalid operator '%s' for key '%s' in '%saffinity.nodeAffinity'", r.Operator, r.Key, prefix)
+			}
+			for _, v := range r.Values {
+				if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+					return fmt.Errorf("invalid value '%s' for key '%s' in '%saffinity.nodeAffinity': %s", v, r.Key, prefix, strings.Join(errs, ", "))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (dev *Dev) validateDivert() error {
+	if dev.Divert == nil {
+		return nil
+	}
+	if len(dev.Divert.Ingress) == 0 && dev.Divert.Service == "" {
+		return fmt.Errorf("'divert' must specify at least one of 'ingress' or 'service'")
+	}
+	if dev.Divert.Service == "" {
+		return fmt.Errorf("'divert.service' is required to divert the ingress '%s'", strings.Join(dev.Divert.Ingress, "', '"))
+	}
+	ingresses := map[string]bool{}
+	for _, ingress := range dev.Divert.Ingress {
+		if ingress == "" {
+			return fmt.Errorf("'divert.ingress' cannot contain empty values")
+		}
+		if ingresses[ingress] {
+			return fmt.Errorf("duplicated ingress '%s' in 'divert.ingress'", ingress)
+		}
+		ingresses[ingress] = true
+	}
+	return nil
+}
+
+// ApplyResourceProfile overrides the resources of the development container with the ones of a resource profile
+func (dev *Dev) ApplyResourceProfile(name string) error {
+	profile, ok := dev.Resources.Profiles[name]
+	if !ok {
+		profiles := []string{}
+		for p := range dev.Resources.Profiles {
+			profiles = append(profiles, p)
+		}
+		sort.Strings(profiles)
+		if len(profiles) == 0 {
+			return fmt.Errorf("resource profile '%s' not found: 'resources.profiles' is not defined in your okteto manifest", name)
+		}
+		return fmt.Errorf("resource profile '%s' not found. Valid profiles are: [%s]", name, strings.Join(profiles, ", "))
+	}
+
+	if dev.Resources.Limits == nil {
+		dev.Resources.Limits = ResourceList{}
+	}
+	for resourceKey, resourceValue := range profile.Limits {
+		dev.Resources.Limits[resourceKey] = resourceValue
+	}
+	if dev.Resources.Requests == nil {
+		dev.Resources.Requests = ResourceList{}
+	}
+	for resourceKey, resourceValue := range profile.Requests {
+		dev.Resources.Requests[resourceKey] = resourceValue
+	}
+	return nil
+}
+
+// GetDefaultIgnores returns the file patterns ignored by default in the sync folders
+func (sync *Sync) GetDefaultIgnores() []string {
+	if sync.DefaultIgnores != nil && !*sync.DefaultIgnores {
+		return nil
+	}
+	return DefaultSyncIgnores
+}
+
+func (dev *Dev) validateSyncConflictsDir() error {
+	if dev.Sync.ConflictsDir == "" {
+		return nil
+	}
+	for _, folder := range dev.Sync.Folders {
+		rel, err := filepath.Rel(folder.LocalPath, dev.Sync.ConflictsDir)
+		if err != nil {
+			continue
+		}
+		if rel == "." || !strings.HasPrefix(rel, "..") {
+			return fmt.Errorf("'sync.conflictsDir' must be outside of the synchronized folder '%s'", folder.LocalPath)
+		}
+	}
+	return nil
+}
+
 func validatePullPolicy(pullPolicy apiv1.PullPolicy) error {
 	switch pullPolicy {
 	case apiv1.PullAlways:
@@ -671,6 +1242,15 @@ func validatePullPolicy(pullPolicy apiv1.PullPolicy) error {
 	return nil
 }
 
+func validateImagePullSecrets(prefix string, names []string) error {
+	for _, name := range names {
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return fmt.Errorf("invalid secret name '%s' in '%simagePullSecrets': %s", name, prefix, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
 func validateSecrets(secrets []Secret) error {
 	seen := map[string]bool{}
 	for _, s := range secrets {
@@ -704,6 +1284,29 @@ func (dev *Dev) isRootUser() bool {
 	return *dev.SecurityContext.RunAsUser == 0
 }
 
+// isPrivileged returns true if the development container runs as a privileged container
+func (dev *Dev) isPrivileged() bool {
+	if dev.SecurityContext == nil || dev.SecurityContext.Privileged == nil {
+		return false
+	}
+	return *dev.SecurityContext.Privileged
+}
+
+// SecurityWarnings returns the warnings about the privileges requested by the securityContext of the development containers.
+// Running as root is only reported if it is set in the manifest, not when it is the okteto default
+func (dev *Dev) SecurityWarnings() []string {
+	warnings := []string{}
+	for _, d := range append([]*Dev{dev}, dev.Services...) {
+		if d.isPrivileged() {
+			warnings = append(warnings, fmt.Sprintf("The development container '%s' runs as a privileged container with full access to the node. Don't use 'privileged' in production", d.Name))
+		}
+		if d.rootRequested {
+			warnings = append(warnings, fmt.Sprintf("The development container '%s' runs as the root user. Make sure your production containers run as a non-root user", d.Name))
+		}
+	}
+	return warnings
+}
+
 // validateSecurityContext checks to see if a root user is specified with runAsNonRoot enabled
 func (dev *Dev) validateSecurityContext() error {
 	if dev.isRootUser() && dev.RunAsNonRoot() {
@@ -813,6 +1416,7 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 	rule := &TranslationRule{
 		Container:        dev.Container,
 		ImagePullPolicy:  dev.ImagePullPolicy,
+		ImagePullSecrets: dev.ImagePullSecrets,
 		Environment:      dev.Environment,
 		Secrets:          dev.Secrets,
 		WorkDir:          dev.Workdir,
@@ -828,6 +1432,7 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 		Lifecycle:        dev.Lifecycle,
 		NodeSelector:     dev.NodeSelector,
 		Affinity:         (*apiv1.Affinity)(dev.Affinity),
+		HostNetwork:      dev.HostNetwork,
 	}
 
 	if !dev.EmptyImage {
@@ -1032,9 +1637,14 @@ func (s *Secret) GetFileName() string {
 	return filepath.Base(s.RemotePath)
 }
 
+// SetStrictEnv sets if expanding an undefined environment variable without a default value is an error
+func SetStrictEnv(strict bool) {
+	strictEnv = strict
+}
+
 //ExpandEnv expands the environments supporting the notation "${var:-$DEFAULT}"
 func ExpandEnv(value string) (string, error) {
-	result, err := envsubst.String(value)
+	result, err := envsubst.StringRestricted(value, strictEnv, false)
 	if err != nil {
 		return "", fmt.Errorf("error expanding environment on '%s': %s", value, err.Error())
 	}
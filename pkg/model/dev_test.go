@@ -16,12 +16,15 @@ package model
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/joho/godotenv"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func Test_LoadDev(t *testing.T) {
@@ -651,6 +654,416 @@ func Test_Reverse(t *testing.T) {
 	}
 }
 
+func Test_LoadForwardRange(t *testing.T) {
+	manifest := []byte(`
+  name: deployment
+  image: code/core:0.1.8
+  forward:
+    - 9000-9002:9100-9102
+    - 8080:80`)
+	dev, err := Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Forward{
+		{Local: 8080, Remote: 80},
+		{Local: 9000, Remote: 9100},
+		{Local: 9001, Remote: 9101},
+		{Local: 9002, Remote: 9102},
+	}
+	if !reflect.DeepEqual(dev.Forward, expected) {
+		t.Errorf("forward ranges weren't expanded. Actual '%+v', Expected '%+v'", dev.Forward, expected)
+	}
+
+	manifest = []byte(`
+  name: deployment
+  image: code/core:0.1.8
+  forward:
+    - 9000-9010:9000-9010
+    - 9010:80`)
+	if _, err := Read(manifest); err == nil {
+		t.Error("overlapping forwards didn't return an error")
+	}
+}
+
+func Test_LoadDeploy(t *testing.T) {
+	manifest := []byte(`
+  name: deployment
+  image: code/core:0.1.8
+  deploy:
+    commands:
+      - helm upgrade --install db charts/db
+    manifests:
+      - k8s/api.yml
+      - /tmp/k8s/worker.yml`)
+	dev, err := Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dev.loadAbsPaths("/okteto/okteto.yml"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &DeployInfo{
+		Commands:  []string{"helm upgrade --install db charts/db"},
+		Manifests: []string{filepath.Join("/okteto", "k8s", "api.yml"), "/tmp/k8s/worker.yml"},
+	}
+	if !reflect.DeepEqual(dev.Deploy, expected) {
+		t.Errorf("expected %+v but got %+v", expected, dev.Deploy)
+	}
+}
+
+func Test_LoadBuild(t *testing.T) {
+	manifest := []byte(`
+name: api
+image: api
+build:
+  api:
+    name: okteto.dev/api:dev
+    context: api
+    args:
+      - ENVIRONMENT=dev
+  worker:
+    context: worker
+    dockerfile: worker/Dockerfile.dev
+services:
+  - name: worker
+    image: worker`)
+	dev, err := Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.loadAbsPaths("/okteto/okteto.yml"); err != nil {
+		t.Fatal(err)
+	}
+
+	api := &BuildInfo{
+		Name:       "okteto.dev/api:dev",
+		Context:    filepath.Join("/okteto", "api"),
+		Dockerfile: filepath.Join("/okteto", "Dockerfile"),
+		Args:       Environment{{Name: "ENVIRONMENT", Value: "dev"}},
+	}
+	worker := &BuildInfo{
+		Context:    filepath.Join("/okteto", "worker"),
+		Dockerfile: filepath.Join("/okteto", "worker", "Dockerfile.dev"),
+	}
+	if !reflect.DeepEqual(dev.Build["api"], api) {
+		t.Errorf("expected build %+v but got %+v", api, dev.Build["api"])
+	}
+	if !reflect.DeepEqual(dev.Build["worker"], worker) {
+		t.Errorf("expected build %+v but got %+v", worker, dev.Build["worker"])
+	}
+	if !reflect.DeepEqual(dev.Image, api) || dev.EmptyImage {
+		t.Errorf("expected image %+v but got %+v", api, dev.Image)
+	}
+	if !reflect.DeepEqual(dev.Push, api) {
+		t.Errorf("expected push %+v but got %+v", api, dev.Push)
+	}
+	if dev.Push == dev.Build["api"] {
+		t.Errorf("push is not a copy of the build section")
+	}
+	if !reflect.DeepEqual(dev.Services[0].Image, worker) || !dev.Services[0].EmptyImage {
+		t.Errorf("expected service image %+v but got %+v", worker, dev.Services[0].Image)
+	}
+}
+
+func Test_LoadBuildLegacyPush(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest []byte
+		expected *BuildInfo
+	}{
+		{
+			name: "legacy-push",
+			manifest: []byte(`
+name: api
+push:
+  name: okteto/api
+  target: prod
+build:
+  api:
+    name: okteto/api:dev`),
+			expected: &BuildInfo{Name: "okteto/api", Context: ".", Dockerfile: "Dockerfile", Target: "prod"},
+		},
+		{
+			name: "push-reference",
+			manifest: []byte(`
+name: api
+push: frontend
+build:
+  frontend:
+    name: okteto/frontend`),
+			expected: &BuildInfo{Name: "okteto/frontend", Context: ".", Dockerfile: "Dockerfile"},
+		},
+		{
+			name: "no-build-section",
+			manifest: []byte(`
+name: api
+push:
+  context: api`),
+			expected: &BuildInfo{Context: "api", Dockerfile: "Dockerfile"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, err := Read(tt.manifest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(dev.Push, tt.expected) {
+				t.Errorf("expected push %+v but got %+v", tt.expected, dev.Push)
+			}
+		})
+	}
+}
+
+func Test_ReadInvalidResourceQuantity(t *testing.T) {
+	manifest := []byte(`
+name: deployment
+resources:
+  limits:
+    memory: 4GB`)
+	_, err := Read(manifest)
+	if err == nil {
+		t.Fatal("expected an error for an invalid quantity")
+	}
+	if !strings.Contains(err.Error(), "invalid quantity '4GB' for resource 'memory'") {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestDev_SecurityWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest []byte
+		expected int
+	}{
+		{
+			name:     "default-root",
+			manifest: []byte("name: api\n"),
+			expected: 0,
+		},
+		{
+			name:     "explicit-root",
+			manifest: []byte("name: api\nsecurityContext:\n  runAsUser: 0\n"),
+			expected: 1,
+		},
+		{
+			name:     "privileged",
+			manifest: []byte("name: api\nsecurityContext:\n  runAsUser: 1000\n  privileged: true\n"),
+			expected: 1,
+		},
+		{
+			name:     "services",
+			manifest: []byte("name: api\nservices:\n  - name: worker\n    securityContext:\n      runAsUser: 0\n      privileged: true\n"),
+			expected: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, err := Read(tt.manifest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if warnings := dev.SecurityWarnings(); len(warnings) != tt.expected {
+				t.Errorf("expected %d warnings, got %v", tt.expected, warnings)
+			}
+		})
+	}
+}
+
+func Test_LoadEnvFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("DB_HOST=db\nDEBUG=false\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "local.env"), []byte("DB_HOST=localhost\nCACHE=redis\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	manifest := []byte(`
+  name: deployment
+  image: code/core:0.1.8
+  environment:
+    DEBUG: "true"
+  envFile:
+    - .env
+    - local.env`)
+	manifestPath := filepath.Join(dir, "okteto.yml")
+	if err := os.WriteFile(manifestPath, manifest, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := Get(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Environment{
+		{Name: "DEBUG", Value: "true"},
+		{Name: "CACHE", Value: "redis"},
+		{Name: "DB_HOST", Value: "localhost"},
+	}
+	if !reflect.DeepEqual(dev.Environment, expected) {
+		t.Errorf("expected %+v but got %+v", expected, dev.Environment)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "local.env"), []byte("CACHE=memcached\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.LoadEnvFiles(); err != nil {
+		t.Fatal(err)
+	}
+	expected = Environment{
+		{Name: "DEBUG", Value: "true"},
+		{Name: "CACHE", Value: "memcached"},
+		{Name: "DB_HOST", Value: "db"},
+	}
+	if !reflect.DeepEqual(dev.Environment, expected) {
+		t.Errorf("expected %+v after reloading but got %+v", expected, dev.Environment)
+	}
+
+	dev.OverrideEnvironment(Environment{{Name: "DEBUG", Value: "false"}, {Name: "FLAG", Value: "on"}})
+	expected = Environment{
+		{Name: "DEBUG", Value: "false"},
+		{Name: "CACHE", Value: "memcached"},
+		{Name: "DB_HOST", Value: "db"},
+		{Name: "FLAG", Value: "on"},
+	}
+	if !reflect.DeepEqual(dev.Environment, expected) {
+		t.Errorf("expected %+v after overriding but got %+v", expected, dev.Environment)
+	}
+
+	if err := dev.LoadEnvFiles(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dev.Environment, expected) {
+		t.Errorf("expected %+v after reloading the overrides but got %+v", expected, dev.Environment)
+	}
+}
+
+func Test_SyncDefaultIgnores(t *testing.T) {
+	var tests = []struct {
+		name     string
+		manifest []byte
+		expected []string
+	}{
+		{
+			name: "default",
+			manifest: []byte(`name: deployment
+sync:
+  - .:/app`),
+			expected: DefaultSyncIgnores,
+		},
+		{
+			name: "disabled",
+			manifest: []byte(`name: deployment
+sync:
+  defaultIgnores: false
+  folders:
+    - .:/app`),
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, err := Read(tt.manifest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := dev.Sync.GetDefaultIgnores(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v but got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_ApplyResourceProfile(t *testing.T) {
+	manifest := []byte(`
+  name: deployment
+  image: code/core:0.1.8
+  resources:
+    requests:
+      cpu: 250m
+      memory: 64Mi
+    limits:
+      memory: 128Mi
+    profiles:
+      heavy:
+        requests:
+          cpu: "2"
+        limits:
+          cpu: "4"
+          memory: 8Gi
+      light:
+        limits:
+          memory: 256Mi`)
+
+	var tests = []struct {
+		name     string
+		profile  string
+		expected ResourceRequirements
+		wantErr  bool
+	}{
+		{
+			name:    "heavy",
+			profile: "heavy",
+			expected: ResourceRequirements{
+				Requests: ResourceList{
+					apiv1.ResourceCPU:    resource.MustParse("2"),
+					apiv1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+				Limits: ResourceList{
+					apiv1.ResourceCPU:    resource.MustParse("4"),
+					apiv1.ResourceMemory: resource.MustParse("8Gi"),
+				},
+			},
+		},
+		{
+			name:    "light",
+			profile: "light",
+			expected: ResourceRequirements{
+				Requests: ResourceList{
+					apiv1.ResourceCPU:    resource.MustParse("250m"),
+					apiv1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+				Limits: ResourceList{
+					apiv1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+			},
+		},
+		{
+			name:    "unknown",
+			profile: "medium",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, err := Read(manifest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = dev.ApplyResourceProfile(tt.profile)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error applying an unknown profile")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(dev.Resources.Requests, tt.expected.Requests) {
+				t.Errorf("expected requests %v but got %v", tt.expected.Requests, dev.Resources.Requests)
+			}
+			if !reflect.DeepEqual(dev.Resources.Limits, tt.expected.Limits) {
+				t.Errorf("expected limits %v but got %v", tt.expected.Limits, dev.Resources.Limits)
+			}
+		})
+	}
+}
+
 func Test_LoadForcePull(t *testing.T) {
 	manifest := []byte(`
   name: a
@@ -932,6 +1345,316 @@ func Test_validate(t *testing.T) {
         runAsGroup: 0`),
 			expectErr: false,
 		},
+		{
+			name: "divert-service-only",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      divert:
+        service: grpc
+        port: 9090`),
+			expectErr: false,
+		},
+		{
+			name: "divert-ingress-without-service",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      divert:
+        ingress: web
+        port: 8080`),
+			expectErr: true,
+		},
+		{
+			name: "divert-multiple-ingresses",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      divert:
+        ingress:
+          - api
+          - web
+        service: web
+        port: 8080`),
+			expectErr: false,
+		},
+		{
+			name: "divert-duplicated-ingresses",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      divert:
+        ingress:
+          - web
+          - web
+        service: web
+        port: 8080`),
+			expectErr: true,
+		},
+		{
+			name: "divert-empty",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      divert:
+        port: 8080`),
+			expectErr: true,
+		},
+		{
+			name: "sync-conflicts-dir-inside-sync-folder",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        conflictsDir: .conflicts
+        folders:
+          - .:/app`),
+			expectErr: true,
+		},
+		{
+			name: "sync-conflicts-dir-outside-sync-folder",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        conflictsDir: ../conflicts
+        folders:
+          - .:/app`),
+			expectErr: false,
+		},
+		{
+			name: "sync-rescan-interval",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        rescanInterval: 10
+        folders:
+          - .:/app`),
+			expectErr: false,
+		},
+		{
+			name: "scan-hook",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      scan:
+        hook: grype $OKTETO_SCAN_IMAGE --fail-on high`),
+			expectErr: false,
+		},
+		{
+			name: "scan-hook-and-command",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      scan:
+        command: trivy
+        hook: grype $OKTETO_SCAN_IMAGE --fail-on high`),
+			expectErr: true,
+		},
+		{
+			name: "sync-negative-rescan-interval",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        rescanInterval: -10
+        folders:
+          - .:/app`),
+			expectErr: true,
+		},
+		{
+			name: "resources",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      resources:
+        requests:
+          cpu: 500m
+          memory: 2Gi
+        limits:
+          cpu: "2"
+          memory: 4Gi`),
+			expectErr: false,
+		},
+		{
+			name: "resources-request-greater-than-limit",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      resources:
+        requests:
+          memory: 8Gi
+        limits:
+          memory: 4Gi`),
+			expectErr: true,
+		},
+		{
+			name: "resources-unsupported",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      resources:
+        limits:
+          memroy: 4Gi`),
+			expectErr: true,
+		},
+		{
+			name: "services-resources-request-greater-than-limit",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: foo
+          sync:
+            - .:/app
+          resources:
+            requests:
+              cpu: "4"
+            limits:
+              cpu: "2"`),
+			expectErr: true,
+		},
+		{
+			name: "valid-scheduling",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      nodeSelector:
+        cloud.google.com/gke-accelerator: nvidia-tesla-t4
+      tolerations:
+        - key: nvidia.com/gpu
+          operator: Exists
+          effect: NoSchedule
+        - key: pool
+          value: gpu
+          effect: NoExecute
+      affinity:
+        nodeAffinity:
+          requiredDuringSchedulingIgnoredDuringExecution:
+            nodeSelectorTerms:
+              - matchExpressions:
+                  - key: pool
+                    operator: In
+                    values:
+                      - gpu`),
+			expectErr: false,
+		},
+		{
+			name: "invalid-node-selector-key",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      nodeSelector:
+        "gpu pool": "true"`),
+			expectErr: true,
+		},
+		{
+			name: "invalid-node-selector-value",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      nodeSelector:
+        pool: "gpu/t4"`),
+			expectErr: true,
+		},
+		{
+			name: "invalid-toleration-operator",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      tolerations:
+        - key: pool
+          operator: Contains
+          value: gpu`),
+			expectErr: true,
+		},
+		{
+			name: "toleration-exists-with-value",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      tolerations:
+        - key: pool
+          operator: Exists
+          value: gpu`),
+			expectErr: true,
+		},
+		{
+			name: "invalid-toleration-effect",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      tolerations:
+        - key: pool
+          value: gpu
+          effect: NoRun`),
+			expectErr: true,
+		},
+		{
+			name: "invalid-node-affinity-operator",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      affinity:
+        nodeAffinity:
+          requiredDuringSchedulingIgnoredDuringExecution:
+            nodeSelectorTerms:
+              - matchExpressions:
+                  - key: pool
+                    operator: Equals
+                    values:
+                      - gpu`),
+			expectErr: true,
+		},
+		{
+			name: "valid-image-pull-secrets",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      imagePullSecrets:
+        - artifactory-registry`),
+			expectErr: false,
+		},
+		{
+			name: "invalid-image-pull-secrets",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      imagePullSecrets:
+        - Artifactory_Registry`),
+			expectErr: true,
+		},
+		{
+			name: "invalid-service-node-selector",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      services:
+        - name: worker
+          sync:
+            - .:/worker
+          nodeSelector:
+            pool: "gpu pool"`),
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1025,6 +1748,11 @@ func Test_ExpandEnv(t *testing.T) {
 			value:  "value-${FOO:-foo}-value",
 			result: "value-foo-value",
 		},
+		{
+			name:   "escaped",
+			value:  "value-$${BAR}-value",
+			result: "value-${BAR}-value",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1040,6 +1768,98 @@ func Test_ExpandEnv(t *testing.T) {
 	}
 }
 
+func Test_ExpandEnvStrict(t *testing.T) {
+	t.Setenv("BAR", "bar")
+	SetStrictEnv(true)
+	defer SetStrictEnv(false)
+
+	tests := []struct {
+		name    string
+		value   string
+		result  string
+		wantErr bool
+	}{
+		{
+			name:   "defined",
+			value:  "value-${BAR}",
+			result: "value-bar",
+		},
+		{
+			name:   "default",
+			value:  "value-${UNDEFINED_OKTETO_VAR:-foo}",
+			result: "value-foo",
+		},
+		{
+			name:   "escaped",
+			value:  "value-$${UNDEFINED_OKTETO_VAR}",
+			result: "value-${UNDEFINED_OKTETO_VAR}",
+		},
+		{
+			name:    "undefined",
+			value:   "value-${UNDEFINED_OKTETO_VAR}",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExpandEnv(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.result {
+				t.Errorf("got '%s', expected '%s'", result, tt.result)
+			}
+		})
+	}
+}
+
+func Test_ReadExpandEnv(t *testing.T) {
+	t.Setenv("TAG", "1.0")
+	t.Setenv("OKTETO_NAMESPACE", "cindy")
+	t.Setenv("BUILD_TARGET", "dev")
+	manifest := []byte(`
+name: api
+namespace: ${OKTETO_NAMESPACE}
+image:
+  name: registry.example.com/app:${TAG}
+  target: ${BUILD_TARGET}
+  cache_from:
+    - registry.example.com/app:${CACHE_TAG:-latest}
+push:
+  name: registry.example.com/app:${TAG}
+container: ${CONTAINER:-api}
+serviceAccount: ${SERVICE_ACCOUNT:-default}
+annotations:
+  version: ${TAG}
+`)
+	dev, err := Read(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev.Namespace != "cindy" {
+		t.Errorf("namespace: got '%s'", dev.Namespace)
+	}
+	if dev.Image.Name != "registry.example.com/app:1.0" || dev.Image.Target != "dev" || dev.Image.CacheFrom[0] != "registry.example.com/app:latest" {
+		t.Errorf("image: got %+v", dev.Image)
+	}
+	if dev.Push.Name != "registry.example.com/app:1.0" {
+		t.Errorf("push: got %+v", dev.Push)
+	}
+	if dev.Container != "api" || dev.ServiceAccount != "default" {
+		t.Errorf("container: got '%s', serviceAccount: got '%s'", dev.Container, dev.ServiceAccount)
+	}
+	if dev.Annotations["version"] != "1.0" {
+		t.Errorf("annotations: got %v", dev.Annotations)
+	}
+
+	SetStrictEnv(true)
+	defer SetStrictEnv(false)
+	if _, err := Read([]byte("name: api\nimage: registry.example.com/app:${UNDEFINED_OKTETO_TAG}\n")); err == nil {
+		t.Errorf("expected an error for an undefined variable in strict mode")
+	}
+}
+
 func TestGetTimeout(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -113,12 +113,14 @@ func MergeDevWithDevRc(dev *Dev, devRc *DevRC) {
 		return strings.Compare(dev.Environment[i].Name, dev.Environment[j].Name) < 0
 	})
 
-	for _, fwd := range devRc.Forward {
-		idx := getForwardPortIdx(dev.Forward, fwd)
-		if idx != -1 {
-			dev.Forward[idx] = fwd
-		} else {
-			dev.Forward = append(dev.Forward, fwd)
+	for _, rc := range devRc.Forward {
+		for _, fwd := range rc.expand() {
+			idx := getForwardPortIdx(dev.Forward, fwd)
+			if idx != -1 {
+				dev.Forward[idx] = fwd
+			} else {
+				dev.Forward = append(dev.Forward, fwd)
+			}
 		}
 
 	}
@@ -0,0 +1,167 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// extendsField is the manifest field with the path of the okteto manifest it extends
+const extendsField = "extends"
+
+// appendedFields are the top level lists of a manifest appended to the ones of the manifest it extends.
+// The rest of lists are replaced
+var appendedFields = map[string]bool{
+	"environment":     true,
+	"envFile":         true,
+	"externalVolumes": true,
+	"forward":         true,
+	"reverse":         true,
+	"secrets":         true,
+	"services":        true,
+	"volumes":         true,
+}
+
+// ExtendsAppendedFields returns the top level lists of an okteto manifest appended to the ones of the manifest it extends, sorted by name
+func ExtendsAppendedFields() []string {
+	fields := make([]string, 0, len(appendedFields))
+	for f := range appendedFields {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// readManifest returns the content of the okteto manifest in devPath, deep-merged over the manifests it extends
+func readManifest(devPath string) ([]byte, error) {
+	return readExtendedManifest(devPath, nil)
+}
+
+func readExtendedManifest(devPath string, visited []string) ([]byte, error) {
+	b, err := os.ReadFile(devPath)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(b, &manifest); err != nil {
+		// the error is returned with the right line numbers when reading the manifest
+		return b, nil
+	}
+	extends, ok := manifest[extendsField]
+	if !ok && len(visited) == 0 {
+		return b, nil
+	}
+
+	// the schema of each manifest is checked before merging them, so the errors refer to the lines of the file that has them
+	if err := checkManifestSchema(devPath, b); err != nil {
+		return nil, err
+	}
+	if !ok {
+		return b, nil
+	}
+	parentPath, ok := extends.(string)
+	if !ok || parentPath == "" {
+		return nil, fmt.Errorf("invalid manifest '%s': '%s' must be the path of an okteto manifest", devPath, extendsField)
+	}
+
+	absPath, err := filepath.Abs(devPath)
+	if err != nil {
+		return nil, err
+	}
+	visited = append(visited, absPath)
+	parentPath = loadAbsPath(filepath.Dir(absPath), parentPath)
+	for _, p := range visited {
+		if p == parentPath {
+			return nil, fmt.Errorf("invalid manifest '%s': cycle in '%s': %s -> %s", devPath, extendsField, strings.Join(visited, " -> "), parentPath)
+		}
+	}
+
+	parentBytes, err := readExtendedManifest(parentPath, visited)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("invalid manifest '%s': the manifest '%s' in '%s' does not exist", devPath, parentPath, extendsField)
+		}
+		return nil, err
+	}
+	parent := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(parentBytes, &parent); err != nil {
+		return nil, fmt.Errorf("invalid manifest '%s': %s", parentPath, err)
+	}
+
+	delete(manifest, extendsField)
+	return yaml.Marshal(mergeManifests(parent, manifest))
+}
+
+// checkManifestSchema returns the schema errors of the content of an okteto manifest that is part of an 'extends' chain
+func checkManifestSchema(devPath string, b []byte) error {
+	err := unmarshalManifest(b, &Dev{}, extendsField)
+	if err == nil {
+		return nil
+	}
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		return fmt.Errorf("%s: %w", devPath, err)
+	}
+	for i := range schemaErr.Errors {
+		schemaErr.Errors[i] = fmt.Sprintf("%s: %s", devPath, schemaErr.Errors[i])
+	}
+	return schemaErr
+}
+
+// mergeManifests deep-merges child over parent.
+// Maps are merged, the top level lists of appendedFields are appended and the rest of values are replaced
+func mergeManifests(parent, child map[interface{}]interface{}) map[interface{}]interface{} {
+	result := mergeMaps(parent, child)
+	for key := range appendedFields {
+		parentList, ok := parent[key].([]interface{})
+		if !ok {
+			continue
+		}
+		childList, ok := child[key].([]interface{})
+		if !ok {
+			continue
+		}
+		result[key] = append(append([]interface{}{}, parentList...), childList...)
+	}
+	return result
+}
+
+func mergeMaps(parent, child map[interface{}]interface{}) map[interface{}]interface{} {
+	result := map[interface{}]interface{}{}
+	for k, v := range parent {
+		result[k] = v
+	}
+	for k, v := range child {
+		parentMap, ok := result[k].(map[interface{}]interface{})
+		if !ok {
+			result[k] = v
+			continue
+		}
+		childMap, ok := v.(map[interface{}]interface{})
+		if !ok {
+			result[k] = v
+			continue
+		}
+		result[k] = mergeMaps(parentMap, childMap)
+	}
+	return result
+}
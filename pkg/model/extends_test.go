@@ -0,0 +1,196 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeManifest(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_GetExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, filepath.Join(dir, "base-okteto.yml"), `
+image: okteto/base:1.0
+command: ["bash"]
+forward:
+  - 8080:8080
+environment:
+  - ENVIRONMENT=dev
+  - LOG_LEVEL=info
+resources:
+  limits:
+    cpu: "1"
+    memory: 1Gi
+`)
+	writeManifest(t, filepath.Join(dir, "api", "okteto.yml"), `
+extends: ../base-okteto.yml
+name: api
+command: ["yarn", "start"]
+forward:
+  - 9229:9229
+environment:
+  - LOG_LEVEL=debug
+resources:
+  limits:
+    memory: 2Gi
+`)
+
+	dev, err := Get(filepath.Join(dir, "api", "okteto.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dev.Name != "api" {
+		t.Errorf("expected name 'api', got '%s'", dev.Name)
+	}
+	if dev.Image.Name != "okteto/base:1.0" {
+		t.Errorf("expected the image of the base manifest, got '%s'", dev.Image.Name)
+	}
+	if !reflect.DeepEqual(dev.Command.Values, []string{"yarn", "start"}) {
+		t.Errorf("expected the command to be replaced, got %v", dev.Command.Values)
+	}
+	if len(dev.Forward) != 2 || dev.Forward[0].Local != 8080 || dev.Forward[1].Local != 9229 {
+		t.Errorf("expected the forwards to be appended, got %+v", dev.Forward)
+	}
+	expectedEnv := Environment{
+		{Name: "ENVIRONMENT", Value: "dev"},
+		{Name: "LOG_LEVEL", Value: "debug"},
+	}
+	if !reflect.DeepEqual(dev.Environment, expectedEnv) {
+		t.Errorf("expected environment %+v, got %+v", expectedEnv, dev.Environment)
+	}
+	if cpu := dev.Resources.Limits["cpu"]; cpu.String() != "1" {
+		t.Errorf("expected the cpu limit of the base manifest, got '%s'", cpu.String())
+	}
+	if memory := dev.Resources.Limits["memory"]; memory.String() != "2Gi" {
+		t.Errorf("expected the memory limit to be merged, got '%s'", memory.String())
+	}
+}
+
+func Test_GetExtendsErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, filepath.Join(dir, "a.yml"), "extends: b.yml\nname: a\n")
+	writeManifest(t, filepath.Join(dir, "b.yml"), "extends: a.yml\nname: b\n")
+	writeManifest(t, filepath.Join(dir, "self.yml"), "extends: ./self.yml\nname: self\n")
+	writeManifest(t, filepath.Join(dir, "missing.yml"), "extends: base.yml\nname: missing\n")
+	writeManifest(t, filepath.Join(dir, "invalid.yml"), "extends: [base.yml]\nname: invalid\n")
+
+	tests := []struct {
+		name     string
+		manifest string
+		expected string
+	}{
+		{name: "cycle", manifest: "a.yml", expected: "cycle in 'extends'"},
+		{name: "self", manifest: "self.yml", expected: "cycle in 'extends'"},
+		{name: "missing", manifest: "missing.yml", expected: "does not exist"},
+		{name: "invalid", manifest: "invalid.yml", expected: "'extends' must be the path of an okteto manifest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Get(filepath.Join(dir, tt.manifest))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected '%s' in error '%s'", tt.expected, err.Error())
+			}
+		})
+	}
+}
+
+func Test_GetExtendsSchemaErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, filepath.Join(dir, "base.yml"), "image: okteto/base:1.0\nforward:\n  - 8080:8080\n")
+	writeManifest(t, filepath.Join(dir, "okteto.yml"), `extends: base.yml
+name: api
+command: ["yarn", "start"]
+environment:
+  - LOG_LEVEL=debug
+workdir: /app
+fowrard:
+  - 9229:9229
+`)
+	writeManifest(t, filepath.Join(dir, "typo-base.yml"), "image: okteto/base:1.0\n\nenviroment:\n  - LOG_LEVEL=info\n")
+	writeManifest(t, filepath.Join(dir, "child.yml"), "extends: typo-base.yml\nname: api\n")
+
+	tests := []struct {
+		name     string
+		manifest string
+		expected string
+	}{
+		{
+			name:     "child",
+			manifest: "okteto.yml",
+			expected: "okteto.yml: line 7: unknown field 'fowrard' (did you mean 'forward'?)",
+		},
+		{
+			name:     "extended",
+			manifest: "child.yml",
+			expected: "typo-base.yml: line 3: unknown field 'enviroment' (did you mean 'environment'?)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Get(filepath.Join(dir, tt.manifest))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected '%s' in error '%s'", tt.expected, err.Error())
+			}
+		})
+	}
+}
+
+func Test_mergeManifests(t *testing.T) {
+	parent := map[interface{}]interface{}{
+		"image":   "okteto/base",
+		"forward": []interface{}{"8080:8080"},
+		"command": []interface{}{"bash"},
+		"sync":    []interface{}{".:/app"},
+		"labels":  map[interface{}]interface{}{"app": "base", "tier": "backend"},
+	}
+	child := map[interface{}]interface{}{
+		"forward": []interface{}{"9229:9229"},
+		"command": []interface{}{"yarn"},
+		"sync":    []interface{}{"src:/app/src"},
+		"labels":  map[interface{}]interface{}{"app": "api"},
+	}
+	expected := map[interface{}]interface{}{
+		"image":   "okteto/base",
+		"forward": []interface{}{"8080:8080", "9229:9229"},
+		"command": []interface{}{"yarn"},
+		"sync":    []interface{}{"src:/app/src"},
+		"labels":  map[interface{}]interface{}{"app": "api", "tier": "backend"},
+	}
+	if got := mergeManifests(parent, child); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if len(parent["forward"].([]interface{})) != 1 {
+		t.Errorf("the parent manifest was modified")
+	}
+}
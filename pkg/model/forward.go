@@ -15,33 +15,47 @@ package model
 
 import (
 	"fmt"
+	"net"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
 
-const malformedPortForward = "Wrong port-forward syntax '%s', must be of the form 'localPort:remotePort' or 'localPort:serviceName:remotePort'"
+const malformedPortForward = "Wrong port-forward syntax '%s', must be of the form 'localPort:remotePort', 'localPort:serviceName:remotePort', 'localStart-localEnd:remoteStart-remoteEnd' or '/local/socket:remotePort', optionally starting with the local bind address like '0.0.0.0:localPort:remotePort'"
 
 // Forward represents a port forwarding definition
 type Forward struct {
 	Local       int               `json:"localPort" yaml:"localPort"`
+	Socket      string            `json:"localSocket,omitempty" yaml:"localSocket,omitempty"`
 	Remote      int               `json:"remotePort" yaml:"remotePort"`
 	Service     bool              `json:"-" yaml:"-"`
 	ServiceName string            `json:"name" yaml:"name"`
 	Labels      map[string]string `json:"labels" yaml:"labels"`
+	BindAddress string            `json:"bindAddress,omitempty" yaml:"bindAddress,omitempty"`
+
+	// rangeLength is the number of ports of a port range, expanded into a forward for each port when the manifest is loaded
+	rangeLength int
 }
 
 type ForwardRaw struct {
 	Local       int               `json:"localPort" yaml:"localPort"`
+	Socket      string            `json:"localSocket,omitempty" yaml:"localSocket,omitempty"`
 	Remote      int               `json:"remotePort" yaml:"remotePort"`
 	Service     bool              `json:"-" yaml:"-"`
 	ServiceName string            `json:"name" yaml:"name"`
 	Labels      map[string]string `json:"labels" yaml:"labels"`
+	BindAddress string            `json:"bindAddress,omitempty" yaml:"bindAddress,omitempty"`
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg for port forwards.
 // It supports the following options:
 // - int:int
 // - int:serviceName:int
+// - int-int:int-int
+// - int-int:serviceName:int-int
+// - /path/to/socket:int
+// - /path/to/socket:serviceName:int
+// The port forms can start with the local bind address, like 0.0.0.0:int:int.
 // Anything else will result in an error
 func (f *Forward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var raw string
@@ -51,15 +65,28 @@ func (f *Forward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	}
 
 	parts := strings.Split(raw, ":")
+	if len(parts) > 2 && isBindAddress(parts[0]) {
+		f.BindAddress = parts[0]
+		parts = parts[1:]
+	}
 	if len(parts) < 2 || len(parts) > 3 {
 		return fmt.Errorf(malformedPortForward, raw)
 	}
 
-	localPort, err := strconv.Atoi(parts[0])
-	if err != nil {
-		return fmt.Errorf("Cannot convert local port '%s' in port-forward '%s'", parts[0], raw)
+	remote := parts[len(parts)-1]
+	if !isSocketPath(parts[0]) && (strings.Contains(parts[0], "-") || strings.Contains(remote, "-")) {
+		return f.unmarshalRange(raw, parts[0], remote, parts[1:len(parts)-1])
+	}
+
+	if isSocketPath(parts[0]) {
+		f.Socket = parts[0]
+	} else {
+		localPort, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return fmt.Errorf("Cannot convert local port '%s' in port-forward '%s'", parts[0], raw)
+		}
+		f.Local = localPort
 	}
-	f.Local = localPort
 
 	if len(parts) == 2 {
 		p, err := strconv.Atoi(parts[1])
@@ -82,20 +109,155 @@ func (f *Forward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// unmarshalRange parses a port range forward like '9000-9010:9000-9010'
+func (f *Forward) unmarshalRange(raw, local, remote string, service []string) error {
+	localStart, localEnd, err := parsePortRange(local)
+	if err != nil {
+		return fmt.Errorf("Wrong local port range '%s' in port-forward '%s': %w", local, raw, err)
+	}
+	remoteStart, remoteEnd, err := parsePortRange(remote)
+	if err != nil {
+		return fmt.Errorf("Wrong remote port range '%s' in port-forward '%s': %w", remote, raw, err)
+	}
+	if localEnd-localStart != remoteEnd-remoteStart {
+		return fmt.Errorf("The local port range '%s' and the remote port range '%s' of port-forward '%s' must have the same number of ports", local, remote, raw)
+	}
+
+	f.Local = localStart
+	f.Remote = remoteStart
+	if localEnd > localStart {
+		f.rangeLength = localEnd - localStart + 1
+	}
+	if len(service) == 1 {
+		f.Service = true
+		f.ServiceName = service[0]
+	}
+	return nil
+}
+
+func parsePortRange(s string) (int, int, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("it must be of the form 'start-end'")
+	}
+	start, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("'%s' is not a port", parts[0])
+	}
+	end, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("'%s' is not a port", parts[1])
+	}
+	if start <= 0 || end > 65535 || start > end {
+		return 0, 0, fmt.Errorf("the ports must be between 1 and 65535 and the start can't be greater than the end")
+	}
+	return start, end, nil
+}
+
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (f Forward) MarshalYAML() (interface{}, error) {
 	return f.String(), nil
 }
 
 func (f Forward) String() string {
+	if f.BindAddress != "" {
+		bound := f
+		bound.BindAddress = ""
+		return fmt.Sprintf("%s:%s", f.BindAddress, bound.String())
+	}
+
+	if f.isRange() {
+		if f.Service {
+			return fmt.Sprintf("%d-%d:%s:%d-%d", f.Local, f.lastLocal(), f.ServiceName, f.Remote, f.Remote+f.rangeLength-1)
+		}
+		return fmt.Sprintf("%d-%d:%d-%d", f.Local, f.lastLocal(), f.Remote, f.Remote+f.rangeLength-1)
+	}
+
 	if f.Service {
-		return fmt.Sprintf("%d:%s:%d", f.Local, f.ServiceName, f.Remote)
+		return fmt.Sprintf("%s:%s:%d", f.LocalString(), f.ServiceName, f.Remote)
 	}
 
-	return fmt.Sprintf("%d:%d", f.Local, f.Remote)
+	return fmt.Sprintf("%s:%d", f.LocalString(), f.Remote)
+}
+
+// IsSocket returns true if the forward listens on a local UNIX socket instead of a local port
+func (f Forward) IsSocket() bool {
+	return f.Socket != ""
+}
+
+// LocalString returns the local side of the forward, the socket path or the port number
+func (f Forward) LocalString() string {
+	if f.IsSocket() {
+		return f.Socket
+	}
+	return strconv.Itoa(f.Local)
+}
+
+func (f Forward) isRange() bool {
+	return f.rangeLength > 1
+}
+
+// lastLocal returns the last local port of a port range, or the local port of a single forward
+func (f Forward) lastLocal() int {
+	if f.isRange() {
+		return f.Local + f.rangeLength - 1
+	}
+	return f.Local
+}
+
+// overlaps returns true if both forwards listen on some of the same local ports
+func (f Forward) overlaps(c Forward) bool {
+	if f.IsSocket() || c.IsSocket() {
+		return false
+	}
+	return f.Local <= c.lastLocal() && c.Local <= f.lastLocal()
+}
+
+// expand returns a forward for each port of a port range
+func (f Forward) expand() []Forward {
+	if !f.isRange() {
+		return []Forward{f}
+	}
+
+	result := make([]Forward, 0, f.rangeLength)
+	for i := 0; i < f.rangeLength; i++ {
+		port := f
+		port.Local = f.Local + i
+		port.Remote = f.Remote + i
+		port.rangeLength = 0
+		result = append(result, port)
+	}
+	return result
+}
+
+// expandForwardRanges replaces the port ranges by a forward for each port of the range.
+// It returns an error if a port range overlaps with any other forward
+func expandForwardRanges(forwards []Forward) ([]Forward, error) {
+	result := []Forward{}
+	for i, f := range forwards {
+		for j := i + 1; j < len(forwards); j++ {
+			if (f.isRange() || forwards[j].isRange()) && f.overlaps(forwards[j]) {
+				return nil, fmt.Errorf("the port-forward '%s' overlaps with the port-forward '%s'", f.String(), forwards[j].String())
+			}
+		}
+		result = append(result, f.expand()...)
+	}
+	return result, nil
+}
+
+func isBindAddress(s string) bool {
+	return s == Localhost || net.ParseIP(s) != nil
+}
+
+func isSocketPath(s string) bool {
+	return strings.HasPrefix(s, "/") || strings.HasPrefix(s, ".")
 }
 
 func (f *Forward) less(c *Forward) bool {
+	if f.Local == c.Local && f.Service == c.Service {
+		return f.Socket < c.Socket
+	}
+
 	if !f.Service && !c.Service {
 		return f.Local < c.Local
 	}
@@ -119,9 +281,11 @@ func (f *Forward) UnmarshalExtendedForm(unmarshal func(interface{}) error) error
 		return err
 	}
 	f.Local = rawForward.Local
+	f.Socket = rawForward.Socket
 	f.Remote = rawForward.Remote
 	f.ServiceName = rawForward.ServiceName
 	f.Labels = rawForward.Labels
+	f.BindAddress = rawForward.BindAddress
 	if len(rawForward.Labels) != 0 || rawForward.ServiceName != "" {
 		f.Service = true
 	}
@@ -130,3 +294,31 @@ func (f *Forward) UnmarshalExtendedForm(unmarshal func(interface{}) error) error
 	}
 	return nil
 }
+
+// ValidateBindAddress returns an error if the address can't be used as the local bind address of a forward
+func ValidateBindAddress(address string) error {
+	if address == "" || address == Localhost {
+		return nil
+	}
+	if ip := net.ParseIP(address); ip == nil || ip.To4() == nil {
+		return fmt.Errorf("'%s' is not a valid bind address, it must be 'localhost' or an IPv4 address", address)
+	}
+	return nil
+}
+
+// ValidateSocket returns an error if the path can't be used as the local UNIX socket of a forward
+func ValidateSocket(path string) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("'%s' is not a valid socket, it must be an absolute path", path)
+	}
+	return nil
+}
+
+// IsLoopbackAddress returns true if the address is only reachable from the local machine
+func IsLoopbackAddress(address string) bool {
+	if address == Localhost {
+		return true
+	}
+	ip := net.ParseIP(address)
+	return ip != nil && ip.IsLoopback()
+}
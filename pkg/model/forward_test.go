@@ -78,6 +78,76 @@ func TestForward_UnmarshalYAML(t *testing.T) {
 			expectErr: false,
 			expected:  Forward{Local: 8080, Remote: 5214, Service: true, ServiceName: "svc"},
 		},
+		{
+			name:     "socket",
+			data:     "/tmp/app.sock:5432",
+			expected: Forward{Socket: "/tmp/app.sock", Remote: 5432},
+		},
+		{
+			name:     "socket-service",
+			data:     "/tmp/app.sock:svc:5432",
+			expected: Forward{Socket: "/tmp/app.sock", Remote: 5432, Service: true, ServiceName: "svc"},
+		},
+		{
+			name:     "socket-with-hyphen",
+			data:     "/tmp/my-app.sock:5432",
+			expected: Forward{Socket: "/tmp/my-app.sock", Remote: 5432},
+		},
+		{
+			name:     "socket-with-hyphen-service",
+			data:     "/tmp/my-app.sock:my-svc:5432",
+			expected: Forward{Socket: "/tmp/my-app.sock", Remote: 5432, Service: true, ServiceName: "my-svc"},
+		},
+		{
+			name:      "socket-remote-range",
+			data:      "/tmp/app.sock:5432-5433",
+			expectErr: true,
+		},
+		{
+			name:     "bind-address",
+			data:     "0.0.0.0:5432:5432",
+			expected: Forward{Local: 5432, Remote: 5432, BindAddress: "0.0.0.0"},
+		},
+		{
+			name:     "bind-address-localhost",
+			data:     "localhost:8080:80",
+			expected: Forward{Local: 8080, Remote: 80, BindAddress: "localhost"},
+		},
+		{
+			name:     "bind-address-service",
+			data:     "192.168.1.10:8080:svc:80",
+			expected: Forward{Local: 8080, Remote: 80, Service: true, ServiceName: "svc", BindAddress: "192.168.1.10"},
+		},
+		{
+			name:      "bind-address-without-local-port",
+			data:      "0.0.0.0:5432",
+			expectErr: true,
+		},
+		{
+			name:     "range",
+			data:     "9000-9010:9100-9110",
+			expected: Forward{Local: 9000, Remote: 9100, rangeLength: 11},
+		},
+		{
+			name:     "range-service",
+			data:     "9000-9002:svc:9000-9002",
+			expected: Forward{Local: 9000, Remote: 9000, Service: true, ServiceName: "svc", rangeLength: 3},
+		},
+		{
+			name:      "range-different-lengths",
+			data:      "9000-9010:9000-9005",
+			expectErr: true,
+		},
+		{
+			name:      "range-reversed",
+			data:      "9010-9000:9010-9000",
+			expectErr: true,
+		},
+		{
+			name:      "range-to-port",
+			data:      "9000-9010:9000",
+			expectErr: true,
+		},
 		{
 			name:      "bad-local-port",
 			data:      "local:8080",
@@ -224,3 +294,151 @@ func TestForwardExtended_MarshalYAML(t *testing.T) {
 		})
 	}
 }
+
+func TestForward_UnmarshalBindAddress(t *testing.T) {
+	data := []byte(`localPort: 8080
+remotePort: 80
+bindAddress: 0.0.0.0`)
+	var result Forward
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	expected := Forward{Local: 8080, Remote: 80, BindAddress: "0.0.0.0"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("didn't unmarshal correctly. Actual '%+v', Expected '%+v'", result, expected)
+	}
+}
+
+func TestValidateBindAddress(t *testing.T) {
+	tests := []struct {
+		address   string
+		expectErr bool
+	}{
+		{address: ""},
+		{address: "localhost"},
+		{address: "127.0.0.1"},
+		{address: "0.0.0.0"},
+		{address: "192.168.1.10"},
+		{address: "::1", expectErr: true},
+		{address: "my-laptop", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := ValidateBindAddress(tt.address)
+			if tt.expectErr && err == nil {
+				t.Error("didn't got expected error")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestForward_UnmarshalSocket(t *testing.T) {
+	data := []byte(`localSocket: /tmp/app.sock
+remotePort: 5432`)
+	var result Forward
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	expected := Forward{Socket: "/tmp/app.sock", Remote: 5432}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("didn't unmarshal correctly. Actual '%+v', Expected '%+v'", result, expected)
+	}
+}
+
+func TestValidateSocket(t *testing.T) {
+	tests := []struct {
+		path      string
+		expectErr bool
+	}{
+		{path: "/tmp/app.sock"},
+		{path: "./app.sock", expectErr: true},
+		{path: "app.sock", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			err := ValidateSocket(tt.path)
+			if tt.expectErr && err == nil {
+				t.Error("didn't got expected error")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestExpandForwardRanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		forwards  []Forward
+		expected  []Forward
+		expectErr bool
+	}{
+		{
+			name:     "single",
+			forwards: []Forward{{Local: 8080, Remote: 80}},
+			expected: []Forward{{Local: 8080, Remote: 80}},
+		},
+		{
+			name:     "range",
+			forwards: []Forward{{Local: 8080, Remote: 80}, {Local: 9000, Remote: 9100, rangeLength: 3}},
+			expected: []Forward{
+				{Local: 8080, Remote: 80},
+				{Local: 9000, Remote: 9100},
+				{Local: 9001, Remote: 9101},
+				{Local: 9002, Remote: 9102},
+			},
+		},
+		{
+			name:     "range-service",
+			forwards: []Forward{{Local: 9000, Remote: 9000, Service: true, ServiceName: "svc", rangeLength: 2}},
+			expected: []Forward{
+				{Local: 9000, Remote: 9000, Service: true, ServiceName: "svc"},
+				{Local: 9001, Remote: 9001, Service: true, ServiceName: "svc"},
+			},
+		},
+		{
+			name:      "overlapping-ranges",
+			forwards:  []Forward{{Local: 9000, Remote: 9000, rangeLength: 10}, {Local: 9005, Remote: 9105, rangeLength: 10}},
+			expectErr: true,
+		},
+		{
+			name:      "range-overlapping-port",
+			forwards:  []Forward{{Local: 9005, Remote: 80}, {Local: 9000, Remote: 9000, rangeLength: 10}},
+			expectErr: true,
+		},
+		{
+			name:     "adjacent-ranges",
+			forwards: []Forward{{Local: 9000, Remote: 9000, rangeLength: 2}, {Local: 9002, Remote: 9002, rangeLength: 2}},
+			expected: []Forward{
+				{Local: 9000, Remote: 9000},
+				{Local: 9001, Remote: 9001},
+				{Local: 9002, Remote: 9002},
+				{Local: 9003, Remote: 9003},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := expandForwardRanges(tt.forwards)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't got expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("didn't expand correctly. Actual '%+v', Expected '%+v'", result, tt.expected)
+			}
+		})
+	}
+}
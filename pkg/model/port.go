@@ -16,6 +16,7 @@ package model
 import (
 	"fmt"
 	"net"
+	"os"
 
 	"github.com/okteto/okteto/pkg/log"
 )
@@ -37,6 +38,28 @@ func GetAvailablePort(iface string) (int, error) {
 
 }
 
+// CheckSocketAvailable returns an error if a local UNIX socket can't be created on path.
+// A leftover socket that nobody is listening on can be replaced
+func CheckSocketAvailable(path string) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("local socket %s can't be created, the file already exists", path)
+	}
+
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return fmt.Errorf("local socket %s is already in-use in your local machine", path)
+	}
+
+	return nil
+}
+
 // IsPortAvailable returns true if the port is already taken
 func IsPortAvailable(iface string, port int) bool {
 	address := fmt.Sprintf("%s:%d", iface, port)
@@ -16,6 +16,8 @@ package model
 import (
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -51,3 +53,33 @@ func TestIsPortAvailable(t *testing.T) {
 		t.Fatalf("port %d was available", p)
 	}
 }
+
+func TestCheckSocketAvailable(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.sock")
+	if err := CheckSocketAvailable(path); err != nil {
+		t.Fatalf("socket %s wasn't available: %s", path, err)
+	}
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckSocketAvailable(path); err == nil {
+		t.Fatalf("socket %s was available while listening", path)
+	}
+
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+	l.Close()
+	if err := CheckSocketAvailable(path); err != nil {
+		t.Fatalf("leftover socket %s wasn't available: %s", path, err)
+	}
+
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, []byte("data"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckSocketAvailable(file); err == nil {
+		t.Fatalf("regular file %s was available", file)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// unknownFieldRegex matches the errors of the yaml strict decoder for unknown fields
+var unknownFieldRegex = regexp.MustCompile(`^line (\d+): field (\S+) not found(?: in type (\S+))?$`)
+
+// manifestFields are the yaml keys of the okteto manifest types, indexed by the type names of the yaml errors
+var manifestFields = getManifestFields(
+	reflect.TypeOf(Dev{}),
+	reflect.TypeOf(buildInfoRaw{}),
+	reflect.TypeOf(syncRaw{}),
+	reflect.TypeOf(reverseRaw{}),
+	reflect.TypeOf(storageResourceRaw{}),
+	reflect.TypeOf(probesRaw{}),
+	reflect.TypeOf(lifecycleRaw{}),
+	reflect.TypeOf(AffinityRaw{}),
+)
+
+// SchemaError is the error returned when the fields of an okteto manifest don't match its schema
+type SchemaError struct {
+	Errors []string
+}
+
+func (e *SchemaError) Error() string {
+	var sb strings.Builder
+	_, _ = sb.WriteString("Invalid manifest:\n")
+	for _, err := range e.Errors {
+		_, _ = sb.WriteString(fmt.Sprintf("    - %s\n", err))
+	}
+	_, _ = sb.WriteString("    See https://okteto.com/docs/reference/manifest/ for details")
+	return sb.String()
+}
+
+// formatUnmarshalError rewrites an unknown field error of the yaml strict decoder as
+// "line 12: unknown field 'fowrard' (did you mean 'forward'?)"
+func formatUnmarshalError(e string) string {
+	m := unknownFieldRegex.FindStringSubmatch(e)
+	if m == nil {
+		return e
+	}
+	line, field, typeName := m[1], m[2], m[3]
+	if typeName == "" {
+		typeName = reflect.TypeOf(Dev{}).String()
+	}
+
+	msg := fmt.Sprintf("line %s: unknown field '%s'", line, field)
+	if suggestion := getSuggestion(field, manifestFields[typeName]); suggestion != "" {
+		msg = fmt.Sprintf("%s (did you mean '%s'?)", msg, suggestion)
+	}
+	return msg
+}
+
+// isIgnoredField returns if an error of the yaml strict decoder is about an unknown top level field in fields
+func isIgnoredField(e string, fields []string) bool {
+	m := unknownFieldRegex.FindStringSubmatch(e)
+	if m == nil || m[3] != "" {
+		return false
+	}
+	for _, f := range fields {
+		if m[2] == f {
+			return true
+		}
+	}
+	return false
+}
+
+// getSuggestion returns the closest candidate to field, or an empty string if none of them is close enough
+func getSuggestion(field string, candidates []string) string {
+	maxDistance := len(field) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	suggestion := ""
+	best := maxDistance + 1
+	for _, c := range candidates {
+		d := levenshtein(strings.ToLower(field), strings.ToLower(c))
+		if d < best {
+			suggestion = c
+			best = d
+		}
+	}
+	return suggestion
+}
+
+// levenshtein returns the number of single character edits needed to turn a into b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
+
+// getManifestFields returns the yaml keys of the struct types reachable from types, indexed by type name
+func getManifestFields(types ...reflect.Type) map[string][]string {
+	result := map[string][]string{}
+	for _, t := range types {
+		addManifestFields(t, result)
+	}
+	return result
+}
+
+func addManifestFields(t reflect.Type, result map[string][]string) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	if _, ok := result[t.String()]; ok {
+		return
+	}
+
+	fields := []string{}
+	result[t.String()] = fields
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := strings.Split(f.Tag.Get("yaml"), ",")
+		name := tag[0]
+		if name == "-" {
+			continue
+		}
+		if len(tag) > 1 && tag[1] == "inline" {
+			addManifestFields(f.Type, result)
+			fields = append(fields, result[f.Type.String()]...)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields = append(fields, name)
+		addManifestFields(f.Type, result)
+	}
+	result[t.String()] = fields
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_ReadUnknownFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		expected string
+	}{
+		{
+			name:     "top-level-typo",
+			manifest: "name: api\nimage: okteto/api\nfowrard:\n  - 8080:80\n",
+			expected: "line 3: unknown field 'fowrard' (did you mean 'forward'?)",
+		},
+		{
+			name:     "nested-typo",
+			manifest: "name: api\nsync:\n  folders:\n    - .:/app\n  rescanInterva: 3\n",
+			expected: "line 5: unknown field 'rescanInterva' (did you mean 'rescanInterval'?)",
+		},
+		{
+			name:     "service-typo",
+			manifest: "name: api\nservices:\n  - name: worker\n    imag: okteto/worker\n",
+			expected: "line 4: unknown field 'imag' (did you mean 'image'?)",
+		},
+		{
+			name:     "build-typo",
+			manifest: "name: api\nimage:\n  contxt: .\n",
+			expected: "line 3: unknown field 'contxt' (did you mean 'context'?)",
+		},
+		{
+			name:     "no-suggestion",
+			manifest: "name: api\nfoo: bar\n",
+			expected: "line 2: unknown field 'foo'\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read([]byte(tt.manifest))
+			if err == nil {
+				t.Fatal("expected an error for an unknown field")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected '%s' in error '%s'", tt.expected, err.Error())
+			}
+		})
+	}
+}
+
+func Test_getSuggestion(t *testing.T) {
+	candidates := []string{"forward", "reverse", "environment", "persistentVolume"}
+	tests := []struct {
+		field    string
+		expected string
+	}{
+		{field: "fordward", expected: "forward"},
+		{field: "Forward", expected: "forward"},
+		{field: "enviroment", expected: "environment"},
+		{field: "persistentvolumes", expected: "persistentVolume"},
+		{field: "volumes", expected: ""},
+		{field: "xyz", expected: ""},
+	}
+	for _, tt := range tests {
+		if got := getSuggestion(tt.field, candidates); got != tt.expected {
+			t.Errorf("getSuggestion(%s) = '%s', expected '%s'", tt.field, got, tt.expected)
+		}
+	}
+}
+
+func Test_levenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "", b: "", expected: 0},
+		{a: "forward", b: "forward", expected: 0},
+		{a: "fowrard", b: "forward", expected: 2},
+		{a: "imag", b: "image", expected: 1},
+		{a: "", b: "sync", expected: 4},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.expected {
+			t.Errorf("levenshtein(%s, %s) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
@@ -43,11 +43,19 @@ type syncRaw struct {
 	Compression    bool         `json:"compression" yaml:"compression"`
 	Verbose        bool         `json:"verbose" yaml:"verbose"`
 	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
+	ConflictsDir   string       `json:"conflictsDir,omitempty" yaml:"conflictsDir,omitempty"`
+	DefaultIgnores *bool        `json:"defaultIgnores,omitempty" yaml:"defaultIgnores,omitempty"`
 	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
 	LocalPath      string
 	RemotePath     string
 }
 
+type reverseRaw struct {
+	Remote       int  `json:"remotePort" yaml:"remotePort"`
+	Local        int  `json:"localPort" yaml:"localPort"`
+	WaitForLocal bool `json:"waitForLocal,omitempty" yaml:"waitForLocal,omitempty"`
+}
+
 type storageResourceRaw struct {
 	Size  Quantity `json:"size,omitempty" yaml:"size,omitempty"`
 	Class string   `json:"class,omitempty" yaml:"class,omitempty"`
@@ -206,17 +214,21 @@ func (c *Command) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		if err != nil {
 			return err
 		}
-		if strings.Contains(single, " ") {
-			c.Values = []string{"sh", "-c", single}
-		} else {
-			c.Values = []string{single}
-		}
+		*c = NewCommand(single)
 	} else {
 		c.Values = multi
 	}
 	return nil
 }
 
+// NewCommand returns the command of a single string. A string with arguments runs with 'sh -c'
+func NewCommand(single string) Command {
+	if strings.Contains(single, " ") {
+		return Command{Values: []string{"sh", "-c", single}}
+	}
+	return Command{Values: []string{single}}
+}
+
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (c Command) MarshalYAML() (interface{}, error) {
 	if len(c.Values) == 1 && !strings.Contains(c.Values[0], " ") {
@@ -225,6 +237,31 @@ func (c Command) MarshalYAML() (interface{}, error) {
 	return c.Values, nil
 }
 
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (d *DivertIngresses) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var multi []string
+	err := unmarshal(&multi)
+	if err != nil {
+		var single string
+		err := unmarshal(&single)
+		if err != nil {
+			return err
+		}
+		*d = DivertIngresses{single}
+	} else {
+		*d = multi
+	}
+	return nil
+}
+
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+func (d DivertIngresses) MarshalYAML() (interface{}, error) {
+	if len(d) == 1 {
+		return d[0], nil
+	}
+	return []string(d), nil
+}
+
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
 func (a *Args) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var multi []string
@@ -268,13 +305,15 @@ func (sync *Sync) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	sync.Compression = rawSync.Compression
 	sync.Verbose = rawSync.Verbose
 	sync.RescanInterval = rawSync.RescanInterval
+	sync.ConflictsDir = rawSync.ConflictsDir
+	sync.DefaultIgnores = rawSync.DefaultIgnores
 	sync.Folders = rawSync.Folders
 	return nil
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (sync Sync) MarshalYAML() (interface{}, error) {
-	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval {
+	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval && sync.ConflictsDir == "" && sync.DefaultIgnores == nil {
 		return sync.Folders, nil
 	}
 	return syncRaw(sync), nil
@@ -298,6 +337,7 @@ func (buildInfo *BuildInfo) UnmarshalYAML(unmarshal func(interface{}) error) err
 	buildInfo.Name = rawBuildInfo.Name
 	buildInfo.Context = rawBuildInfo.Context
 	buildInfo.Dockerfile = rawBuildInfo.Dockerfile
+	buildInfo.CacheFrom = rawBuildInfo.CacheFrom
 	buildInfo.Target = rawBuildInfo.Target
 	buildInfo.Args = rawBuildInfo.Args
 	return nil
@@ -314,6 +354,9 @@ func (buildInfo BuildInfo) MarshalYAML() (interface{}, error) {
 	if buildInfo.Target != "" {
 		return buildInfoRaw(buildInfo), nil
 	}
+	if len(buildInfo.CacheFrom) != 0 {
+		return buildInfoRaw(buildInfo), nil
+	}
 	if buildInfo.Args != nil && len(buildInfo.Args) != 0 {
 		return buildInfoRaw(buildInfo), nil
 	}
@@ -417,7 +460,17 @@ func (f *Reverse) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var raw string
 	err := unmarshal(&raw)
 	if err != nil {
-		return err
+		var rawReverse reverseRaw
+		if err := unmarshal(&rawReverse); err != nil {
+			return err
+		}
+		if rawReverse.Remote == 0 || rawReverse.Local == 0 {
+			return fmt.Errorf("Wrong reverse syntax, 'remotePort' and 'localPort' are required")
+		}
+		f.Remote = rawReverse.Remote
+		f.Local = rawReverse.Local
+		f.WaitForLocal = rawReverse.WaitForLocal
+		return nil
 	}
 
 	parts := strings.SplitN(raw, ":", 2)
@@ -441,6 +494,9 @@ func (f *Reverse) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (f Reverse) MarshalYAML() (interface{}, error) {
+	if f.WaitForLocal {
+		return reverseRaw(f), nil
+	}
 	return fmt.Sprintf("%d:%d", f.Remote, f.Local), nil
 }
 
@@ -459,7 +515,7 @@ func (r *ResourceList) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	for k, v := range raw {
 		parsed, err := resource.ParseQuantity(v)
 		if err != nil {
-			return err
+			return fmt.Errorf("invalid quantity '%s' for resource '%s': %s", v, k, err)
 		}
 
 		(*r)[k] = parsed
@@ -52,6 +52,16 @@ func TestReverseMashalling(t *testing.T) {
 			data:      "8080:svc",
 			expectErr: true,
 		},
+		{
+			name:     "extended-wait-for-local",
+			data:     "remotePort: 8080\nlocalPort: 9090\nwaitForLocal: true",
+			expected: Reverse{Local: 9090, Remote: 8080, WaitForLocal: true},
+		},
+		{
+			name:      "extended-missing-port",
+			data:      "remotePort: 8080\nwaitForLocal: true",
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -245,6 +255,38 @@ func TestCommandMashalling(t *testing.T) {
 	}
 }
 
+func TestDivertIngressesUnmashalling(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected DivertIngresses
+	}{
+		{
+			"single",
+			[]byte("web"),
+			DivertIngresses{"web"},
+		},
+		{
+			"multiple",
+			[]byte("['api', 'web', 'admin']"),
+			DivertIngresses{"api", "web", "admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result DivertIngresses
+			if err := yaml.Unmarshal(tt.data, &result); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("didn't unmarshal correctly. Actual %+v, Expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestImageMashalling(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -266,6 +308,11 @@ func TestImageMashalling(t *testing.T) {
 			image:    BuildInfo{Name: "image-name", Context: "path"},
 			expected: "name: image-name\ncontext: path\n",
 		},
+		{
+			name:     "cache-from",
+			image:    BuildInfo{Name: "image-name", CacheFrom: []string{"base", "previous"}},
+			expected: "name: image-name\ncache_from:\n- base\n- previous\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -282,6 +329,22 @@ func TestImageMashalling(t *testing.T) {
 	}
 }
 
+func TestImageUnmashalling(t *testing.T) {
+	manifest := []byte(`name: image-name
+cache_from:
+  - base
+  - previous`)
+	var result BuildInfo
+	if err := yaml.Unmarshal(manifest, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"base", "previous"}
+	if !reflect.DeepEqual(result.CacheFrom, expected) {
+		t.Errorf("didn't unmarshal correctly. Actual %+v, Expected %+v", result.CacheFrom, expected)
+	}
+}
+
 func TestProbesMashalling(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -964,3 +1027,46 @@ func TestSyncFoldersUnmashalling(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncUnmashalling(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected Sync
+	}{
+		{
+			name: "list",
+			data: []byte(`- .:/usr/src/app`),
+			expected: Sync{
+				RescanInterval: DefaultSyncthingRescanInterval,
+				Folders:        []SyncFolder{{LocalPath: ".", RemotePath: "/usr/src/app"}},
+			},
+		},
+		{
+			name: "conflicts-dir",
+			data: []byte(`conflictsDir: ../conflicts
+rescanInterval: 100
+folders:
+  - .:/usr/src/app`),
+			expected: Sync{
+				RescanInterval: 100,
+				ConflictsDir:   "../conflicts",
+				Folders:        []SyncFolder{{LocalPath: ".", RemotePath: "/usr/src/app"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Sync{}
+
+			if err := yaml.UnmarshalStrict(tt.data, &result); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("didn't unmarshal correctly. Actual %+v, Expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
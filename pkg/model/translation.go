@@ -25,6 +25,7 @@ type TranslationRule struct {
 	Container         string               `json:"container,omitempty"`
 	Image             string               `json:"image,omitempty"`
 	ImagePullPolicy   apiv1.PullPolicy     `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
+	ImagePullSecrets  []string             `json:"imagePullSecrets,omitempty" yaml:"imagePullSecrets,omitempty"`
 	Environment       Environment          `json:"environment,omitempty"`
 	Secrets           []Secret             `json:"secrets,omitempty"`
 	Command           []string             `json:"command,omitempty"`
@@ -42,6 +43,7 @@ type TranslationRule struct {
 	Docker            DinDContainer        `json:"docker" yaml:"docker"`
 	NodeSelector      map[string]string    `json:"nodeSelector" yaml:"nodeSelector"`
 	Affinity          *apiv1.Affinity      `json:"affinity" yaml:"affinity"`
+	HostNetwork       bool                 `json:"hostNetwork,omitempty" yaml:"hostNetwork,omitempty"`
 }
 
 // IsMainDevContainer returns true if the translation rule applies to the main dev container of the okteto manifest
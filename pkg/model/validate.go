@@ -0,0 +1,94 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+)
+
+// ValidateStrict returns the errors of a development container that are only detected when running okteto,
+// like duplicated forwards, missing sync folders or missing Dockerfiles in the 'build' section
+func (dev *Dev) ValidateStrict() []error {
+	errs := []error{}
+	errs = append(errs, dev.validateDuplicatedForwards()...)
+	errs = append(errs, dev.validateSyncFoldersExist()...)
+	errs = append(errs, dev.validateBuildDockerfiles()...)
+	return errs
+}
+
+func (dev *Dev) validateDuplicatedForwards() []error {
+	errs := []error{}
+	forwards := map[int]bool{}
+	sockets := map[string]bool{}
+	for _, f := range dev.Forward {
+		if f.IsSocket() {
+			if sockets[f.Socket] {
+				errs = append(errs, fmt.Errorf("local socket %s is listed multiple times in 'forward'", f.Socket))
+			}
+			sockets[f.Socket] = true
+			continue
+		}
+		if forwards[f.Local] {
+			errs = append(errs, fmt.Errorf("local port %d is listed multiple times in 'forward'", f.Local))
+		}
+		forwards[f.Local] = true
+	}
+	reverses := map[int]bool{}
+	for _, r := range dev.Reverse {
+		if reverses[r.Remote] {
+			errs = append(errs, fmt.Errorf("remote port %d is listed multiple times in 'reverse'", r.Remote))
+		}
+		reverses[r.Remote] = true
+	}
+	return errs
+}
+
+func (dev *Dev) validateSyncFoldersExist() []error {
+	errs := []error{}
+	for _, folder := range dev.Sync.Folders {
+		info, err := os.Stat(folder.LocalPath)
+		switch {
+		case os.IsNotExist(err):
+			errs = append(errs, fmt.Errorf("the local folder '%s' of 'sync' does not exist", folder.LocalPath))
+		case err != nil:
+			errs = append(errs, fmt.Errorf("the local folder '%s' of 'sync' can't be read: %s", folder.LocalPath, err))
+		case !info.IsDir():
+			errs = append(errs, fmt.Errorf("the local path '%s' of 'sync' is not a folder", folder.LocalPath))
+		}
+	}
+	return errs
+}
+
+func (dev *Dev) validateBuildDockerfiles() []error {
+	names := make([]string, 0, len(dev.Build))
+	for name := range dev.Build {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := []error{}
+	for _, name := range names {
+		b := dev.Build[name]
+		if uri, err := url.ParseRequestURI(b.Context); err == nil && uri.Scheme != "" && uri.Host != "" {
+			continue
+		}
+		if !FileExists(b.Dockerfile) {
+			errs = append(errs, fmt.Errorf("the Dockerfile '%s' of the image '%s' of the 'build' section does not exist", b.Dockerfile, name))
+		}
+	}
+	return errs
+}
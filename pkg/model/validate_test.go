@@ -0,0 +1,112 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDev_ValidateStrict(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "api"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "api", "Dockerfile"), []byte("FROM alpine"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		manifest string
+		expected []string
+	}{
+		{
+			name: "valid",
+			manifest: `name: api
+forward:
+  - 8080:8080
+  - 9229:9229
+sync:
+  - api:/app
+build:
+  api:
+    context: api
+    dockerfile: api/Dockerfile`,
+		},
+		{
+			name: "duplicated-forwards",
+			manifest: `name: api
+forward:
+  - 8080:8080
+  - 8080:8081
+reverse:
+  - 9000:9000
+  - 9000:9001`,
+			expected: []string{
+				"local port 8080 is listed multiple times in 'forward'",
+				"remote port 9000 is listed multiple times in 'reverse'",
+			},
+		},
+		{
+			name: "duplicated-sockets",
+			manifest: `name: api
+forward:
+  - /tmp/app.sock:5432
+  - /tmp/db.sock:5432
+  - /tmp/app.sock:5433`,
+			expected: []string{
+				"local socket /tmp/app.sock is listed multiple times in 'forward'",
+			},
+		},
+		{
+			name: "missing-sync-folder",
+			manifest: `name: api
+sync:
+  - worker:/app`,
+			expected: []string{"the local folder"},
+		},
+		{
+			name: "missing-dockerfile",
+			manifest: `name: api
+build:
+  worker:
+    context: worker`,
+			expected: []string{"the Dockerfile"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devPath := filepath.Join(dir, "okteto.yml")
+			if err := os.WriteFile(devPath, []byte(tt.manifest), 0600); err != nil {
+				t.Fatal(err)
+			}
+			dev, err := Get(devPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs := dev.ValidateStrict()
+			if len(errs) != len(tt.expected) {
+				t.Fatalf("expected %d errors, got %v", len(tt.expected), errs)
+			}
+			for i := range errs {
+				if !strings.Contains(errs[i].Error(), tt.expected[i]) {
+					t.Errorf("expected '%s' in '%s'", tt.expected[i], errs[i].Error())
+				}
+			}
+		})
+	}
+}
@@ -36,7 +36,7 @@ func (c *OktetoClient) GetAction(ctx context.Context, name string) (*Action, err
 		"space": graphql.String(namespace),
 	}
 
-	err := c.client.Query(ctx, &query, variables)
+	err := c.query(ctx, &query, variables)
 	if err != nil {
 		return nil, translateAPIErr(err)
 	}
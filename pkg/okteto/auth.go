@@ -113,7 +113,7 @@ func (c *OktetoClient) queryUser(ctx context.Context) (*User, error) {
 			TelemetryEnabled graphql.Boolean `graphql:"telemetryEnabled"`
 		} `graphql:"user"`
 	}
-	err := c.client.Query(ctx, &query, nil)
+	err := c.query(ctx, &query, nil)
 	if err != nil {
 		if strings.Contains(err.Error(), "Cannot query field \"globalNamespace\" on type \"me\"") {
 			return c.deprecatedQueryUser(ctx)
@@ -159,7 +159,7 @@ func (c *OktetoClient) deprecatedQueryUser(ctx context.Context) (*User, error) {
 			Certificate graphql.String
 		} `graphql:"user"`
 	}
-	err := c.client.Query(ctx, &query, nil)
+	err := c.query(ctx, &query, nil)
 	if err != nil {
 		return nil, translateAPIErr(err)
 	}
@@ -0,0 +1,57 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+
+	"github.com/shurcooL/graphql"
+)
+
+// Capabilities represents the features supported by the okteto backend of a context
+type Capabilities struct {
+	Actions bool
+}
+
+// GetCapabilities returns the features supported by the okteto backend, inspecting its graphql schema
+func (c *OktetoClient) GetCapabilities(ctx context.Context) (*Capabilities, error) {
+	var query struct {
+		Schema struct {
+			QueryType struct {
+				Fields []struct {
+					Name graphql.String
+				}
+			}
+		} `graphql:"__schema"`
+	}
+	if err := c.query(ctx, &query, nil); err != nil {
+		return nil, translateAPIErr(err)
+	}
+
+	fields := []string{}
+	for _, f := range query.Schema.QueryType.Fields {
+		fields = append(fields, string(f.Name))
+	}
+	return getCapabilities(fields), nil
+}
+
+func getCapabilities(queryFields []string) *Capabilities {
+	result := &Capabilities{}
+	for _, f := range queryFields {
+		if f == "action" {
+			result.Actions = true
+		}
+	}
+	return result
+}
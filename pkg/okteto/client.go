@@ -15,7 +15,9 @@ package okteto
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"strings"
@@ -26,6 +28,9 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// insecureURLs overrides the TLS verification setting saved in the okteto context of an okteto instance
+var insecureURLs = map[string]bool{}
+
 //Client implementation to connect to Okteto API
 type OktetoClient struct {
 	client *graphql.Client
@@ -46,7 +51,7 @@ func NewOktetoClient() (*OktetoClient, error) {
 		&oauth2.Token{AccessToken: token,
 			TokenType: "Bearer"},
 	)
-	httpClient := oauth2.NewClient(context.Background(), src)
+	httpClient := newHTTPClient(Context().Name, src)
 
 	client := &OktetoClient{
 		client: graphql.NewClient(u, httpClient),
@@ -65,7 +70,7 @@ func NewOktetoClientFromUrlAndToken(url, token string) (*OktetoClient, error) {
 		&oauth2.Token{AccessToken: token,
 			TokenType: "Bearer"},
 	)
-	httpClient := oauth2.NewClient(context.Background(), src)
+	httpClient := newHTTPClient(url, src)
 
 	client := &OktetoClient{
 		client: graphql.NewClient(u, httpClient),
@@ -80,13 +85,56 @@ func NewOktetoClientFromUrl(url string) (*OktetoClient, error) {
 		return nil, err
 	}
 
-	httpClient := oauth2.NewClient(context.Background(), nil)
+	httpClient := newHTTPClient(url, nil)
 	client := &OktetoClient{
 		client: graphql.NewClient(u, httpClient),
 	}
 	return client, nil
 }
 
+// query runs a graphql query, retrying it on transient errors of the okteto API
+func (c *OktetoClient) query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
+	return c.client.Query(withRetries(ctx), q, variables)
+}
+
+// SetInsecureSkipTLSVerify sets if the TLS certificate of an okteto instance is verified.
+// The setting is persisted in the okteto context of the instance once it is saved
+func SetInsecureSkipTLSVerify(oktetoURL string, insecure bool) {
+	insecureURLs[oktetoURL] = insecure
+}
+
+// IsInsecureSkipTLSVerify returns if the TLS certificate of an okteto instance is not verified
+func IsInsecureSkipTLSVerify(oktetoURL string) bool {
+	if insecure, ok := insecureURLs[oktetoURL]; ok {
+		return insecure
+	}
+	if CurrentStore == nil && !contextExists() {
+		return false
+	}
+	octx, ok := ContextStore().Contexts[oktetoURL]
+	return ok && octx.Insecure
+}
+
+// GetTransport returns the http transport used to connect to the services of an okteto instance
+func GetTransport(oktetoURL string) http.RoundTripper {
+	insecure := IsInsecureSkipTLSVerify(oktetoURL)
+	if !insecure && caBundle == nil {
+		return http.DefaultTransport
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if insecure {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402
+	} else {
+		transport.TLSClientConfig = &tls.Config{RootCAs: getRootCAs(), MinVersion: tls.VersionTLS12}
+	}
+	return transport
+}
+
+func newHTTPClient(oktetoURL string, src oauth2.TokenSource) *http.Client {
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: newRetryTransport(GetTransport(oktetoURL))})
+	return oauth2.NewClient(ctx, src)
+}
+
 func parseOktetoURL(u string) (string, error) {
 	if u == "" {
 		return "", fmt.Errorf("the okteto URL is not set")
@@ -14,6 +14,7 @@
 package okteto
 
 import (
+	"net/http"
 	"os"
 	"testing"
 
@@ -150,3 +151,56 @@ func Test_parseOktetoURL(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetTransport(t *testing.T) {
+	CurrentStore = &OktetoContextStore{
+		CurrentContext: "https://okteto.secure.dev",
+		Contexts: map[string]*OktetoContext{
+			"https://okteto.secure.dev":   {Name: "https://okteto.secure.dev"},
+			"https://okteto.insecure.dev": {Name: "https://okteto.insecure.dev", Insecure: true},
+		},
+	}
+	defer func() { insecureURLs = map[string]bool{} }()
+
+	var tests = []struct {
+		name     string
+		url      string
+		override *bool
+		insecure bool
+	}{
+		{name: "secure-context", url: "https://okteto.secure.dev", insecure: false},
+		{name: "insecure-context", url: "https://okteto.insecure.dev", insecure: true},
+		{name: "unknown-context", url: "https://okteto.unknown.dev", insecure: false},
+		{name: "insecure-override", url: "https://okteto.secure.dev", override: boolPtr(true), insecure: true},
+		{name: "secure-override", url: "https://okteto.insecure.dev", override: boolPtr(false), insecure: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			insecureURLs = map[string]bool{}
+			if tt.override != nil {
+				SetInsecureSkipTLSVerify(tt.url, *tt.override)
+			}
+
+			if got := IsInsecureSkipTLSVerify(tt.url); got != tt.insecure {
+				t.Errorf("IsInsecureSkipTLSVerify, got %t, want %t", got, tt.insecure)
+			}
+
+			transport := GetTransport(tt.url)
+			if !tt.insecure {
+				if transport != http.DefaultTransport {
+					t.Errorf("expected the default transport")
+				}
+				return
+			}
+			httpTransport, ok := transport.(*http.Transport)
+			if !ok || httpTransport.TLSClientConfig == nil || !httpTransport.TLSClientConfig.InsecureSkipVerify {
+				t.Errorf("expected an insecure transport")
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
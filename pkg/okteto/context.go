@@ -56,6 +56,7 @@ type OktetoContext struct {
 	Certificate      string `json:"certificate,omitempty"`
 	GlobalNamespace  string `json:"globalNamespace,omitempty"`
 	TelemetryEnabled string `json:"telemetryEnabled,omitempty"`
+	Insecure         bool   `json:"insecure,omitempty"`
 }
 
 func InitContextWithToken(ctx context.Context, oktetoUrl, oktetoToken string) error {
@@ -94,12 +95,28 @@ func InitContext(ctx context.Context, oktetoToken string) error {
 	if oktetoToken == "" {
 		oktetoToken = os.Getenv("OKTETO_TOKEN")
 	}
+	tokenFromEnv := oktetoToken != "" && oktetoToken == os.Getenv("OKTETO_TOKEN")
 	if oktetoToken != "" {
 		if currentContext == "" {
 			currentContext = CloudURL
 		}
+		if !IsOktetoURL(currentContext) {
+			return errors.UserError{
+				E:    fmt.Errorf("'OKTETO_URL' is not a valid URL: '%s'", currentContext),
+				Hint: "Set 'OKTETO_URL' to the URL of your Okteto instance, e.g. https://cloud.okteto.com",
+			}
+		}
 		log.Information("Using 'OKTETO_TOKEN' to access %s", currentContext)
-		return InitContextWithToken(ctx, currentContext, oktetoToken)
+		if err := InitContextWithToken(ctx, currentContext, oktetoToken); err != nil {
+			if tokenFromEnv && isUnauthorized(err) {
+				return errors.UserError{
+					E:    fmt.Errorf("the token set in 'OKTETO_TOKEN' is not valid for %s", currentContext),
+					Hint: "Generate a new personal access token in the settings of your Okteto instance and update 'OKTETO_TOKEN'",
+				}
+			}
+			return err
+		}
+		return nil
 	}
 
 	if contextExists() {
@@ -138,6 +155,14 @@ func InitContext(ctx context.Context, oktetoToken string) error {
 	return nil
 }
 
+//isUnauthorized returns if err is raised because the okteto token is not valid
+func isUnauthorized(err error) bool {
+	if err == errors.ErrNotLogged {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "unauthorized")
+}
+
 //contextExists checks if an okteto context has been created
 func contextExists() bool {
 	oktetoContextFolder := config.GetOktetoContextFolder()
@@ -199,21 +224,24 @@ func Context() *OktetoContext {
 		log.Fatalf(errors.ErrCorruptedOktetoContexts, config.GetOktetoHome())
 	}
 
+	log.AddMaskedWord(octx.Token)
 	return octx
 }
 
+// namespaceFromEnv is the OKTETO_NAMESPACE set by the user when the command started.
+// It's read before okteto exports the namespace of the current context to the same variable
+var namespaceFromEnv = os.Getenv("OKTETO_NAMESPACE")
+
+//SetCurrentContext sets the okteto context and namespace used by the running command.
+//The change only lives in memory: neither the kubeconfig nor the okteto context store are modified
 func SetCurrentContext(oktetoContext, namespace string) error {
 	if oktetoContext == "" {
 		oktetoContext = os.Getenv(config.OktetoContextVariableName)
 	}
 
-	if namespace == "" {
-		namespace = os.Getenv("OKTETO_NAMESPACE")
-	}
-
 	octxStore := ContextStore()
 
-	if oktetoContext != "" {
+	if oktetoContext != "" && oktetoContext != octxStore.CurrentContext {
 		if IsOktetoURL(oktetoContext) {
 			if _, ok := octxStore.Contexts[oktetoContext]; !ok {
 				//TODO: start login sequence
@@ -222,18 +250,40 @@ func SetCurrentContext(oktetoContext, namespace string) error {
 		} else {
 			kubeconfigFile := config.GetKubeconfigPath()
 			cfg := client.GetKubeconfig(kubeconfigFile)
-			if _, ok := cfg.Contexts[oktetoContext]; !ok {
+			if cfg == nil {
 				return fmt.Errorf(errors.ErrKubernetesContextNotFound, oktetoContext, kubeconfigFile)
 			}
-			if err := saveContextConfigInFile(CurrentStore); err != nil {
-				return err
+			kubeContext, ok := cfg.Contexts[oktetoContext]
+			if !ok {
+				return fmt.Errorf(errors.ErrKubernetesContextNotFound, oktetoContext, kubeconfigFile)
+			}
+			if _, ok := octxStore.Contexts[oktetoContext]; !ok {
+				contextNamespace := kubeContext.Namespace
+				if contextNamespace == "" {
+					contextNamespace = "default"
+				}
+				cfg.CurrentContext = oktetoContext
+				octxStore.Contexts[oktetoContext] = &OktetoContext{
+					Name:       oktetoContext,
+					Namespace:  contextNamespace,
+					Kubeconfig: encodeOktetoKubeconfig(cfg),
+				}
 			}
 		}
 		octxStore.CurrentContext = oktetoContext
 	}
 
+	if namespace == "" {
+		namespace = namespaceFromEnv
+	}
+
 	if namespace != "" {
 		octx := Context()
+		if namespace != octx.Namespace {
+			if err := checkNamespaceAccess(context.Background(), namespace); err != nil {
+				return err
+			}
+		}
 		octx.Namespace = namespace
 	}
 
@@ -261,6 +311,7 @@ func UpdateOktetoClusterContext(name string, u *User, namespace string, cfg *cli
 		certificate = base64.StdEncoding.EncodeToString([]byte(u.Certificate))
 	}
 	telemetry := getTelemetry(u)
+	insecure := IsInsecureSkipTLSVerify(name)
 	CurrentStore.Contexts[name] = &OktetoContext{
 		Name:             name,
 		UserID:           u.ID,
@@ -273,6 +324,7 @@ func UpdateOktetoClusterContext(name string, u *User, namespace string, cfg *cli
 		Registry:         u.Registry,
 		Certificate:      certificate,
 		TelemetryEnabled: telemetry,
+		Insecure:         insecure,
 	}
 
 	CurrentStore.CurrentContext = name
@@ -293,6 +345,7 @@ func SaveOktetoClusterContext(name string, u *User, namespace string, cfg *clien
 		kubeconfigBase64 = encodeOktetoKubeconfig(cfg)
 	}
 	telemetry := getTelemetry(u)
+	insecure := IsInsecureSkipTLSVerify(name)
 	CurrentStore.Contexts[name] = &OktetoContext{
 		Name:             name,
 		UserID:           u.ID,
@@ -305,6 +358,7 @@ func SaveOktetoClusterContext(name string, u *User, namespace string, cfg *clien
 		Registry:         u.Registry,
 		Certificate:      u.Certificate,
 		TelemetryEnabled: telemetry,
+		Insecure:         insecure,
 	}
 
 	CurrentStore.CurrentContext = name
@@ -441,20 +495,46 @@ func encodeOktetoKubeconfig(cfg *clientcmdapi.Config) string {
 }
 
 func GetK8sClient() (*kubernetes.Clientset, *rest.Config, error) {
+	kubeconfigFile, kubeContext, err := GetK8sKubeconfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.GetForContext(kubeconfigFile, kubeContext)
+}
+
+// GetK8sKubeconfig returns the kubeconfig file and the kubernetes context used to access the cluster of the current okteto context.
+// Every kubernetes client of the okteto CLI must be built from them, so they all target the same cluster with the same credentials
+func GetK8sKubeconfig() (string, string, error) {
 	octx := Context()
+	if !IsOktetoContext() {
+		// use the kubeconfig of the user, its credentials might have changed since the okteto context was saved
+		kubeconfigFile := config.GetKubeconfigPath()
+		if cfg := client.GetKubeconfig(kubeconfigFile); cfg != nil {
+			if _, ok := cfg.Contexts[octx.Name]; ok {
+				return kubeconfigFile, octx.Name, nil
+			}
+		}
+		if config.IsKubeconfigPathSet() {
+			return "", "", errors.UserError{
+				E:    fmt.Errorf(errors.ErrKubernetesContextNotFound, octx.Name, kubeconfigFile),
+				Hint: "Select a context of your kubeconfig file with '--context' or 'okteto context'",
+			}
+		}
+		log.Infof("kubernetes context '%s' not found in '%s', using the kubeconfig saved in the okteto context", octx.Name, kubeconfigFile)
+	}
 	kubeconfigBytes, err := base64.StdEncoding.DecodeString(octx.Kubeconfig)
 	if err != nil {
-		return nil, nil, fmt.Errorf(errors.ErrCorruptedOktetoContexts, config.GetOktetoHome())
+		return "", "", fmt.Errorf(errors.ErrCorruptedOktetoContexts, config.GetOktetoHome())
 	}
 	cfg, err := clientcmd.Load(kubeconfigBytes)
 	if err != nil {
-		return nil, nil, err
+		return "", "", err
 	}
 	kubeconfigFile := config.GetOktetoContextKubeconfigPath()
 	if err := client.WriteKubeconfig(cfg, kubeconfigFile); err != nil {
-		return nil, nil, err
+		return "", "", err
 	}
-	return client.Get(kubeconfigFile)
+	return kubeconfigFile, "", nil
 }
 
 // GetSanitizedUsername returns the username of the authenticated user sanitized to be DNS compatible
@@ -463,6 +543,15 @@ func GetSanitizedUsername() string {
 	return reg.ReplaceAllString(strings.ToLower(octx.Username), "-")
 }
 
+// GetDivertKey returns the key used to divert the traffic of a development container sanitized to be DNS compatible.
+// It defaults to the username of the authenticated user if 'divert.value' is not set
+func GetDivertKey(dev *model.Dev) string {
+	if dev.Divert == nil || dev.Divert.Value == "" {
+		return GetSanitizedUsername()
+	}
+	return strings.Trim(reg.ReplaceAllString(strings.ToLower(dev.Divert.Value), "-"), "-")
+}
+
 func (okctx *OktetoContext) ToUser() *User {
 	u := &User{
 		ID:              okctx.UserID,
@@ -1,6 +1,18 @@
 package okteto
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+)
 
 func Test_IsTelemetryEnabled(t *testing.T) {
 	var tests = []struct {
@@ -55,3 +67,347 @@ func Test_IsOktetoCloud(t *testing.T) {
 	}
 
 }
+
+func Test_GetDivertKey(t *testing.T) {
+	var tests = []struct {
+		name string
+		dev  *model.Dev
+		want string
+	}{
+		{name: "no-divert", dev: &model.Dev{}, want: "cindy"},
+		{name: "no-value", dev: &model.Dev{Divert: &model.Divert{Service: "api"}}, want: "cindy"},
+		{name: "value", dev: &model.Dev{Divert: &model.Divert{Service: "api", Value: "feature/Login_Page"}}, want: "feature-login-page"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			CurrentStore = &OktetoContextStore{
+				CurrentContext: "test",
+				Contexts: map[string]*OktetoContext{
+					"test": {Name: "https://cloud.okteto.com", Username: "Cindy"},
+				},
+			}
+			if got := GetDivertKey(tt.dev); got != tt.want {
+				t.Errorf("GetDivertKey, got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_InitContextWithInvalidURL(t *testing.T) {
+	t.Setenv("OKTETO_URL", "cloud.okteto.com")
+	t.Setenv("OKTETO_TOKEN", "token")
+	err := InitContext(context.Background(), "")
+	if _, ok := err.(errors.UserError); !ok {
+		t.Fatalf("expected a user error, got %v", err)
+	}
+}
+
+func Test_isUnauthorized(t *testing.T) {
+	var tests = []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "not-logged", err: errors.ErrNotLogged, want: true},
+		{name: "unauthorized", err: fmt.Errorf("unauthorized. Please run 'okteto context url' and try again"), want: true},
+		{name: "other", err: fmt.Errorf("server temporarily unavailable, please try again"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUnauthorized(tt.err); got != tt.want {
+				t.Errorf("isUnauthorized() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_SetCurrentContextIsEphemeral(t *testing.T) {
+	t.Setenv("OKTETO_FOLDER", t.TempDir())
+	t.Setenv("OKTETO_NAMESPACE", "")
+	t.Setenv(config.OktetoContextVariableName, "")
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	kubeconfigContent := []byte(`apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- cluster:
+    server: https://dev.example.com
+  name: dev
+- cluster:
+    server: https://ci.example.com
+  name: ci
+contexts:
+- context:
+    cluster: dev
+    namespace: dev-ns
+    user: dev
+  name: dev
+- context:
+    cluster: ci
+    namespace: ci-ns
+    user: ci
+  name: ci
+users:
+- name: dev
+  user:
+    token: dev-token
+- name: ci
+  user:
+    token: ci-token
+`)
+	if err := os.WriteFile(kubeconfig, kubeconfigContent, 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	CurrentStore = &OktetoContextStore{
+		CurrentContext: "dev",
+		Contexts: map[string]*OktetoContext{
+			"dev": {Name: "dev", Namespace: "dev-ns"},
+			"ci":  {Name: "ci", Namespace: "ci-ns"},
+		},
+	}
+
+	if err := SetCurrentContext("missing", ""); err == nil {
+		t.Fatal("expected error for a context that doesn't exist")
+	}
+
+	if err := SetCurrentContext("ci", ""); err != nil {
+		t.Fatal(err)
+	}
+	if Context().Name != "ci" || Context().Namespace != "ci-ns" {
+		t.Errorf("got context '%s' and namespace '%s', want 'ci' and 'ci-ns'", Context().Name, Context().Namespace)
+	}
+	content, err := os.ReadFile(kubeconfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, kubeconfigContent) {
+		t.Error("the kubeconfig was modified")
+	}
+	if _, err := os.Stat(config.GetOktetoContextsStorePath()); !os.IsNotExist(err) {
+		t.Errorf("the okteto context store was written: %v", err)
+	}
+
+	if err := SetCurrentContext("", "other-ns"); err != nil {
+		t.Fatal(err)
+	}
+	if Context().Name != "ci" || Context().Namespace != "other-ns" {
+		t.Errorf("got context '%s' and namespace '%s', want 'ci' and 'other-ns'", Context().Name, Context().Namespace)
+	}
+}
+
+func Test_SetCurrentContextNamespaceFromEnv(t *testing.T) {
+	t.Setenv("OKTETO_FOLDER", t.TempDir())
+	t.Setenv("OKTETO_NAMESPACE", "exported-ns")
+	t.Setenv(config.OktetoContextVariableName, "")
+	defer func() { namespaceFromEnv = "" }()
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	kubeconfigContent := []byte(`apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:1
+  name: ci
+contexts:
+- context:
+    cluster: ci
+    namespace: ci-ns
+    user: ci
+  name: ci
+users:
+- name: ci
+  user:
+    token: ci-token
+`)
+	if err := os.WriteFile(kubeconfig, kubeconfigContent, 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	var tests = []struct {
+		name      string
+		env       string
+		namespace string
+		expected  string
+	}{
+		{
+			name:     "context-namespace",
+			expected: "ci-ns",
+		},
+		{
+			name:     "env-namespace",
+			env:      "env-ns",
+			expected: "env-ns",
+		},
+		{
+			name:      "argument-namespace",
+			env:       "env-ns",
+			namespace: "arg-ns",
+			expected:  "arg-ns",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespaceFromEnv = tt.env
+			CurrentStore = &OktetoContextStore{
+				CurrentContext: "dev",
+				Contexts: map[string]*OktetoContext{
+					"dev": {Name: "dev", Namespace: "dev-ns"},
+					"ci":  {Name: "ci", Namespace: "ci-ns"},
+				},
+			}
+			if err := SetCurrentContext("ci", tt.namespace); err != nil {
+				t.Fatal(err)
+			}
+			if Context().Namespace != tt.expected {
+				t.Errorf("got namespace '%s', want '%s'", Context().Namespace, tt.expected)
+			}
+			if got := os.Getenv("OKTETO_NAMESPACE"); got != "exported-ns" {
+				t.Errorf("OKTETO_NAMESPACE was overwritten with '%s'", got)
+			}
+		})
+	}
+}
+
+func Test_GetK8sClientFromKubeconfig(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	kubeconfigContent := []byte(`apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- cluster:
+    server: https://dev.example.com
+  name: dev
+- cluster:
+    server: https://ci.example.com
+  name: ci
+contexts:
+- context:
+    cluster: dev
+    user: dev
+  name: dev
+- context:
+    cluster: ci
+    user: ci
+  name: ci
+users:
+- name: dev
+  user:
+    token: dev-token
+- name: ci
+  user:
+    token: ci-token
+`)
+	if err := os.WriteFile(kubeconfig, kubeconfigContent, 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	CurrentStore = &OktetoContextStore{
+		CurrentContext: "ci",
+		Contexts: map[string]*OktetoContext{
+			"ci": {Name: "ci", Namespace: "ci-ns"},
+		},
+	}
+
+	_, restConfig, err := GetK8sClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restConfig.Host != "https://ci.example.com" {
+		t.Errorf("got host '%s', want 'https://ci.example.com'", restConfig.Host)
+	}
+	if restConfig.BearerToken != "ci-token" {
+		t.Errorf("got token '%s', want 'ci-token'", restConfig.BearerToken)
+	}
+}
+
+func Test_GetK8sKubeconfig(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	kubeconfigContent := []byte(`apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- cluster:
+    server: https://ci.example.com
+  name: ci
+contexts:
+- context:
+    cluster: ci
+    user: ci
+  name: ci
+users:
+- name: ci
+  user:
+    token: ci-token
+`)
+	if err := os.WriteFile(kubeconfig, kubeconfigContent, 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	CurrentStore = &OktetoContextStore{
+		CurrentContext: "ci",
+		Contexts: map[string]*OktetoContext{
+			"ci": {Name: "ci", Namespace: "ci-ns"},
+		},
+	}
+
+	kubeconfigFile, kubeContext, err := GetK8sKubeconfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kubeconfigFile != kubeconfig {
+		t.Errorf("got kubeconfig '%s', want '%s'", kubeconfigFile, kubeconfig)
+	}
+	if kubeContext != "ci" {
+		t.Errorf("got context '%s', want 'ci'", kubeContext)
+	}
+}
+
+func Test_GetK8sKubeconfigContextNotFound(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	kubeconfigContent := []byte(`apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- cluster:
+    server: https://dev.example.com
+  name: dev
+contexts:
+- context:
+    cluster: dev
+    user: dev
+  name: dev
+users:
+- name: dev
+  user:
+    token: dev-token
+`)
+	if err := os.WriteFile(kubeconfig, kubeconfigContent, 0600); err != nil {
+		t.Fatal(err)
+	}
+	config.SetKubeconfigPath(kubeconfig)
+	defer config.SetKubeconfigPath("")
+
+	CurrentStore = &OktetoContextStore{
+		CurrentContext: "ci",
+		Contexts: map[string]*OktetoContext{
+			"ci": {Name: "ci", Namespace: "ci-ns"},
+		},
+	}
+
+	_, _, err := GetK8sKubeconfig()
+	if err == nil {
+		t.Fatal("expected error for a context missing in --kubeconfig")
+	}
+	if _, ok := err.(errors.UserError); !ok {
+		t.Errorf("expected a user error, got %T", err)
+	}
+	if !strings.Contains(err.Error(), kubeconfig) {
+		t.Errorf("expected the error to name '%s', got '%s'", kubeconfig, err.Error())
+	}
+}
@@ -42,7 +42,7 @@ func (c *OktetoClient) GetCredentials(ctx context.Context) (*Credential, error)
 	variables := map[string]interface{}{
 		"cred": graphql.String(""),
 	}
-	err := c.client.Query(ctx, &query, variables)
+	err := c.query(ctx, &query, variables)
 	if err != nil {
 		return nil, translateAPIErr(err)
 	}
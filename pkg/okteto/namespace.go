@@ -17,9 +17,15 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/namespaces"
+	"github.com/okteto/okteto/pkg/log"
 	"github.com/shurcooL/graphql"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
 )
 
 const (
@@ -60,7 +66,7 @@ func (c *OktetoClient) ListNamespaces(ctx context.Context) ([]Namespace, error)
 		} `graphql:"spaces"`
 	}
 
-	err := c.client.Query(ctx, &query, nil)
+	err := c.query(ctx, &query, nil)
 	if err != nil {
 		return nil, translateAPIErr(err)
 	}
@@ -134,3 +140,100 @@ func validateNamespace(namespace, object string) error {
 	}
 	return nil
 }
+
+// checkNamespaceAccess returns an error if the namespace doesn't exist or the user doesn't have access to it.
+// The check is skipped if the namespaces available to the user can't be listed
+func checkNamespaceAccess(ctx context.Context, namespace string) error {
+	available, err := getAvailableNamespaces(ctx)
+	if err != nil {
+		log.Infof("failed to list namespaces, skipping the validation of namespace '%s': %s", namespace, err)
+		return nil
+	}
+	err = isNamespaceAvailable(namespace, available)
+	if err == nil {
+		return nil
+	}
+
+	// some namespaces the user has access to are not listed, like the namespaces shared with the user
+	c, _, kErr := GetK8sClient()
+	if kErr != nil {
+		log.Infof("failed to get the kubernetes client, skipping the validation of namespace '%s': %s", namespace, kErr)
+		return nil
+	}
+	hasAccess, kErr := canGetNamespace(ctx, namespace, c)
+	if kErr != nil {
+		log.Infof("failed to get namespace '%s', skipping its validation: %s", namespace, kErr)
+		return nil
+	}
+	if hasAccess {
+		return nil
+	}
+	return err
+}
+
+// canGetNamespace returns if the namespace exists and the user is allowed to get it
+func canGetNamespace(ctx context.Context, namespace string, c kubernetes.Interface) (bool, error) {
+	_, err := namespaces.Get(ctx, namespace, c)
+	if err == nil {
+		return true, nil
+	}
+	if k8sErrors.IsNotFound(err) || k8sErrors.IsForbidden(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+func getAvailableNamespaces(ctx context.Context) ([]string, error) {
+	if IsOktetoContext() {
+		oktetoClient, err := NewOktetoClient()
+		if err != nil {
+			return nil, err
+		}
+		spaces, err := oktetoClient.ListNamespaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]string, 0, len(spaces))
+		for _, space := range spaces {
+			result = append(result, space.ID)
+		}
+
+		previews, err := oktetoClient.ListPreviews(ctx)
+		if err != nil {
+			log.Infof("failed to list previews: %s", err)
+		}
+		for _, preview := range previews {
+			result = append(result, preview.ID)
+		}
+
+		if globalNamespace := Context().GlobalNamespace; globalNamespace != "" {
+			result = append(result, globalNamespace)
+		}
+		return result, nil
+	}
+
+	c, _, err := GetK8sClient()
+	if err != nil {
+		return nil, err
+	}
+	return namespaces.List(ctx, c)
+}
+
+func isNamespaceAvailable(namespace string, available []string) error {
+	for _, ns := range available {
+		if ns == namespace {
+			return nil
+		}
+	}
+
+	hint := "You don't have access to any namespace"
+	if len(available) > 0 {
+		sorted := append([]string{}, available...)
+		sort.Strings(sorted)
+		hint = fmt.Sprintf("Available namespaces: %s", strings.Join(sorted, ", "))
+	}
+	return errors.UserError{
+		E:    fmt.Errorf(errors.ErrNamespaceNotFound, namespace),
+		Hint: hint,
+	}
+}
@@ -14,9 +14,23 @@
 package okteto
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/okteto/okteto/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func Test_validateNamespaceName(t *testing.T) {
@@ -73,3 +87,145 @@ func Test_validateNamespaceName(t *testing.T) {
 		})
 	}
 }
+
+func Test_isNamespaceAvailable(t *testing.T) {
+	var tests = []struct {
+		name      string
+		namespace string
+		available []string
+		wantErr   bool
+		wantHint  string
+	}{
+		{
+			name:      "available",
+			namespace: "dev",
+			available: []string{"prod", "dev"},
+		},
+		{
+			name:      "typo",
+			namespace: "dve",
+			available: []string{"prod", "dev"},
+			wantErr:   true,
+			wantHint:  "Available namespaces: dev, prod",
+		},
+		{
+			name:      "no-namespaces",
+			namespace: "dev",
+			available: []string{},
+			wantErr:   true,
+			wantHint:  "You don't have access to any namespace",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := isNamespaceAvailable(tt.namespace, tt.available)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			uErr, ok := err.(errors.UserError)
+			if !ok {
+				t.Fatalf("expected a user error, got %v", err)
+			}
+			if uErr.Hint != tt.wantHint {
+				t.Errorf("got hint '%s', want '%s'", uErr.Hint, tt.wantHint)
+			}
+		})
+	}
+}
+
+func Test_getAvailableNamespacesOkteto(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query string `json:"query"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(body.Query, "previews"):
+			w.Write([]byte(`{"data":{"previews":[{"id":"pr-123","sleeping":false,"scope":"global"}]}}`))
+		case strings.Contains(body.Query, "spaces"):
+			w.Write([]byte(`{"data":{"spaces":[{"id":"cindy","sleeping":false}]}}`))
+		default:
+			t.Errorf("unexpected query: %s", body.Query)
+		}
+	}))
+	defer server.Close()
+
+	CurrentStore = &OktetoContextStore{
+		CurrentContext: server.URL,
+		Contexts: map[string]*OktetoContext{
+			server.URL: {Name: server.URL, Token: "token", Namespace: "cindy", GlobalNamespace: "okteto"},
+		},
+	}
+
+	available, err := getAvailableNamespaces(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"cindy", "pr-123", "okteto"}
+	if !reflect.DeepEqual(available, expected) {
+		t.Errorf("got %v, want %v", available, expected)
+	}
+}
+
+func Test_canGetNamespace(t *testing.T) {
+	var tests = []struct {
+		name     string
+		reactor  k8stesting.ReactionFunc
+		expected bool
+		wantErr  bool
+	}{
+		{
+			name:     "exists",
+			expected: true,
+		},
+		{
+			name: "not-found",
+			reactor: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, k8sErrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "shared")
+			},
+			expected: false,
+		},
+		{
+			name: "forbidden",
+			reactor: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, k8sErrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "shared", fmt.Errorf("forbidden"))
+			},
+			expected: false,
+		},
+		{
+			name: "other-error",
+			reactor: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, fmt.Errorf("connection refused")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset(&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shared"}})
+			if tt.reactor != nil {
+				c.PrependReactor("get", "namespaces", tt.reactor)
+			}
+			got, err := canGetNamespace(context.Background(), "shared", c)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.expected {
+				t.Errorf("got %t, want %t", got, tt.expected)
+			}
+		})
+	}
+}
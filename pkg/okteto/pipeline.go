@@ -22,6 +22,7 @@ import (
 	"github.com/okteto/okteto/pkg/log"
 	"github.com/shurcooL/graphql"
 	giturls "github.com/whilp/git-urls"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // SpaceBody top body answer
@@ -37,10 +38,12 @@ type GitDeployResponse struct {
 
 //GitDeploy represents an Okteto pipeline status
 type GitDeploy struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	Repository string `json:"repository"`
-	Status     string `json:"status"`
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Repository string   `json:"repository"`
+	Status     string   `json:"status"`
+	Branch     string   `json:"branch,omitempty"`
+	Labels     []string `json:"labels,omitempty"`
 }
 
 // Space represents the contents of an Okteto Cloud space
@@ -56,8 +59,11 @@ type Variable struct {
 	Value string `json:"value"`
 }
 
-// DeployPipeline creates a pipeline
-func (c *OktetoClient) DeployPipeline(ctx context.Context, name, repository, branch, filename string, variables []Variable) (*GitDeployResponse, error) {
+// DeployPipeline creates a pipeline. If revision is not empty, the pipeline is deployed from that git revision of the branch
+func (c *OktetoClient) DeployPipeline(ctx context.Context, name, repository, branch, revision, filename string, variables []Variable) (*GitDeployResponse, error) {
+	if revision != "" {
+		return c.deployPipelineRevision(ctx, name, repository, branch, revision, filename, variables)
+	}
 
 	gitDeployResponse := &GitDeployResponse{}
 	if len(variables) > 0 {
@@ -160,6 +166,63 @@ func (c *OktetoClient) DeployPipeline(ctx context.Context, name, repository, bra
 	return gitDeployResponse, nil
 }
 
+func (c *OktetoClient) deployPipelineRevision(ctx context.Context, name, repository, branch, revision, filename string, variables []Variable) (*GitDeployResponse, error) {
+	var mutation struct {
+		GitDeployResponse struct {
+			Action struct {
+				Id     graphql.String
+				Name   graphql.String
+				Status graphql.String
+			}
+			GitDeploy struct {
+				Id         graphql.String
+				Name       graphql.String
+				Status     graphql.String
+				Repository graphql.String
+			}
+		} `graphql:"deployGitRepository(name: $name, repository: $repository, space: $space, branch: $branch, revision: $revision, variables: $variables, filename: $filename)"`
+	}
+	variablesVariable := make([]InputVariable, 0)
+	for _, v := range variables {
+		variablesVariable = append(variablesVariable, InputVariable{
+			Name:  graphql.String(v.Name),
+			Value: graphql.String(v.Value),
+		})
+	}
+	queryVariables := map[string]interface{}{
+		"name":       graphql.String(name),
+		"repository": graphql.String(repository),
+		"space":      graphql.String(Context().Namespace),
+		"branch":     graphql.String(branch),
+		"revision":   graphql.String(revision),
+		"variables":  variablesVariable,
+		"filename":   graphql.String(filename),
+	}
+
+	err := c.client.Mutate(ctx, &mutation, queryVariables)
+	if err != nil {
+		if strings.Contains(err.Error(), "Unknown argument \"revision\"") {
+			return nil, fmt.Errorf("failed to deploy pipeline: your Okteto instance doesn't support deploying a git revision")
+		}
+		return nil, fmt.Errorf("failed to deploy pipeline: %w", translateAPIErr(err))
+	}
+
+	return &GitDeployResponse{
+		Action: &Action{
+			ID:     string(mutation.GitDeployResponse.Action.Id),
+			Name:   string(mutation.GitDeployResponse.Action.Name),
+			Status: string(mutation.GitDeployResponse.Action.Status),
+		},
+		GitDeploy: &GitDeploy{
+			ID:         string(mutation.GitDeployResponse.GitDeploy.Id),
+			Name:       string(mutation.GitDeployResponse.GitDeploy.Name),
+			Repository: string(mutation.GitDeployResponse.GitDeploy.Repository),
+			Status:     string(mutation.GitDeployResponse.GitDeploy.Status),
+			Branch:     branch,
+		},
+	}, nil
+}
+
 func (c *OktetoClient) deprecatedDeployPipeline(ctx context.Context, name, repository, branch, filename string, variables []Variable) (*GitDeployResponse, error) {
 
 	gitDeployResponse := &GitDeployResponse{}
@@ -239,7 +302,7 @@ func (c *OktetoClient) GetPipelineByName(ctx context.Context, name string) (*Git
 	variables := map[string]interface{}{
 		"id": graphql.String(Context().Namespace),
 	}
-	err := c.client.Query(ctx, &query, variables)
+	err := c.query(ctx, &query, variables)
 	if err != nil {
 		return nil, translateAPIErr(err)
 	}
@@ -256,6 +319,64 @@ func (c *OktetoClient) GetPipelineByName(ctx context.Context, name string) (*Git
 	return nil, errors.ErrNotFound
 }
 
+// ListPipelines returns the pipelines of the current namespace matching a label selector
+func (c *OktetoClient) ListPipelines(ctx context.Context, labelSelector string) ([]GitDeploy, error) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector '%s': %w", labelSelector, err)
+	}
+
+	var query struct {
+		Space struct {
+			GitDeploys []struct {
+				Id         graphql.String
+				Name       graphql.String
+				Status     graphql.String
+				Repository graphql.String
+				Labels     []graphql.String
+			}
+		} `graphql:"space(id: $id)"`
+	}
+	variables := map[string]interface{}{
+		"id": graphql.String(Context().Namespace),
+	}
+	if err := c.query(ctx, &query, variables); err != nil {
+		return nil, translateAPIErr(err)
+	}
+
+	result := []GitDeploy{}
+	for _, gitDeploy := range query.Space.GitDeploys {
+		pipelineLabels := make([]string, 0, len(gitDeploy.Labels))
+		for _, l := range gitDeploy.Labels {
+			pipelineLabels = append(pipelineLabels, string(l))
+		}
+		if !selector.Matches(getPipelineLabelSet(pipelineLabels)) {
+			continue
+		}
+		result = append(result, GitDeploy{
+			ID:         string(gitDeploy.Id),
+			Name:       string(gitDeploy.Name),
+			Repository: string(gitDeploy.Repository),
+			Status:     string(gitDeploy.Status),
+			Labels:     pipelineLabels,
+		})
+	}
+	return result, nil
+}
+
+//getPipelineLabelSet converts the pipeline labels into a label set. Labels without value are added with an empty one
+func getPipelineLabelSet(pipelineLabels []string) labels.Set {
+	result := labels.Set{}
+	for _, l := range pipelineLabels {
+		key, value := l, ""
+		if i := strings.Index(l, "="); i >= 0 {
+			key, value = l[:i], l[i+1:]
+		}
+		result[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return result
+}
+
 // GetPipelineByRepository gets a pipeline given its repo url
 func (c *OktetoClient) GetPipelineByRepository(ctx context.Context, repository string) (*GitDeployResponse, error) {
 	var query struct {
@@ -270,7 +391,7 @@ func (c *OktetoClient) GetPipelineByRepository(ctx context.Context, repository s
 	variables := map[string]interface{}{
 		"id": graphql.String(Context().Namespace),
 	}
-	err := c.client.Query(ctx, &query, variables)
+	err := c.query(ctx, &query, variables)
 	if err != nil {
 		return nil, translateAPIErr(err)
 	}
@@ -290,6 +411,44 @@ func (c *OktetoClient) GetPipelineByRepository(ctx context.Context, repository s
 	return nil, errors.ErrNotFound
 }
 
+// GetPipelineByRepositoryAndBranch gets a pipeline given its repo url and the branch it was deployed from
+func (c *OktetoClient) GetPipelineByRepositoryAndBranch(ctx context.Context, repository, branch string) (*GitDeployResponse, error) {
+	var query struct {
+		Pipeline struct {
+			GitDeploys []struct {
+				Id         graphql.String
+				Name       graphql.String
+				Repository graphql.String
+				Branch     graphql.String
+				Status     graphql.String
+			}
+		} `graphql:"space(id: $id)"`
+	}
+	variables := map[string]interface{}{
+		"id": graphql.String(Context().Namespace),
+	}
+	err := c.query(ctx, &query, variables)
+	if err != nil {
+		return nil, translateAPIErr(err)
+	}
+
+	for _, gitDeploy := range query.Pipeline.GitDeploys {
+		if areSameRepository(string(gitDeploy.Repository), repository) && string(gitDeploy.Branch) == branch {
+			pipeline := &GitDeployResponse{
+				GitDeploy: &GitDeploy{
+					ID:         string(gitDeploy.Id),
+					Name:       string(gitDeploy.Name),
+					Repository: string(gitDeploy.Repository),
+					Branch:     string(gitDeploy.Branch),
+					Status:     string(gitDeploy.Status),
+				},
+			}
+			return pipeline, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
 func areSameRepository(repoA, repoB string) bool {
 	parsedRepoA, _ := giturls.Parse(repoA)
 	parsedRepoB, _ := giturls.Parse(repoB)
@@ -306,7 +465,7 @@ func areSameRepository(repoA, repoB string) bool {
 	return repoPathA == repoPathB
 }
 
-// DestroyPipeline destroys a pipeline
+// DestroyPipeline destroys a pipeline. Destroying a pipeline is idempotent, so the call is retried on transient errors
 func (c *OktetoClient) DestroyPipeline(ctx context.Context, name string, destroyVolumes bool) (*GitDeployResponse, error) {
 	log.Infof("destroy pipeline: %s/%s", Context().Namespace, name)
 	gitDeployResponse := &GitDeployResponse{}
@@ -332,7 +491,7 @@ func (c *OktetoClient) DestroyPipeline(ctx context.Context, name string, destroy
 			"destroyVolumes": graphql.Boolean(destroyVolumes),
 			"space":          graphql.String(Context().Namespace),
 		}
-		err := c.client.Mutate(ctx, &mutation, queryVariables)
+		err := c.client.Mutate(withRetries(ctx), &mutation, queryVariables)
 		if err != nil {
 			if strings.Contains(err.Error(), "Cannot query field \"action\" on type \"GitDeploy\"") {
 				return c.deprecatedDestroyPipeline(ctx, name, destroyVolumes)
@@ -371,7 +530,7 @@ func (c *OktetoClient) DestroyPipeline(ctx context.Context, name string, destroy
 			"name":  graphql.String(name),
 			"space": graphql.String(Context().Namespace),
 		}
-		err := c.client.Mutate(ctx, &mutation, queryVariables)
+		err := c.client.Mutate(withRetries(ctx), &mutation, queryVariables)
 		if err != nil {
 			if strings.Contains(err.Error(), "Cannot query field \"action\" on type \"GitDeploy\"") {
 				return c.deprecatedDestroyPipeline(ctx, name, destroyVolumes)
@@ -411,7 +570,7 @@ func (c *OktetoClient) deprecatedDestroyPipeline(ctx context.Context, name strin
 			"destroyVolumes": graphql.Boolean(destroyVolumes),
 			"space":          graphql.String(Context().Namespace),
 		}
-		err := c.client.Mutate(ctx, &mutation, queryVariables)
+		err := c.client.Mutate(withRetries(ctx), &mutation, queryVariables)
 		if err != nil {
 			return nil, fmt.Errorf("failed to deploy pipeline: %w", translateAPIErr(err))
 		}
@@ -431,7 +590,7 @@ func (c *OktetoClient) deprecatedDestroyPipeline(ctx context.Context, name strin
 			"name":  graphql.String(name),
 			"space": graphql.String(Context().Namespace),
 		}
-		err := c.client.Mutate(ctx, &mutation, queryVariables)
+		err := c.client.Mutate(withRetries(ctx), &mutation, queryVariables)
 		if err != nil {
 			if strings.Contains(err.Error(), "Cannot query field \"action\" on type \"GitDeploy\"") {
 				return c.deprecatedDestroyPipeline(ctx, name, destroyVolumes)
@@ -472,7 +631,7 @@ func (c *OktetoClient) GetResourcesStatusFromPipeline(ctx context.Context, name
 		"id": graphql.String(Context().Namespace),
 	}
 
-	err = c.client.Query(ctx, &query, variables)
+	err = c.query(ctx, &query, variables)
 	if err != nil {
 		return nil, translateAPIErr(err)
 	}
@@ -0,0 +1,148 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shurcooL/graphql"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func Test_getPipelineLabelSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   []string
+		selector string
+		expected bool
+	}{
+		{
+			name:     "key-value-match",
+			labels:   []string{"env=preview", "team=web"},
+			selector: "env=preview",
+			expected: true,
+		},
+		{
+			name:     "key-value-mismatch",
+			labels:   []string{"env=staging"},
+			selector: "env=preview",
+			expected: false,
+		},
+		{
+			name:     "plain-label-exists",
+			labels:   []string{"preview"},
+			selector: "preview",
+			expected: true,
+		},
+		{
+			name:     "multiple-requirements",
+			labels:   []string{"preview", "team=web"},
+			selector: "preview,team=api",
+			expected: false,
+		},
+		{
+			name:     "no-labels",
+			labels:   []string{},
+			selector: "preview",
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := labels.Parse(tt.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := selector.Matches(getPipelineLabelSet(tt.labels)); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_deployPipelineRevision(t *testing.T) {
+	var tests = []struct {
+		name     string
+		response string
+		wantErr  string
+	}{
+		{
+			name:     "deployed",
+			response: `{"data":{"deployGitRepository":{"action":{"id":"1","name":"action-1","status":"progressing"},"gitDeploy":{"id":"2","name":"movies","status":"progressing","repository":"https://github.com/okteto/movies"}}}}`,
+		},
+		{
+			name:     "revision-not-supported",
+			response: `{"errors":[{"message":"Unknown argument \"revision\" on field \"deployGitRepository\" of type \"Mutation\"."}]}`,
+			wantErr:  "your Okteto instance doesn't support deploying a git revision",
+		},
+		{
+			name:     "other-error",
+			response: `{"errors":[{"message":"repository not found"}]}`,
+			wantErr:  "failed to deploy pipeline",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			CurrentStore = &OktetoContextStore{
+				CurrentContext: "https://okteto.example.com",
+				Contexts: map[string]*OktetoContext{
+					"https://okteto.example.com": {Name: "https://okteto.example.com", Namespace: "cindy"},
+				},
+			}
+
+			var variables map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Variables map[string]interface{} `json:"variables"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				variables = body.Variables
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			c := &OktetoClient{client: graphql.NewClient(server.URL, server.Client())}
+			resp, err := c.DeployPipeline(context.Background(), "movies", "https://github.com/okteto/movies", "main", "abc123", "okteto-pipeline.yml", []Variable{{Name: "A", Value: "1"}})
+
+			if variables["revision"] != "abc123" || variables["branch"] != "main" || variables["space"] != "cindy" {
+				t.Errorf("unexpected mutation variables: %v", variables)
+			}
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Action.Name != "action-1" {
+				t.Errorf("got action '%s', expected 'action-1'", resp.Action.Name)
+			}
+			if resp.GitDeploy.Name != "movies" || resp.GitDeploy.Branch != "main" {
+				t.Errorf("got pipeline '%s' of branch '%s', expected 'movies' of branch 'main'", resp.GitDeploy.Name, resp.GitDeploy.Branch)
+			}
+		})
+	}
+}
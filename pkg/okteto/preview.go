@@ -258,7 +258,7 @@ func (c *OktetoClient) ListPreviews(ctx context.Context) ([]Preview, error) {
 		} `graphql:"previews"`
 	}
 
-	err := c.client.Query(ctx, &query, nil)
+	err := c.query(ctx, &query, nil)
 	if err != nil {
 		return nil, translateAPIErr(err)
 	}
@@ -296,7 +296,7 @@ func (c *OktetoClient) ListPreviewsEndpoints(ctx context.Context, previewName st
 	}
 	endpoints := make([]Endpoint, 0)
 
-	err := c.client.Query(ctx, &query, variables)
+	err := c.query(ctx, &query, variables)
 	if err != nil {
 		return nil, translateAPIErr(err)
 	}
@@ -334,7 +334,7 @@ func (c *OktetoClient) GetPreviewEnvByName(ctx context.Context, name string) (*G
 	variables := map[string]interface{}{
 		"id": graphql.String(Context().Namespace),
 	}
-	err := c.client.Query(ctx, &query, variables)
+	err := c.query(ctx, &query, variables)
 	if err != nil {
 		return nil, translateAPIErr(err)
 	}
@@ -370,7 +370,7 @@ func (c *OktetoClient) GetResourcesStatusFromPreview(ctx context.Context, previe
 		"id": graphql.String(previewName),
 	}
 
-	err := c.client.Query(ctx, &query, variables)
+	err := c.query(ctx, &query, variables)
 	if err != nil {
 		return nil, translateAPIErr(err)
 	}
@@ -0,0 +1,92 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/okteto/okteto/pkg/errors"
+)
+
+const (
+	// ProxyEnvVar sets the proxy used for the connections to the okteto API, the registry and the builder
+	ProxyEnvVar = "OKTETO_PROXY"
+
+	// CABundleEnvVar sets the path to a PEM bundle of certificate authorities trusted in addition to the system ones
+	CABundleEnvVar = "OKTETO_CA_BUNDLE"
+)
+
+// caBundle is the PEM bundle of additional certificate authorities set with SetCABundle
+var caBundle []byte
+
+// SetProxy routes the connections of okteto through the given proxy.
+// The proxy is exported as HTTPS_PROXY and HTTP_PROXY so every client honors it, together with NO_PROXY
+func SetProxy(proxy string) error {
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" {
+		return errors.UserError{
+			E:    fmt.Errorf("invalid proxy '%s'", proxy),
+			Hint: "Use the format 'http://[user:password@]host:port'",
+		}
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return errors.UserError{
+			E:    fmt.Errorf("invalid proxy '%s': unsupported scheme '%s'", proxy, u.Scheme),
+			Hint: "Supported schemes are 'http', 'https' and 'socks5'",
+		}
+	}
+	for _, name := range []string{"HTTPS_PROXY", "HTTP_PROXY"} {
+		if err := os.Setenv(name, proxy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetCABundle trusts the certificate authorities of the given PEM file, in addition to the system ones.
+// This is needed when a proxy intercepts the TLS connections
+func SetCABundle(path string) error {
+	bundle, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the CA bundle: %w", err)
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(bundle) {
+		return errors.UserError{
+			E:    fmt.Errorf("the CA bundle '%s' doesn't contain any PEM certificate", path),
+			Hint: "Check that the file is a PEM encoded certificate bundle",
+		}
+	}
+	caBundle = bundle
+	return nil
+}
+
+// GetCABundle returns the PEM bundle of additional certificate authorities, if any
+func GetCABundle() []byte {
+	return caBundle
+}
+
+// getRootCAs returns the system certificate authorities and the ones of the CA bundle
+func getRootCAs() *x509.CertPool {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	pool.AppendCertsFromPEM(caBundle)
+	return pool
+}
@@ -0,0 +1,179 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// apiRetriesEnvVar sets the number of retries of idempotent calls to the okteto API. 0 disables retries
+	apiRetriesEnvVar = "OKTETO_API_RETRIES"
+
+	// apiRetryBackoffEnvVar sets the initial wait between retries, doubled after every retry
+	apiRetryBackoffEnvVar = "OKTETO_API_RETRY_BACKOFF"
+
+	// apiRetryStatusCodesEnvVar sets the comma-separated list of status codes that are retried
+	apiRetryStatusCodesEnvVar = "OKTETO_API_RETRY_STATUS_CODES"
+)
+
+type retryContextKey struct{}
+
+// RetryPolicy defines how idempotent calls to the okteto API are retried
+type RetryPolicy struct {
+	Retries     int
+	Backoff     time.Duration
+	StatusCodes map[int]bool
+}
+
+// retryTransport retries the requests whose context is marked with withRetries
+type retryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+func defaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Retries: 3,
+		Backoff: time.Second,
+		StatusCodes: map[int]bool{
+			http.StatusBadGateway:         true,
+			http.StatusServiceUnavailable: true,
+			http.StatusGatewayTimeout:     true,
+		},
+	}
+}
+
+// getRetryPolicy returns the default retry policy overridden by the OKTETO_API_RETRY* environment variables
+func getRetryPolicy() RetryPolicy {
+	policy := defaultRetryPolicy()
+	if v := os.Getenv(apiRetriesEnvVar); v != "" {
+		retries, err := strconv.Atoi(v)
+		if err != nil || retries < 0 {
+			log.Infof("ignoring invalid value of %s: '%s'", apiRetriesEnvVar, v)
+		} else {
+			policy.Retries = retries
+		}
+	}
+	if v := os.Getenv(apiRetryBackoffEnvVar); v != "" {
+		backoff, err := time.ParseDuration(v)
+		if err != nil || backoff < 0 {
+			log.Infof("ignoring invalid value of %s: '%s'", apiRetryBackoffEnvVar, v)
+		} else {
+			policy.Backoff = backoff
+		}
+	}
+	if v := os.Getenv(apiRetryStatusCodesEnvVar); v != "" {
+		statusCodes := map[int]bool{}
+		for _, s := range strings.Split(v, ",") {
+			code, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				log.Infof("ignoring invalid value of %s: '%s'", apiRetryStatusCodesEnvVar, v)
+				statusCodes = nil
+				break
+			}
+			statusCodes[code] = true
+		}
+		if statusCodes != nil {
+			policy.StatusCodes = statusCodes
+		}
+	}
+	return policy
+}
+
+// withRetries marks the calls done with the returned context as safe to retry.
+// Only idempotent calls should be retried, mutations are not retried by default
+func withRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryContextKey{}, true)
+}
+
+func isRetryable(ctx context.Context) bool {
+	retry, ok := ctx.Value(retryContextKey{}).(bool)
+	return ok && retry
+}
+
+func newRetryTransport(base http.RoundTripper) *retryTransport {
+	return &retryTransport{
+		base:   base,
+		policy: getRetryPolicy(),
+		sleep:  sleepWithContext,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.policy.Retries == 0 || !isRetryable(req.Context()) || (req.Body != nil && req.GetBody == nil) {
+		return t.base.RoundTrip(req)
+	}
+
+	backoff := t.policy.Backoff
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 {
+			r = req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+
+		resp, err := t.base.RoundTrip(r)
+		if attempt == t.policy.Retries || !t.shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		if err != nil {
+			log.Infof("call to the okteto API failed, retrying in %s: %s", backoff, err)
+		} else {
+			log.Infof("call to the okteto API returned '%s', retrying in %s", resp.Status, backoff)
+			io.Copy(io.Discard, resp.Body) // #nosec G104
+			resp.Body.Close()
+		}
+
+		if err := t.sleep(req.Context(), backoff); err != nil {
+			return nil, err
+		}
+		backoff *= 2
+	}
+}
+
+func (t *retryTransport) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return resp == nil
+	}
+	return t.policy.StatusCodes[resp.StatusCode]
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
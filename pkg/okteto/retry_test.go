@@ -0,0 +1,131 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_retryTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		retryable bool
+		statuses  []int
+		want      int
+		wantCalls int
+	}{
+		{
+			name:      "retried-until-success",
+			retryable: true,
+			statuses:  []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			want:      http.StatusOK,
+			wantCalls: 3,
+		},
+		{
+			name:      "retries-exhausted",
+			retryable: true,
+			statuses:  []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			want:      http.StatusBadGateway,
+			wantCalls: 3,
+		},
+		{
+			name:      "not-retryable-status-code",
+			retryable: true,
+			statuses:  []int{http.StatusInternalServerError, http.StatusOK},
+			want:      http.StatusInternalServerError,
+			wantCalls: 1,
+		},
+		{
+			name:      "mutation-not-retried",
+			retryable: false,
+			statuses:  []int{http.StatusBadGateway, http.StatusOK},
+			want:      http.StatusBadGateway,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != "query" {
+					t.Errorf("got body '%s' in call %d", body, calls)
+				}
+				w.WriteHeader(tt.statuses[calls])
+				calls++
+			}))
+			defer server.Close()
+
+			transport := &retryTransport{
+				base: http.DefaultTransport,
+				policy: RetryPolicy{
+					Retries:     2,
+					Backoff:     time.Millisecond,
+					StatusCodes: defaultRetryPolicy().StatusCodes,
+				},
+				sleep: func(_ context.Context, _ time.Duration) error { return nil },
+			}
+			ctx := context.Background()
+			if tt.retryable {
+				ctx = withRetries(ctx)
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader("query"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := (&http.Client{Transport: transport}).Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("got status %d, want %d", resp.StatusCode, tt.want)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func Test_getRetryPolicy(t *testing.T) {
+	t.Setenv(apiRetriesEnvVar, "0")
+	t.Setenv(apiRetryBackoffEnvVar, "250ms")
+	t.Setenv(apiRetryStatusCodesEnvVar, "500, 502")
+	policy := getRetryPolicy()
+	if policy.Retries != 0 {
+		t.Errorf("got %d retries, want 0", policy.Retries)
+	}
+	if policy.Backoff != 250*time.Millisecond {
+		t.Errorf("got backoff %s, want 250ms", policy.Backoff)
+	}
+	if len(policy.StatusCodes) != 2 || !policy.StatusCodes[500] || !policy.StatusCodes[502] {
+		t.Errorf("got status codes %v", policy.StatusCodes)
+	}
+
+	t.Setenv(apiRetriesEnvVar, "many")
+	t.Setenv(apiRetryBackoffEnvVar, "soon")
+	t.Setenv(apiRetryStatusCodesEnvVar, "bad")
+	policy = getRetryPolicy()
+	defaultPolicy := defaultRetryPolicy()
+	if policy.Retries != defaultPolicy.Retries || policy.Backoff != defaultPolicy.Backoff || len(policy.StatusCodes) != len(defaultPolicy.StatusCodes) {
+		t.Errorf("invalid values should fall back to the default policy, got %+v", policy)
+	}
+}
@@ -40,7 +40,7 @@ func (c *OktetoClient) GetSecrets(ctx context.Context) ([]Secret, error) {
 		} `graphql:"getGitDeploySecrets"`
 	}
 
-	err := c.client.Query(ctx, &query, nil)
+	err := c.query(ctx, &query, nil)
 	if err != nil {
 		return nil, translateAPIErr(err)
 	}
@@ -74,7 +74,7 @@ func (c *OktetoClient) GetSecretsAndKubeCredentials(ctx context.Context) (*Secre
 	variables := map[string]interface{}{
 		"cred": graphql.String(""),
 	}
-	err := c.client.Query(ctx, &query, variables)
+	err := c.query(ctx, &query, variables)
 	if err != nil {
 		return nil, translateAPIErr(err)
 	}
@@ -19,12 +19,14 @@ import (
 
 	"github.com/heroku/docker-registry-client/registry"
 	"github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
 )
 
 // NewRegistryClient creates a new Registry with the given URL and credentials, then Ping()s it
 // before returning it to verify that the registry is available.
+// The TLS settings are the ones of the current okteto context
 func NewRegistryClient(registryURL, username, password string) (*registry.Registry, error) {
-	transport := http.DefaultTransport
+	transport := okteto.GetTransport(okteto.Context().Name)
 	return newFromTransport(registryURL, username, password, transport)
 }
 
@@ -0,0 +1,73 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+func TestNewRegistryClientInsecureContext(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var tests = []struct {
+		name     string
+		insecure bool
+		wantErr  bool
+	}{
+		{
+			name:     "insecure-context",
+			insecure: true,
+			wantErr:  false,
+		},
+		{
+			name:     "secure-context",
+			insecure: false,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			okteto.CurrentStore = &okteto.OktetoContextStore{
+				CurrentContext: "https://okteto.example.com",
+				Contexts: map[string]*okteto.OktetoContext{
+					"https://okteto.example.com": {
+						Name:     "https://okteto.example.com",
+						Registry: server.URL,
+						Insecure: tt.insecure,
+					},
+				},
+			}
+
+			c, err := NewRegistryClient(server.URL, "user", "token")
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = c.Ping()
+			if tt.wantErr && err == nil {
+				t.Error("expected a TLS error with a secure context")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error with an insecure context: %s", err)
+			}
+		})
+	}
+}
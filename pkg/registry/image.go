@@ -62,3 +62,9 @@ func GetDevImageTag(dev *model.Dev, imageTag, imageFromDeployment, oktetoRegistr
 	}
 	return GetImageTag(imageFromDeployment, dev.Name, dev.Namespace, oktetoRegistryURL)
 }
+
+// GetImageWithDigest returns the immutable reference of an image tag given its digest
+func GetImageWithDigest(imageTag, digest string) string {
+	repo, _ := GetRepoNameAndTag(imageTag)
+	return fmt.Sprintf("%s@%s", repo, digest)
+}
@@ -277,3 +277,38 @@ func Test_GetResgistryAndRepo(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetImageWithDigest(t *testing.T) {
+	var tests = []struct {
+		name     string
+		image    string
+		digest   string
+		expected string
+	}{
+		{
+			name:     "with-tag",
+			image:    "registry.okteto.dev/cindy/app:okteto",
+			digest:   "sha256:1234",
+			expected: "registry.okteto.dev/cindy/app@sha256:1234",
+		},
+		{
+			name:     "without-tag",
+			image:    "okteto/app",
+			digest:   "sha256:1234",
+			expected: "okteto/app@sha256:1234",
+		},
+		{
+			name:     "registry-with-port",
+			image:    "localhost:5000/app:1.0",
+			digest:   "sha256:1234",
+			expected: "localhost:5000/app@sha256:1234",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := GetImageWithDigest(tt.image, tt.digest); result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
@@ -56,7 +56,7 @@ func GetImageTagWithDigest(imageTag string) (string, error) {
 		return imageTag, nil
 	}
 	u.Scheme = "https"
-	c, err := NewRegistryClient(u.String(), username, okteto.Context().Token)
+	c, err := newFromTransport(u.String(), username, okteto.Context().Token, okteto.GetTransport(okteto.Context().Name))
 	if err != nil {
 		log.Infof("error creating registry client: %s", err.Error())
 		return imageTag, nil
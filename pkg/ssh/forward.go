@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"os"
 	"sync"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 type forward struct {
 	localAddress  string
 	remoteAddress string
+	socket        bool
 	c             bool
 	lock          sync.Mutex
 	pool          *pool
@@ -52,7 +54,7 @@ func (f *forward) setDisconnected() {
 }
 
 func (f *forward) start(ctx context.Context) {
-	localListener, err := net.Listen("tcp", f.localAddress)
+	localListener, err := f.listen()
 	if err != nil {
 		log.Infof("%s -> failed to listen: %s", f.String(), err)
 		return
@@ -87,6 +89,19 @@ func (f *forward) start(ctx context.Context) {
 
 }
 
+// listen opens the local listener of the forward.
+// The socket file of a local UNIX socket is removed when the listener is closed
+func (f *forward) listen() (net.Listener, error) {
+	if !f.socket {
+		return net.Listen("tcp", f.localAddress)
+	}
+
+	if err := os.Remove(f.localAddress); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return net.Listen("unix", f.localAddress)
+}
+
 func (f *forward) handle(local net.Conn) {
 	defer local.Close()
 
@@ -30,6 +30,7 @@ type ForwardManager struct {
 	localInterface  string
 	remoteInterface string
 	forwards        map[int]*forward
+	sockets         map[string]*forward
 	reverses        map[int]*reverse
 	ctx             context.Context
 	sshAddr         string
@@ -45,6 +46,7 @@ func NewForwardManager(ctx context.Context, sshAddr, localInterface, remoteInter
 		localInterface:  localInterface,
 		remoteInterface: remoteInterface,
 		forwards:        make(map[int]*forward),
+		sockets:         make(map[string]*forward),
 		reverses:        make(map[int]*reverse),
 		sshAddr:         sshAddr,
 		pf:              pf,
@@ -52,7 +54,7 @@ func NewForwardManager(ctx context.Context, sshAddr, localInterface, remoteInter
 	}
 }
 
-func (fm *ForwardManager) canAdd(localPort int, checkAvailable bool) error {
+func (fm *ForwardManager) canAdd(localAddress string, localPort int, checkAvailable bool) error {
 	if _, ok := fm.reverses[localPort]; ok {
 		return fmt.Errorf("port %d is listed multiple times, please check your reverse forwards configuration", localPort)
 	}
@@ -65,12 +67,12 @@ func (fm *ForwardManager) canAdd(localPort int, checkAvailable bool) error {
 		return nil
 	}
 
-	if !model.IsPortAvailable(fm.localInterface, localPort) {
+	if !model.IsPortAvailable(localAddress, localPort) {
 		if localPort <= 1024 {
 			os := runtime.GOOS
 			switch os {
 			case "darwin":
-				if fm.localInterface == model.Localhost {
+				if localAddress == model.Localhost {
 					return fmt.Errorf("local port %d is privileged. Define 'interface: 0.0.0.0' in your okteto manifest and try again", localPort)
 				}
 			case "linux":
@@ -83,15 +85,31 @@ func (fm *ForwardManager) canAdd(localPort int, checkAvailable bool) error {
 	return nil
 }
 
+func (fm *ForwardManager) canAddSocket(path string) error {
+	if _, ok := fm.sockets[path]; ok {
+		return fmt.Errorf("socket %s is listed multiple times, please check your forwards configuration", path)
+	}
+
+	return model.CheckSocketAvailable(path)
+}
+
 // Add initializes a remote forward
 func (fm *ForwardManager) Add(f model.Forward) error {
+	if f.IsSocket() {
+		return fm.addSocket(f)
+	}
+
+	localAddress := fm.localInterface
+	if f.BindAddress != "" {
+		localAddress = f.BindAddress
+	}
 
-	if err := fm.canAdd(f.Local, true); err != nil {
+	if err := fm.canAdd(localAddress, f.Local, true); err != nil {
 		return err
 	}
 
 	fm.forwards[f.Local] = &forward{
-		localAddress:  fmt.Sprintf("%s:%d", fm.localInterface, f.Local),
+		localAddress:  fmt.Sprintf("%s:%d", localAddress, f.Local),
 		remoteAddress: fmt.Sprintf("%s:%d", fm.remoteInterface, f.Remote),
 	}
 
@@ -102,6 +120,25 @@ func (fm *ForwardManager) Add(f model.Forward) error {
 	return nil
 }
 
+// addSocket initializes a remote forward from a local UNIX socket
+func (fm *ForwardManager) addSocket(f model.Forward) error {
+	if err := fm.canAddSocket(f.Socket); err != nil {
+		return err
+	}
+
+	fm.sockets[f.Socket] = &forward{
+		localAddress:  f.Socket,
+		remoteAddress: fmt.Sprintf("%s:%d", fm.remoteInterface, f.Remote),
+		socket:        true,
+	}
+
+	if f.Service {
+		fm.sockets[f.Socket].remoteAddress = fmt.Sprintf("%s:%d", f.ServiceName, f.Remote)
+	}
+
+	return nil
+}
+
 // Start starts a port-forward to the remote port and then starts forwards and reverse forwards as goroutines
 func (fm *ForwardManager) Start(devPod, namespace string) error {
 	log.Info("starting SSH forward manager")
@@ -157,6 +194,11 @@ func (fm *ForwardManager) Start(devPod, namespace string) error {
 
 	}
 
+	for _, ff := range fm.sockets {
+		ff.pool = fm.pool
+		go ff.start(fm.ctx)
+	}
+
 	for _, rt := range fm.reverses {
 		rt.pool = fm.pool
 		go rt.start(fm.ctx)
@@ -17,7 +17,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -105,6 +108,72 @@ func TestForward(t *testing.T) {
 	}
 }
 
+func TestForwardSocket(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sshPort, err := model.GetAvailablePort(model.Localhost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sshAddr := fmt.Sprintf("localhost:%d", sshPort)
+	ssh := testSSHHandler{}
+	go ssh.listenAndServe(sshAddr)
+	fm := NewForwardManager(ctx, sshAddr, model.Localhost, "0.0.0.0", nil, "")
+
+	remote, err := model.GetAvailablePort(model.Localhost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		handler := &testHTTPHandler{message: fmt.Sprintf("%d", remote)}
+		_ = http.ListenAndServe(fmt.Sprintf(":%d", remote), handler)
+	}()
+
+	path := filepath.Join(t.TempDir(), "app.sock")
+	if err := fm.Add(model.Forward{Socket: path, Remote: remote}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fm.Start("", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	f := fm.sockets[path]
+	for i := 0; i < 100 && !f.connected(); i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", path)
+			},
+		},
+	}
+	r, err := client.Get("http://socket")
+	if err != nil {
+		t.Fatalf("%s failed: %s", f.String(), err)
+	}
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != fmt.Sprintf("%d", remote) {
+		t.Errorf("%s got: %s, expected: %d", f.String(), body, remote)
+	}
+
+	cancel()
+	fm.Stop()
+	for i := 0; i < 100 && f.connected(); i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("socket %s wasn't removed: %v", path, err)
+	}
+}
+
 func TestReverse(t *testing.T) {
 	ctx := context.Background()
 	sshPort, err := model.GetAvailablePort(model.Localhost)
@@ -28,7 +28,7 @@ const configXML = `<configuration version="32">
     <ignoreDelete>{{ $.IgnoreDelete }}</ignoreDelete>
     <scanProgressIntervalS>1</scanProgressIntervalS>
     <pullerPauseS>0</pullerPauseS>
-    <maxConflicts>0</maxConflicts>
+    <maxConflicts>{{ $.MaxConflicts }}</maxConflicts>
     <disableSparseFiles>false</disableSparseFiles>
     <disableTempIndexes>false</disableTempIndexes>
     <paused>false</paused>
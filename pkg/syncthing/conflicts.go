@@ -0,0 +1,116 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/okteto/okteto/pkg/log"
+)
+
+// conflictFileRegex matches the conflict copies created by syncthing, e.g. main.sync-conflict-20210101-120000-ABCDEFG.go
+var conflictFileRegex = regexp.MustCompile(`\.sync-conflict-\d{8}-\d{6}-[A-Z0-9]{7}`)
+
+// MonitorConflicts periodically moves the conflict copies of the synchronized folders to the conflicts directory
+func (s *Syncthing) MonitorConflicts(ctx context.Context) {
+	if s.ConflictsDir == "" {
+		return
+	}
+	ticker := time.NewTicker(10 * time.Second)
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.MoveConflicts(); err != nil {
+				log.Infof("failed to move sync conflicts to '%s': %s", s.ConflictsDir, err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// MoveConflicts moves the conflict copies of the synchronized folders to the conflicts directory.
+// Each folder keeps its relative paths under a directory named after it.
+func (s *Syncthing) MoveConflicts() error {
+	if s.ConflictsDir == "" {
+		return nil
+	}
+	for _, folder := range s.Folders {
+		if err := moveFolderConflicts(folder.LocalPath, s.ConflictsDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetConflicts returns the conflict copies that are still in the synchronized folders
+func (s *Syncthing) GetConflicts() ([]string, error) {
+	conflicts := []string{}
+	for _, folder := range s.Folders {
+		err := walkFolderConflicts(folder.LocalPath, func(path string) error {
+			conflicts = append(conflicts, path)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return conflicts, nil
+}
+
+func moveFolderConflicts(localPath, conflictsDir string) error {
+	return walkFolderConflicts(localPath, func(path string) error {
+		rel, err := filepath.Rel(localPath, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(conflictsDir, filepath.Base(localPath), rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return fmt.Errorf("failed to create '%s': %w", filepath.Dir(target), err)
+		}
+		if err := os.Rename(path, target); err != nil {
+			return fmt.Errorf("failed to move '%s': %w", path, err)
+		}
+		log.Infof("moved sync conflict '%s' to '%s'", path, target)
+		return nil
+	})
+}
+
+// walkFolderConflicts calls fn for every conflict copy of a synchronized folder
+func walkFolderConflicts(localPath string, fn func(path string) error) error {
+	return filepath.WalkDir(localPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".stfolder" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !conflictFileRegex.MatchString(d.Name()) {
+			return nil
+		}
+		return fn(path)
+	})
+}
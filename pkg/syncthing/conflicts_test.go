@@ -0,0 +1,99 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMoveConflicts(t *testing.T) {
+	dir := t.TempDir()
+	localPath := filepath.Join(dir, "app")
+	conflictsDir := filepath.Join(dir, "conflicts")
+
+	files := []string{
+		"main.go",
+		"main.sync-conflict-20210101-120000-ABCDEFG.go",
+		filepath.Join("pkg", "util.sync-conflict-20210101-120000-ABCDEFG.go"),
+		filepath.Join("pkg", "sync-conflict.go"),
+	}
+	for _, f := range files {
+		path := filepath.Join(localPath, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(f), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &Syncthing{
+		ConflictsDir: conflictsDir,
+		Folders:      []*Folder{{Name: "1", LocalPath: localPath}},
+	}
+	if err := s.MoveConflicts(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []string{"main.go", filepath.Join("pkg", "sync-conflict.go")} {
+		if _, err := os.Stat(filepath.Join(localPath, f)); err != nil {
+			t.Errorf("'%s' shouldn't have been moved: %s", f, err)
+		}
+	}
+	for _, f := range []string{files[1], files[2]} {
+		if _, err := os.Stat(filepath.Join(localPath, f)); !os.IsNotExist(err) {
+			t.Errorf("'%s' should have been moved", f)
+		}
+		if _, err := os.Stat(filepath.Join(conflictsDir, "app", f)); err != nil {
+			t.Errorf("'%s' not found in the conflicts directory: %s", f, err)
+		}
+	}
+}
+
+func TestGetConflicts(t *testing.T) {
+	dir := t.TempDir()
+	localPath := filepath.Join(dir, "app")
+
+	files := []string{
+		"main.go",
+		"main.sync-conflict-20210101-120000-ABCDEFG.go",
+		filepath.Join(".stfolder", "main.sync-conflict-20210101-120000-ABCDEFG.go"),
+	}
+	for _, f := range files {
+		path := filepath.Join(localPath, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(f), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &Syncthing{
+		Folders: []*Folder{
+			{Name: "1", LocalPath: localPath},
+			{Name: "2", LocalPath: filepath.Join(dir, "missing")},
+		},
+	}
+	conflicts, err := s.GetConflicts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := filepath.Join(localPath, files[1])
+	if len(conflicts) != 1 || conflicts[0] != expected {
+		t.Errorf("expected conflicts [%s], got %v", expected, conflicts)
+	}
+}
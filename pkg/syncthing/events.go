@@ -0,0 +1,140 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// SyncDirectionUp is the direction of the files synchronized from the local folder to the development container
+	SyncDirectionUp = "up"
+
+	// SyncDirectionDown is the direction of the files synchronized from the development container to the local folder
+	SyncDirectionDown = "down"
+)
+
+// SyncedItem represents a file synchronized by syncthing
+type SyncedItem struct {
+	Folder    string
+	Path      string
+	Action    string
+	Direction string
+	Error     string
+}
+
+// itemFinishedEvent represents an ItemFinished event in syncthing.
+type itemFinishedEvent struct {
+	Id   int `json:"id"`
+	Data struct {
+		Item   string  `json:"item"`
+		Folder string  `json:"folder"`
+		Error  *string `json:"error"`
+		Action string  `json:"action"`
+	} `json:"data"`
+}
+
+// MonitorSyncedItems reports every file synchronized by the local and remote syncthing until the context is canceled.
+// Files finished by the remote syncthing were synchronized up, files finished by the local syncthing were synchronized down
+func (s *Syncthing) MonitorSyncedItems(ctx context.Context, report func(SyncedItem)) {
+	since := map[bool]int{
+		true:  s.getLastItemFinishedID(ctx, true),
+		false: s.getLastItemFinishedID(ctx, false),
+	}
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for _, local := range []bool{false, true} {
+				items, last, err := s.getSyncedItems(ctx, local, since[local])
+				if err != nil {
+					log.Infof("failed to get syncthing events local=%t: %s", local, err)
+					continue
+				}
+				since[local] = last
+				for _, item := range items {
+					report(item)
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Syncthing) getLastItemFinishedID(ctx context.Context, local bool) int {
+	events, err := s.getItemFinishedEvents(ctx, local, 0, 1)
+	if err != nil || len(events) == 0 {
+		return 0
+	}
+	return events[len(events)-1].Id
+}
+
+// getSyncedItems returns the files synchronized since the event with the given id and the id of the last event
+func (s *Syncthing) getSyncedItems(ctx context.Context, local bool, since int) ([]SyncedItem, int, error) {
+	events, err := s.getItemFinishedEvents(ctx, local, since, 0)
+	if err != nil {
+		return nil, since, err
+	}
+
+	direction := SyncDirectionUp
+	if local {
+		direction = SyncDirectionDown
+	}
+
+	result := []SyncedItem{}
+	for _, e := range events {
+		if e.Id > since {
+			since = e.Id
+		}
+		item := SyncedItem{
+			Folder:    e.Data.Folder,
+			Path:      e.Data.Item,
+			Action:    e.Data.Action,
+			Direction: direction,
+		}
+		if e.Data.Error != nil {
+			item.Error = *e.Data.Error
+		}
+		result = append(result, item)
+	}
+	return result, since, nil
+}
+
+func (s *Syncthing) getItemFinishedEvents(ctx context.Context, local bool, since, limit int) ([]itemFinishedEvent, error) {
+	params := map[string]string{
+		"since":   strconv.Itoa(since),
+		"timeout": "0",
+		"events":  "ItemFinished",
+	}
+	if limit > 0 {
+		params["limit"] = strconv.Itoa(limit)
+	}
+	body, err := s.APICall(ctx, "rest/events", "GET", 200, params, local, nil, true, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []itemFinishedEvent{}
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetSyncedItems(t *testing.T) {
+	var gotSince string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSince = r.URL.Query().Get("since")
+		if r.URL.Query().Get("events") != "ItemFinished" {
+			t.Errorf("unexpected events filter: %s", r.URL.Query().Get("events"))
+		}
+		fmt.Fprint(w, `[
+			{"id": 12, "type": "ItemFinished", "data": {"item": "src/main.go", "folder": "okteto-1", "error": null, "action": "update"}},
+			{"id": 15, "type": "ItemFinished", "data": {"item": "old.txt", "folder": "okteto-1", "error": "permission denied", "action": "delete"}}
+		]`)
+	}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	s := &Syncthing{
+		GUIAddress:       address,
+		RemoteGUIAddress: address,
+		Client:           &http.Client{},
+	}
+
+	var tests = []struct {
+		name      string
+		local     bool
+		direction string
+	}{
+		{name: "remote", local: false, direction: SyncDirectionUp},
+		{name: "local", local: true, direction: SyncDirectionDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, last, err := s.getSyncedItems(context.Background(), tt.local, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotSince != "10" {
+				t.Errorf("got since '%s', want '10'", gotSince)
+			}
+			if last != 15 {
+				t.Errorf("got last event %d, want 15", last)
+			}
+			if len(items) != 2 {
+				t.Fatalf("got %d items, want 2", len(items))
+			}
+			if items[0].Path != "src/main.go" || items[0].Action != "update" || items[0].Error != "" || items[0].Direction != tt.direction {
+				t.Errorf("unexpected item: %+v", items[0])
+			}
+			if items[1].Path != "old.txt" || items[1].Error != "permission denied" || items[1].Direction != tt.direction {
+				t.Errorf("unexpected item: %+v", items[1])
+			}
+		})
+	}
+}
@@ -50,6 +50,7 @@ const (
 	keyFile    = "key.pem"
 	configFile = "config.xml"
 	logFile    = "syncthing.log"
+	indexGlob  = "index-*.db"
 
 	// DefaultRemoteDeviceID remote syncthing device ID
 	DefaultRemoteDeviceID = "ATOPHFJ-VPVLDFY-QVZDCF2-OQQ7IOW-OG4DIXF-OA7RWU3-ZYA4S22-SI4XVAU"
@@ -95,6 +96,8 @@ type Syncthing struct {
 	pid              int           `yaml:"-"`
 	RescanInterval   string        `yaml:"-"`
 	Compression      string        `yaml:"-"`
+	MaxConflicts     string        `yaml:"-"`
+	ConflictsDir     string        `yaml:"-"`
 	timeout          time.Duration `yaml:"-"`
 }
 
@@ -186,6 +189,12 @@ func New(dev *model.Dev) (*Syncthing, error) {
 	if dev.Sync.Compression {
 		compression = "always"
 	}
+
+	// conflict copies are only kept in the local folder, when there is a directory to collect them
+	maxConflicts := "0"
+	if dev.Sync.ConflictsDir != "" {
+		maxConflicts = "-1"
+	}
 	s := &Syncthing{
 		APIKey:           "cnd",
 		GUIPassword:      pwd,
@@ -210,6 +219,8 @@ func New(dev *model.Dev) (*Syncthing, error) {
 		Folders:          []*Folder{},
 		RescanInterval:   strconv.Itoa(dev.Sync.RescanInterval),
 		Compression:      compression,
+		MaxConflicts:     maxConflicts,
+		ConflictsDir:     dev.Sync.ConflictsDir,
 		timeout:          time.Duration(dev.Timeout.Default),
 	}
 	index := 1
@@ -822,6 +833,23 @@ func GetFolderName(folder *Folder) string {
 	return fmt.Sprintf("okteto-%s", folder.Name)
 }
 
+// RemoveDatabase removes the local syncthing index and configuration of a development container.
+// The next syncthing run starts from scratch and scans all the files again
+func RemoveDatabase(dev *model.Dev) error {
+	home := config.GetAppHome(dev.Namespace, dev.Name)
+	paths, err := filepath.Glob(filepath.Join(home, indexGlob))
+	if err != nil {
+		return err
+	}
+	paths = append(paths, filepath.Join(home, configFile), getInfoFile(dev.Namespace, dev.Name))
+	for _, p := range paths {
+		if err := os.RemoveAll(p); err != nil {
+			return fmt.Errorf("failed to remove '%s': %w", p, err)
+		}
+	}
+	return nil
+}
+
 func getInfoFile(namespace, name string) string {
 	return filepath.Join(config.GetAppHome(namespace, name), "syncthing.info")
 }
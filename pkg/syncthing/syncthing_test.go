@@ -16,7 +16,11 @@ package syncthing
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/model"
 )
 
 func TestGetFiles(t *testing.T) {
@@ -44,3 +48,68 @@ func TestGetFiles(t *testing.T) {
 		t.Errorf("got %s, expected %s", info, expected)
 	}
 }
+
+func TestUpdateConfigRescanInterval(t *testing.T) {
+	s := &Syncthing{
+		Home:           t.TempDir(),
+		Type:           "sendreceive",
+		RescanInterval: "10",
+		Folders:        []*Folder{{Name: "1", LocalPath: "/app"}},
+	}
+
+	if err := s.UpdateConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(s.Home, configFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(b), `<folder id="okteto-1" label="1" path="/app" type="sendreceive" rescanIntervalS="10"`) {
+		t.Errorf("rescan interval not written to the folder config:\n%s", string(b))
+	}
+}
+
+func TestRemoveDatabase(t *testing.T) {
+	t.Setenv("OKTETO_FOLDER", t.TempDir())
+	dev := &model.Dev{Name: "application", Namespace: "test"}
+	home := config.GetAppHome(dev.Namespace, dev.Name)
+
+	removed := []string{
+		filepath.Join(home, "index-v0.14.0.db", "000001.log"),
+		filepath.Join(home, configFile),
+		getInfoFile(dev.Namespace, dev.Name),
+	}
+	kept := []string{
+		filepath.Join(home, certFile),
+		filepath.Join(home, "okteto.log"),
+	}
+	for _, p := range append(removed, kept...) {
+		if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("content"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := RemoveDatabase(dev); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range removed {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("'%s' wasn't removed", p)
+		}
+	}
+	for _, p := range kept {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("'%s' was removed: %s", p, err)
+		}
+	}
+
+	if err := RemoveDatabase(dev); err != nil {
+		t.Errorf("removing a missing database failed: %s", err)
+	}
+}